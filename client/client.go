@@ -2,16 +2,16 @@ package client
 
 import (
 	"crypto/rand"
-	"encoding/binary"
+	"crypto/rsa"
 	"errors"
 	"io"
 	"log"
-	"math"
 	"math/big"
 	"runtime"
 	"runtime/debug"
-	"sort"
 	"strconv"
+	"strings"
+	"sync"
 
 	"golang.org/x/crypto/nacl/box"
 	"golang.org/x/net/context"
@@ -20,7 +20,7 @@ import (
 	"github.com/kwonalbert/xrd/config"
 	"github.com/kwonalbert/xrd/mailbox"
 	"github.com/kwonalbert/xrd/mixnet"
-	"github.com/kwonalbert/xrd/mixnet/verifiable_mixnet"	
+	"github.com/kwonalbert/xrd/mixnet/verifiable_mixnet"
 	"github.com/kwonalbert/xrd/span"
 )
 
@@ -30,112 +30,112 @@ type client struct {
 	publicKeys  []*[32]byte
 	privateKeys []*[32]byte
 
+	assigner    *config.Assigner
 	assignments map[[32]byte][]*config.Group
-	maxLoad     int
 
 	mailboxes map[string]*config.Server
 	servers   map[string]*config.Server
 	groups    map[string]*config.Group
 
+	// placement of the inboxes on the mailboxes
+	ring *mailbox.Ring
+	// download with PIR, which needs every inbox at every mailbox
+	pir bool
+	// long-term identities of the users, or nil for new ones every round
+	keys Keystore
+	// tokens of each user for its chains, if the round needs them
+	userTokens map[[32]byte][][]byte
+
 	ciphertexts map[string][][]byte
 	prfs        map[string][][]byte
+	tokens      map[string][][]byte
+	// size of the messages of the round, to check the deliveries
+	msgSize int
 }
 
 // n: number of virtual clients this will handle
-func NewClient(mailboxes, servers map[string]*config.Server, groups map[string]*config.Group) ClientServer {
+// replicas: number of mailboxes holding each inbox
+// pir: whether to hide which inboxes are downloaded from the mailboxes,
+// which needs all mailboxes to replicate all inboxes
+// keys: where the identities of the users are, so the same users come
+// back every round (nil for throwaway users)
+func NewClient(mailboxes, servers map[string]*config.Server, groups map[string]*config.Group, replicas int, pir bool, keys Keystore) ClientServer {
+	if pir && len(mailboxes) < 2 {
+		panic("PIR needs at least two mailboxes")
+	}
+	if pir && replicas < len(mailboxes) {
+		panic("PIR needs every inbox at every mailbox")
+	}
 	c := &client{
 		mailboxes: mailboxes,
 		servers:   servers,
 		groups:    groups,
+		assigner:  config.NewAssigner(groups),
+		ring:      mailbox.NewRing(mailboxes, replicas),
+		pir:       pir,
+		keys:      keys,
 	}
 	return c
 }
 
-func (clt *client) setupRound(round int, n int) {
+func (clt *client) setupRound(round int, n int) error {
 	pubs, privs := make([]*[32]byte, n), make([]*[32]byte, n)
 	var err error
-	for i := range pubs {
-		pubs[i], privs[i], err = box.GenerateKey(rand.Reader)
+	if clt.keys != nil {
+		pubs, privs, err = clt.keys.Identities(n)
 		if err != nil {
-			panic("Could not generate private keys")
-		}
-	}
-
-	tmpAssign := config.Assignments(clt.groups)
-	assignments := make(map[[32]byte][]*config.Group)
-
-	// find the assignement group ids per user
-	// spans := span.NSpans(n, len(tmpAssign))
-	// for i, span := range spans {
-	// 	for _, pub := range pubs[span.Start:span.End] {
-	// 		assignments[string((*pub)[:])] = tmpAssign[i]
-	// 	}
-	// }
-
-	division, maxLoad := findOptimalAssignment(clt.groups, tmpAssign)
-
-	end := 0
-	for a := range tmpAssign {
-		start := end
-		offset := int(math.Ceil(division[a] * float64(n)))
-		end = start + offset
-		if start+offset > n || a == len(tmpAssign)-1 {
-			end = n
+			return err
 		}
-		for _, pub := range pubs[start:end] {
-			assignments[(*pub)] = tmpAssign[a]
+	} else {
+		for i := range pubs {
+			pubs[i], privs[i], err = box.GenerateKey(rand.Reader)
+			if err != nil {
+				panic("Could not generate private keys")
+			}
 		}
 	}
 
-	counts := make(map[string]int)
-	for gid := range clt.groups {
-		counts[gid] = 0
-	}
-
-	for _, v := range assignments {
-		for _, group := range v {
-			counts[group.Gid]++
-		}
+	// the chains of each user follow from its key, so its peers and
+	// the servers can compute them too
+	assignments := make(map[[32]byte][]*config.Group)
+	for _, pub := range pubs {
+		assignments[*pub] = clt.assigner.Assign(pub[:], uint64(round))
 	}
 
-	// for gid := range clt.groups {
-	// 	if counts[gid] == 0 {
-	// 		log.Println(gid, "empty")
-	// 	}
-	// }
-
 	clt.publicKeys = pubs
 	clt.privateKeys = privs
 	clt.assignments = assignments
-	clt.maxLoad = int(maxLoad*float64(n)) + 1
+	return nil
 }
 
-func (clt *client) mapUsers() map[[32]byte]string {
-	mailboxMap := make(map[[32]byte]string)
-	spans := span.NSpans(clt.n, len(clt.mailboxes))
-
-	mlist := make([]string, len(clt.mailboxes))
-	i := 0
-	for k := range clt.mailboxes {
-		mlist[i] = k
-		i++
-	}
-	sort.Strings(mlist)
+// userGroup is the users whose inboxes are at the same replicas.
+type userGroup struct {
+	replicas []string // primary first
+	users    []int
+}
 
-	i = 0
-	for _, k := range mlist {
-		for _, pub := range clt.publicKeys[spans[i].Start:spans[i].End] {
-			mailboxMap[*pub] = k
+// mapUsers groups the users by their replicas, in the same way as the
+// servers deliver the mails.
+func (clt *client) mapUsers() map[string]*userGroup {
+	groups := make(map[string]*userGroup)
+	for u, pub := range clt.publicKeys {
+		replicas := clt.ring.Replicas(*pub)
+		id := strings.Join(replicas, ",")
+		group, ok := groups[id]
+		if !ok {
+			group = &userGroup{replicas: replicas}
+			groups[id] = group
 		}
-		i++
+		group.users = append(group.users, u)
 	}
-	return mailboxMap
+	return groups
 }
 
 type clientJob struct {
-	publicKey *[32]byte
-	msg       []byte
-	results   chan clientResult
+	publicKey  *[32]byte
+	privateKey *[32]byte
+	msg        []byte
+	results    chan clientResult
 }
 
 type clientResult struct {
@@ -144,22 +144,20 @@ type clientResult struct {
 	prfs        [][]byte
 }
 
-func clientWorker(round int, servers map[string]*config.Server, groups map[string]*config.Group, xs, ys map[string][]*big.Int, assignments map[[32]byte][]*config.Group, groupSize int, jobs chan clientJob) {
-	var nonce [24]byte
-	binary.PutUvarint(nonce[:], uint64(round))
+func clientWorker(round int, servers map[string]*config.Server, groups map[string]*config.Group, suites map[string]verifiable_mixnet.Suite, xs, ys map[string][]*big.Int, kems map[string][][]byte, assignments map[[32]byte][]*config.Group, groupSize int, jobs chan clientJob) {
+	// a nonce per chain, to trace the mails back to their chains
+	mailNonces := make(map[string]*[24]byte)
+	for gid := range groups {
+		mailNonces[gid] = mailNonce(uint64(round), gid)
+	}
 
 	nonces := make([][]byte, groupSize)
 	auxs := make([][]byte, groupSize)
 
 	envClients := make(map[string]mixnet.Client)
 	for gid := range groups {
-		envClients[gid] = mixnet.NewClient()
-		envClients[gid].NewRound(round, xs[gid], ys[gid])
-	}
-
-	_, priv, err := box.GenerateKey(rand.Reader)
-	if err != nil {
-		panic("Could not generate key for encryption")
+		envClients[gid] = mixnet.NewClient(suites[gid])
+		envClients[gid].NewRound(round, xs[gid], ys[gid], kems[gid])
 	}
 
 	for job := range jobs {
@@ -167,20 +165,12 @@ func clientWorker(round int, servers map[string]*config.Server, groups map[strin
 		mails := make([]*mailbox.Mail, len(myGroups))
 		ciphertexts := make([][]byte, len(myGroups))
 		prfs := make([][]byte, len(myGroups))
-		for g := range myGroups {
-			mails[g] = mailbox.SealMail(job.publicKey, priv, &nonce, job.msg)
+		for g, group := range myGroups {
+			mails[g] = mailbox.SealMail(job.publicKey, job.privateKey, mailNonces[group.Gid], job.msg)
 		}
 
 		for g, group := range myGroups {
-			for i := range nonces {
-				nonce := verifiable_mixnet.Nonce(round, int(group.Row), i)
-				nonces[i] = nonce[:]
-			}
-			onionKeys := config.GroupToKeys(servers, group)
-			mb := mailbox.MarshalMail(mails[g])
-
-			inner := envClients[group.Gid].GenerateRoundInput(round, mb)
-			ciphertexts[g], prfs[g] = verifiable_mixnet.P256OnionEncrypt(inner, auxs, nonces, onionKeys, true)
+			ciphertexts[g], prfs[g] = onionEncrypt(round, servers, group, suites[group.Gid], envClients[group.Gid], mails[g], nonces, auxs)
 		}
 		job.results <- clientResult{
 			key:         job.publicKey,
@@ -190,21 +180,45 @@ func clientWorker(round int, servers map[string]*config.Server, groups map[strin
 	}
 }
 
-func (clt *client) getInnerKeys(round int) (map[string][]*big.Int, map[string][]*big.Int, error) {
+// onionEncrypt encrypts a mail for the chain of group. nonces and auxs
+// are scratch space of the size of the group.
+func onionEncrypt(round int, servers map[string]*config.Server, group *config.Group, suite verifiable_mixnet.Suite, envClient mixnet.Client, mail *mailbox.Mail, nonces, auxs [][]byte) ([]byte, []byte) {
+	for i := range nonces {
+		nonce := verifiable_mixnet.Nonce(round, int(group.Row), i)
+		nonces[i] = nonce[:]
+	}
+	onionKeys := config.GroupToKeys(servers, group)
+	mb := mailbox.MarshalMail(mail)
+
+	inner := envClient.GenerateRoundInput(round, mb)
+	if group.Hybrid {
+		kemKeys := config.GroupToKEMKeys(servers, group)
+		return verifiable_mixnet.P256HybridOnionEncrypt(suite, inner, auxs, nonces, onionKeys, kemKeys, true)
+	}
+	return verifiable_mixnet.P256OnionEncrypt(suite, inner, auxs, nonces, onionKeys, true)
+}
+
+// getInnerKeys fetches the inner keys of the round of every group.
+func getInnerKeys(servers map[string]*config.Server, groups map[string]*config.Group, round int) (map[string][]*big.Int, map[string][]*big.Int, map[string][][]byte, error) {
 	xm := make(map[string][]*big.Int)
 	ym := make(map[string][]*big.Int)
+	km := make(map[string][][]byte)
 
-	conns, err := config.DialServers(clt.servers)
+	conns, err := config.DialServers(servers)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	defer config.CloseConns(conns)
 
-	for gid, cfg := range clt.groups {
+	for gid, cfg := range groups {
 		xs := make([]*big.Int, len(cfg.Servers))
 		ys := make([]*big.Int, len(xs))
+		var kems [][]byte
+		if cfg.Hybrid {
+			kems = make([][]byte, len(xs))
+		}
 		for i, sid := range cfg.Servers {
-			rpc := mixnet.NewMixClient(conns[clt.servers[sid].Address])
+			rpc := mixnet.NewMixClient(conns[servers[sid].Address])
 			md := metadata.Pairs(
 				"id", sid,
 			)
@@ -214,21 +228,32 @@ func (clt *client) getInnerKeys(round int) (map[string][]*big.Int, map[string][]
 			})
 			if err != nil {
 				log.Println("Could not fetch inner keys from servers")
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			xs[i], ys[i] = new(big.Int).SetBytes(resp.X), new(big.Int).SetBytes(resp.Y)
+			if kems != nil {
+				kems[i] = resp.KemKey
+			}
 		}
 		xm[gid] = xs
 		ym[gid] = ys
+		km[gid] = kems
 	}
 
-	return xm, ym, nil
+	return xm, ym, km, nil
 }
 
-func (clt *client) generateExperimentMessages(round, msgSize int) (map[string][][]byte, map[string][][]byte, error) {
-	xs, ys, err := clt.getInnerKeys(round)
+func (clt *client) generateExperimentMessages(round, msgSize int) (map[string][][]byte, map[string][][]byte, map[string][][]byte, error) {
+	xs, ys, kems, err := getInnerKeys(clt.servers, clt.groups, round)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	suites := make(map[string]verifiable_mixnet.Suite)
+	for gid, group := range clt.groups {
+		suites[gid], err = mixnet.GroupSuite(group)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	groupSize := -1
@@ -239,24 +264,29 @@ func (clt *client) generateExperimentMessages(round, msgSize int) (map[string][]
 
 	jobs := make(chan clientJob, runtime.NumCPU()*2)
 	for i := 0; i < runtime.NumCPU()*2; i++ {
-		go clientWorker(round, clt.servers, clt.groups, xs, ys, clt.assignments, groupSize, jobs)
+		go clientWorker(round, clt.servers, clt.groups, suites, xs, ys, kems, clt.assignments, groupSize, jobs)
 	}
 	results := make(chan clientResult, runtime.NumCPU()*2)
 
 	msg := make([]byte, msgSize)
-	for _, pub := range clt.publicKeys {
-		go func(pub *[32]byte) {
+	for u, pub := range clt.publicKeys {
+		go func(pub, priv *[32]byte) {
 			jobs <- clientJob{
-				publicKey: pub,
-				msg:       msg,
-				results:   results,
+				publicKey:  pub,
+				privateKey: priv,
+				msg:        msg,
+				results:    results,
 			}
-		}(pub)
+		}(pub, clt.privateKeys[u])
 	}
 
 	// create per chain requests
 	ciphertexts := make(map[string][][]byte)
 	prfs := make(map[string][][]byte)
+	var tokens map[string][][]byte
+	if clt.userTokens != nil {
+		tokens = make(map[string][][]byte)
+	}
 
 	for range clt.publicKeys {
 		res := <-results
@@ -264,22 +294,26 @@ func (clt *client) generateExperimentMessages(round, msgSize int) (map[string][]
 			group := clt.assignments[*res.key][c]
 			ciphertexts[group.Gid] = append(ciphertexts[group.Gid], ciphertext)
 			prfs[group.Gid] = append(prfs[group.Gid], res.prfs[c])
+			if tokens != nil {
+				tokens[group.Gid] = append(tokens[group.Gid], clt.userTokens[*res.key][c])
+			}
 		}
 	}
 
 	close(jobs)
 	close(results)
 
-	return ciphertexts, prfs, nil
+	return ciphertexts, prfs, tokens, nil
 }
 
 func (clt *client) RegisterUsers(ctx context.Context, in *RegisterUsersRequest) (*RegisterUsersResponse, error) {
 	clt.n = int(in.NumUsers) // keep this value around to reuse later
 
 	// first create users
-	clt.setupRound(int(in.Round), int(in.NumUsers))
-
-	mailboxMap := clt.mapUsers()
+	err := clt.setupRound(int(in.Round), int(in.NumUsers))
+	if err != nil {
+		return nil, err
+	}
 
 	conns, err := config.DialServers(clt.mailboxes)
 	if err != nil {
@@ -292,58 +326,132 @@ func (clt *client) RegisterUsers(ctx context.Context, in *RegisterUsersRequest)
 		rpcs[mid] = mailbox.NewMailboxClient(conns[cfg.Address])
 	}
 
-	spans := span.NSpans(clt.n, len(clt.mailboxes))
-	keys := make([][]byte, spans[0].End-spans[0].Start)
-	for i := range spans {
-		numKeys := spans[i].End - spans[i].Start
-		mid := mailboxMap[*clt.publicKeys[spans[i].Start]]
-
-		expected := make([]uint64, numKeys)
-		for k := range expected {
-			key := *clt.publicKeys[spans[i].Start+k]
-			expected[k] = uint64(len(clt.assignments[key]))
+	clt.userTokens = nil
+	var tokenKey *rsa.PublicKey
+	if len(in.TokenKey) > 0 {
+		tokenKey, err = mixnet.UnmarshalTokenPublicKey(in.TokenKey)
+		if err != nil {
+			return nil, err
 		}
+		clt.userTokens = make(map[[32]byte][][]byte)
+	}
 
-		for i, key := range clt.publicKeys[spans[i].Start:spans[i].End] {
-			keys[i] = (*key)[:]
+	for _, group := range clt.mapUsers() {
+		keys := make([][]byte, len(group.users))
+		expected := make([]uint64, len(group.users))
+		chains := make([]*mailbox.Chains, len(group.users))
+		for k, u := range group.users {
+			keys[k] = (*clt.publicKeys[u])[:]
+			assignment := clt.assignments[*clt.publicKeys[u]]
+			expected[k] = uint64(len(assignment))
+			chains[k] = &mailbox.Chains{Gids: make([]string, len(assignment))}
+			for g, group := range assignment {
+				chains[k].Gids[g] = group.Gid
+			}
 		}
 
-		streamSpan := span.StreamSpan(numKeys, config.StreamSize, 32)
+		err := registerAtReplicas(rpcs, group.replicas, in.Round, keys, expected, nil, chains, clt.pir)
+		if err != nil {
+			return nil, err
+		}
 
-		stream, err := rpcs[mid].RegisterUsers(context.Background())
+		if tokenKey == nil {
+			continue
+		}
+		pubs := make([]*[32]byte, len(group.users))
+		privs := make([]*[32]byte, len(group.users))
+		assignments := make([][]*config.Group, len(group.users))
+		for k, u := range group.users {
+			pubs[k], privs[k] = clt.publicKeys[u], clt.privateKeys[u]
+			assignments[k] = clt.assignments[*pubs[k]]
+		}
+		tokens, err := requestTokens(rpcs[group.replicas[0]], in.Round, tokenKey, pubs, privs, assignments)
 		if err != nil {
 			return nil, err
 		}
+		for k, pub := range pubs {
+			clt.userTokens[*pub] = tokens[k]
+		}
+	}
 
-		for _, sspan := range streamSpan {
-			req := &mailbox.RegisterUsersRequest{
-				Round:    in.Round,
-				UserKeys: keys[:numKeys][sspan.Start:sspan.End],
-				Expected: expected[sspan.Start:sspan.End],
-			}
+	return &RegisterUsersResponse{}, nil
+}
 
-			err := stream.Send(req)
-			if err != nil {
-				return nil, err
+// registerAtReplicas registers the users at all of their replicas. A
+// replica that is down catches up from the others later, unless all
+// of them must have the users.
+func registerAtReplicas(rpcs map[string]mailbox.MailboxClient, replicas []string, round uint64, keys [][]byte, expected, capacity []uint64, chains []*mailbox.Chains, all bool) error {
+	registered := 0
+	for _, mid := range replicas {
+		err := registerUsers(rpcs[mid], round, keys, expected, capacity, chains)
+		if err != nil {
+			if all {
+				return err
 			}
+			log.Println("Could not register users at", mid, err)
+			continue
 		}
-		_, err = stream.CloseAndRecv()
-		if err != nil && err != io.EOF {
-			return nil, err
+		registered++
+	}
+	if registered == 0 {
+		return errors.New("Could not register users at any replica")
+	}
+	return nil
+}
+
+func registerUsers(rpc mailbox.MailboxClient, round uint64, keys [][]byte, expected, capacity []uint64, chains []*mailbox.Chains) error {
+	stream, err := rpc.RegisterUsers(context.Background())
+	if err != nil {
+		return err
+	}
+
+	size := 32 + 8
+	for _, chain := range chains {
+		csize := 32 + 8
+		for _, gid := range chain.Gids {
+			csize += len(gid) + 2
+		}
+		if csize > size {
+			size = csize
 		}
 	}
+	streamSpan := span.StreamSpan(len(keys), config.StreamSize, size)
+	for _, sspan := range streamSpan {
+		req := &mailbox.RegisterUsersRequest{
+			Round:    round,
+			UserKeys: keys[sspan.Start:sspan.End],
+			Expected: expected[sspan.Start:sspan.End],
+		}
+		// capacities and chains are optional
+		if capacity != nil {
+			req.Capacity = capacity[sspan.Start:sspan.End]
+		}
+		if chains != nil {
+			req.Chains = chains[sspan.Start:sspan.End]
+		}
 
-	return &RegisterUsersResponse{}, nil
+		err := stream.Send(req)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
 }
 
 func (clt *client) GenerateMessages(ctx context.Context, in *GenerateMessagesRequest) (*GenerateMessagesResponse, error) {
-	ciphertexts, prfs, err := clt.generateExperimentMessages(int(in.Round), int(in.MsgSize))
+	ciphertexts, prfs, tokens, err := clt.generateExperimentMessages(int(in.Round), int(in.MsgSize))
 	if err != nil {
 		return nil, err
 	}
 
 	clt.ciphertexts = ciphertexts
 	clt.prfs = prfs
+	clt.tokens = tokens
+	clt.msgSize = int(in.MsgSize)
 
 	// uncomment to force memory back, though shouldn't be necessary..
 	debug.FreeOSMemory()
@@ -351,13 +459,12 @@ func (clt *client) GenerateMessages(ctx context.Context, in *GenerateMessagesReq
 	return &GenerateMessagesResponse{}, nil
 }
 
-func (clt *client) submitMixRequest(mrpcs map[string]mixnet.MixClient, round uint64, gid string) error {
-	ciphertexts := clt.ciphertexts
-	prfs := clt.prfs
-
-	errs := make(chan error, len(clt.groups[gid].Servers))
+// submitCiphertexts submits the ciphertexts of a chain to all of its
+// servers, and their tokens, if any, to the first one.
+func submitCiphertexts(mrpcs map[string]mixnet.MixClient, round uint64, group *config.Group, ciphertexts, prfs, tokens [][]byte) error {
+	errs := make(chan error, len(group.Servers))
 
-	for i, sid := range clt.groups[gid].Servers {
+	for i, sid := range group.Servers {
 		go func(i int, sid string) {
 			md := metadata.Pairs(
 				"id", sid,
@@ -372,12 +479,19 @@ func (clt *client) submitMixRequest(mrpcs map[string]mixnet.MixClient, round uin
 				return
 			}
 
-			spans := span.StreamSpan(len(ciphertexts[gid]), config.StreamSize, len(ciphertexts[gid][0])+len(prfs[gid][0]))
+			size := len(ciphertexts[0]) + len(prfs[0])
+			if i == 0 && tokens != nil {
+				size += len(tokens[0])
+			}
+			spans := span.StreamSpan(len(ciphertexts), config.StreamSize, size)
 			for _, span := range spans {
 				req := &mixnet.SubmitCiphertextsRequest{
 					Round:       round,
-					Ciphertexts: ciphertexts[gid][span.Start:span.End],
-					Proofs:      prfs[gid][span.Start:span.End],
+					Ciphertexts: ciphertexts[span.Start:span.End],
+					Proofs:      prfs[span.Start:span.End],
+				}
+				if i == 0 && tokens != nil {
+					req.Tokens = tokens[span.Start:span.End]
 				}
 				err = stream.Send(req)
 				if err != nil {
@@ -397,7 +511,7 @@ func (clt *client) submitMixRequest(mrpcs map[string]mixnet.MixClient, round uin
 		}(i, sid)
 	}
 
-	for range clt.groups[gid].Servers {
+	for range group.Servers {
 		err := <-errs
 		if err != nil {
 			return err
@@ -426,7 +540,7 @@ func (clt *client) SubmitMessages(ctx context.Context, in *SubmitMessagesRequest
 
 	for gid := range clt.ciphertexts {
 		// submit message to mixnet for mixing
-		err = clt.submitMixRequest(mrpcs, in.Round, gid)
+		err = submitCiphertexts(mrpcs, in.Round, clt.groups[gid], clt.ciphertexts[gid], clt.prfs[gid], clt.tokens[gid])
 		if err != nil {
 			return nil, err
 		}
@@ -434,6 +548,7 @@ func (clt *client) SubmitMessages(ctx context.Context, in *SubmitMessagesRequest
 
 	clt.ciphertexts = make(map[string][][]byte)
 	clt.prfs = make(map[string][][]byte)
+	clt.tokens = nil
 	// uncomment to force memory back, though shouldn't be necessary..
 	debug.FreeOSMemory()
 
@@ -441,8 +556,6 @@ func (clt *client) SubmitMessages(ctx context.Context, in *SubmitMessagesRequest
 }
 
 func (clt *client) DownloadMessages(ctx context.Context, in *DownloadMessagesRequest) (*DownloadMessagesResponse, error) {
-	mailboxMap := clt.mapUsers()
-
 	conns, err := config.DialServers(clt.mailboxes)
 	if err != nil {
 		return nil, err
@@ -454,72 +567,146 @@ func (clt *client) DownloadMessages(ctx context.Context, in *DownloadMessagesReq
 		rpcs[mid] = mailbox.NewMailboxClient(conns[cfg.Address])
 	}
 
-	spans := span.NSpans(clt.n, len(clt.mailboxes))
-	errs := make(chan error, len(spans))
-
-	// send all the fetch requests
-	for i := range spans {
-		go func(i int) {
-			keys := make([][]byte, spans[i].End-spans[i].Start)
-			mid := mailboxMap[*clt.publicKeys[spans[i].Start]]
+	if clt.pir {
+		resp, err := clt.pirDownload(in.Round, rpcs)
+		debug.FreeOSMemory()
+		return resp, err
+	}
 
-			for i, key := range clt.publicKeys[spans[i].Start:spans[i].End] {
-				keys[i] = (*key)[:]
+	groups := clt.mapUsers()
+	errs := make(chan error, len(groups))
+
+	// the mailboxes return partial inboxes after the round deadline
+	var mu sync.Mutex
+	resp := &DownloadMessagesResponse{}
+	check := newDeliveryCheck()
+
+	// fetch from the first replica that answers
+	for _, group := range groups {
+		go func(group *userGroup) {
+			var err error
+			var gresp *DownloadMessagesResponse
+			for _, mid := range group.replicas {
+				gresp, err = clt.getMails(rpcs[mid], in.Round, group.users, check)
+				if err == nil {
+					break
+				}
+				log.Println("Could not get mails from", mid, err)
 			}
-
-			stream, err := rpcs[mid].GetMails(context.Background())
 			if err != nil {
 				errs <- err
 				return
 			}
-
-			go func() { // stream the requests, in case it's too big
-				streamSpans := span.StreamSpan(len(keys), config.StreamSize, 32)
-
-				for _, sspan := range streamSpans {
-					req := &mailbox.GetMailsRequest{
-						Round:    in.Round,
-						UserKeys: keys[sspan.Start:sspan.End],
-					}
-					err := stream.Send(req)
-					if err != nil {
-						errs <- err
-						return
-					}
-				}
-				errs <- stream.CloseSend()
-			}()
-
-			// TODO: should check if the received msgs are accurate
-			go func() { // get the responses
-				for {
-					_, err := stream.Recv()
-					if err == io.EOF {
-						break
-					} else if err != nil {
-						errs <- err
-						return
-					}
-					errs <- nil
-				}
-			}()
-		}(i)
+			mu.Lock()
+			resp.Expected += gresp.Expected
+			resp.Received += gresp.Received
+			resp.Incomplete += gresp.Incomplete
+			mu.Unlock()
+			errs <- nil
+		}(group)
 	}
 
-	for range spans {
+	for range groups {
 		err := <-errs
 		if err != nil {
 			return nil, err
 		}
-
-		err = <-errs
-		if err != nil {
-			return nil, err
-		}
 	}
 
 	//log.Println("All mails received")
 	debug.FreeOSMemory()
 
-	return &DownloadMessagesResponse{}, nil
+	resp.Chains = check.report()
+	return resp, nil
+}
+
+// getMails fetches the inboxes of the users from one mailbox, counts
+// the mails, and checks them.
+func (clt *client) getMails(rpc mailbox.MailboxClient, round uint64, users []int, check *deliveryCheck) (*DownloadMessagesResponse, error) {
+	pubs := make([]*[32]byte, len(users))
+	privs := make([]*[32]byte, len(users))
+	for k, u := range users {
+		pubs[k], privs[k] = clt.publicKeys[u], clt.privateKeys[u]
+	}
+	inboxes, err := getInboxes(rpc, round, pubs, privs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DownloadMessagesResponse{}
+	msg := make([]byte, clt.msgSize)
+	for k, inbox := range inboxes {
+		resp.Expected += inbox.Expected
+		resp.Received += inbox.Received
+		if inbox.Received < inbox.Expected {
+			resp.Incomplete++
+		}
+		check.check(round, pubs[k], privs[k], clt.assignments[*pubs[k]], msg, inbox)
+	}
+	return resp, nil
+}
+
+// getInboxes fetches the inboxes of the key pairs from one mailbox,
+// once wait[k] mails are in each (all the expected ones if wait is nil).
+func getInboxes(rpc mailbox.MailboxClient, round uint64, pubs, privs []*[32]byte, wait []uint64) ([]*mailbox.Inbox, error) {
+	keys := make([][]byte, len(pubs))
+	for k := range pubs {
+		keys[k] = (*pubs[k])[:]
+	}
+
+	// prove to the mailbox that we own the keys
+	cresp, err := rpc.GetChallenge(context.Background(), &mailbox.GetChallengeRequest{
+		Round: round,
+	})
+	if err != nil {
+		return nil, err
+	}
+	proofs := make([][]byte, len(keys))
+	for k := range pubs {
+		proofs[k] = mailbox.ProveOwnership(pubs[k], privs[k], round, cresp.Challenge)
+	}
+
+	stream, err := rpc.GetMails(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(chan error, 1)
+	go func() { // stream the requests, in case it's too big
+		streamSpans := span.StreamSpan(len(keys), config.StreamSize, 32+mailbox.PROOF_SIZE)
+
+		for _, sspan := range streamSpans {
+			req := &mailbox.GetMailsRequest{
+				Round:    round,
+				UserKeys: keys[sspan.Start:sspan.End],
+				Proofs:   proofs[sspan.Start:sspan.End],
+			}
+			if wait != nil {
+				req.Wait = wait[sspan.Start:sspan.End]
+			}
+			err := stream.Send(req)
+			if err != nil {
+				errs <- err
+				return
+			}
+		}
+		errs <- stream.CloseSend()
+	}()
+
+	var inboxes []*mailbox.Inbox
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		inboxes = append(inboxes, in.Inboxes...)
+	}
+
+	err = <-errs
+	if err != nil {
+		return nil, err
+	}
+	return inboxes, nil
 }
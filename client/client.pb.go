@@ -16,6 +16,15 @@ It has these top-level messages:
 	SubmitMessagesResponse
 	DownloadMessagesRequest
 	DownloadMessagesResponse
+	ChainCheck
+	Identities
+	Identity
+	Contact
+	Conversation
+	Stream
+	Outgoing
+	Fragment
+	Request
 */
 package client
 
@@ -41,9 +50,39 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type Status int32
+
+const (
+	Status_QUEUED       Status = 0
+	Status_SUBMITTED    Status = 1
+	Status_DELIVERED    Status = 2
+	Status_ACKNOWLEDGED Status = 3
+)
+
+var Status_name = map[int32]string{
+	0: "QUEUED",
+	1: "SUBMITTED",
+	2: "DELIVERED",
+	3: "ACKNOWLEDGED",
+}
+var Status_value = map[string]int32{
+	"QUEUED":       0,
+	"SUBMITTED":    1,
+	"DELIVERED":    2,
+	"ACKNOWLEDGED": 3,
+}
+
+func (x Status) String() string {
+	return proto.EnumName(Status_name, int32(x))
+}
+func (Status) EnumDescriptor() ([]byte, []int) { return fileDescriptorClient, []int{0} }
+
 type RegisterUsersRequest struct {
 	Round    uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 	NumUsers uint64 `protobuf:"fixed64,2,opt,name=num_users,json=numUsers,proto3" json:"num_users,omitempty"`
+	Dial     bool   `protobuf:"varint,3,opt,name=dial,proto3" json:"dial,omitempty"`
+	// modulus of the key of the submission tokens, empty if not needed
+	TokenKey []byte `protobuf:"bytes,4,opt,name=token_key,json=tokenKey,proto3" json:"token_key,omitempty"`
 }
 
 func (m *RegisterUsersRequest) Reset()                    { *m = RegisterUsersRequest{} }
@@ -65,6 +104,20 @@ func (m *RegisterUsersRequest) GetNumUsers() uint64 {
 	return 0
 }
 
+func (m *RegisterUsersRequest) GetDial() bool {
+	if m != nil {
+		return m.Dial
+	}
+	return false
+}
+
+func (m *RegisterUsersRequest) GetTokenKey() []byte {
+	if m != nil {
+		return m.TokenKey
+	}
+	return nil
+}
+
 type RegisterUsersResponse struct {
 }
 
@@ -146,6 +199,11 @@ func (m *DownloadMessagesRequest) GetRound() uint64 {
 }
 
 type DownloadMessagesResponse struct {
+	Expected   uint64 `protobuf:"fixed64,1,opt,name=expected,proto3" json:"expected,omitempty"`
+	Received   uint64 `protobuf:"fixed64,2,opt,name=received,proto3" json:"received,omitempty"`
+	Incomplete uint64 `protobuf:"fixed64,3,opt,name=incomplete,proto3" json:"incomplete,omitempty"`
+	// delivery checks of the mails of the simulated users, by gid
+	Chains []*ChainCheck `protobuf:"bytes,4,rep,name=chains" json:"chains,omitempty"`
 }
 
 func (m *DownloadMessagesResponse) Reset()                    { *m = DownloadMessagesResponse{} }
@@ -153,457 +211,2437 @@ func (m *DownloadMessagesResponse) String() string            { return proto.Com
 func (*DownloadMessagesResponse) ProtoMessage()               {}
 func (*DownloadMessagesResponse) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{7} }
 
-func init() {
-	proto.RegisterType((*RegisterUsersRequest)(nil), "client.RegisterUsersRequest")
-	proto.RegisterType((*RegisterUsersResponse)(nil), "client.RegisterUsersResponse")
-	proto.RegisterType((*GenerateMessagesRequest)(nil), "client.GenerateMessagesRequest")
-	proto.RegisterType((*GenerateMessagesResponse)(nil), "client.GenerateMessagesResponse")
-	proto.RegisterType((*SubmitMessagesRequest)(nil), "client.SubmitMessagesRequest")
-	proto.RegisterType((*SubmitMessagesResponse)(nil), "client.SubmitMessagesResponse")
-	proto.RegisterType((*DownloadMessagesRequest)(nil), "client.DownloadMessagesRequest")
-	proto.RegisterType((*DownloadMessagesResponse)(nil), "client.DownloadMessagesResponse")
+func (m *DownloadMessagesResponse) GetExpected() uint64 {
+	if m != nil {
+		return m.Expected
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *DownloadMessagesResponse) GetReceived() uint64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *DownloadMessagesResponse) GetIncomplete() uint64 {
+	if m != nil {
+		return m.Incomplete
+	}
+	return 0
+}
 
-// Client API for Client service
+func (m *DownloadMessagesResponse) GetChains() []*ChainCheck {
+	if m != nil {
+		return m.Chains
+	}
+	return nil
+}
 
-type ClientClient interface {
-	RegisterUsers(ctx context.Context, in *RegisterUsersRequest, opts ...grpc.CallOption) (*RegisterUsersResponse, error)
-	GenerateMessages(ctx context.Context, in *GenerateMessagesRequest, opts ...grpc.CallOption) (*GenerateMessagesResponse, error)
-	SubmitMessages(ctx context.Context, in *SubmitMessagesRequest, opts ...grpc.CallOption) (*SubmitMessagesResponse, error)
-	DownloadMessages(ctx context.Context, in *DownloadMessagesRequest, opts ...grpc.CallOption) (*DownloadMessagesResponse, error)
+// ChainCheck counts the copies of the mails sent through a chain that
+// came back to the users, see verify.go.
+type ChainCheck struct {
+	Gid       string `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Expected  uint64 `protobuf:"fixed64,2,opt,name=expected,proto3" json:"expected,omitempty"`
+	Delivered uint64 `protobuf:"fixed64,3,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	Missing   uint64 `protobuf:"fixed64,4,opt,name=missing,proto3" json:"missing,omitempty"`
+	Corrupted uint64 `protobuf:"fixed64,5,opt,name=corrupted,proto3" json:"corrupted,omitempty"`
 }
 
-type clientClient struct {
-	cc *grpc.ClientConn
+func (m *ChainCheck) Reset()                    { *m = ChainCheck{} }
+func (m *ChainCheck) String() string            { return proto.CompactTextString(m) }
+func (*ChainCheck) ProtoMessage()               {}
+func (*ChainCheck) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{8} }
+
+func (m *ChainCheck) GetGid() string {
+	if m != nil {
+		return m.Gid
+	}
+	return ""
 }
 
-func NewClientClient(cc *grpc.ClientConn) ClientClient {
-	return &clientClient{cc}
+func (m *ChainCheck) GetExpected() uint64 {
+	if m != nil {
+		return m.Expected
+	}
+	return 0
 }
 
-func (c *clientClient) RegisterUsers(ctx context.Context, in *RegisterUsersRequest, opts ...grpc.CallOption) (*RegisterUsersResponse, error) {
-	out := new(RegisterUsersResponse)
-	err := grpc.Invoke(ctx, "/client.Client/RegisterUsers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChainCheck) GetDelivered() uint64 {
+	if m != nil {
+		return m.Delivered
 	}
-	return out, nil
+	return 0
 }
 
-func (c *clientClient) GenerateMessages(ctx context.Context, in *GenerateMessagesRequest, opts ...grpc.CallOption) (*GenerateMessagesResponse, error) {
-	out := new(GenerateMessagesResponse)
-	err := grpc.Invoke(ctx, "/client.Client/GenerateMessages", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChainCheck) GetMissing() uint64 {
+	if m != nil {
+		return m.Missing
 	}
-	return out, nil
+	return 0
 }
 
-func (c *clientClient) SubmitMessages(ctx context.Context, in *SubmitMessagesRequest, opts ...grpc.CallOption) (*SubmitMessagesResponse, error) {
-	out := new(SubmitMessagesResponse)
-	err := grpc.Invoke(ctx, "/client.Client/SubmitMessages", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChainCheck) GetCorrupted() uint64 {
+	if m != nil {
+		return m.Corrupted
 	}
-	return out, nil
+	return 0
 }
 
-func (c *clientClient) DownloadMessages(ctx context.Context, in *DownloadMessagesRequest, opts ...grpc.CallOption) (*DownloadMessagesResponse, error) {
-	out := new(DownloadMessagesResponse)
-	err := grpc.Invoke(ctx, "/client.Client/DownloadMessages", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+// Identities is what a keystore file keeps, encrypted with a passphrase.
+type Identities struct {
+	Identities []*Identity `protobuf:"bytes,1,rep,name=identities" json:"identities,omitempty"`
+}
+
+func (m *Identities) Reset()                    { *m = Identities{} }
+func (m *Identities) String() string            { return proto.CompactTextString(m) }
+func (*Identities) ProtoMessage()               {}
+func (*Identities) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{9} }
+
+func (m *Identities) GetIdentities() []*Identity {
+	if m != nil {
+		return m.Identities
 	}
-	return out, nil
+	return nil
 }
 
-// Server API for Client service
+type Identity struct {
+	PublicKey  []byte     `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	PrivateKey []byte     `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Contacts   []*Contact `protobuf:"bytes,3,rep,name=contacts" json:"contacts,omitempty"`
+}
 
-type ClientServer interface {
-	RegisterUsers(context.Context, *RegisterUsersRequest) (*RegisterUsersResponse, error)
-	GenerateMessages(context.Context, *GenerateMessagesRequest) (*GenerateMessagesResponse, error)
-	SubmitMessages(context.Context, *SubmitMessagesRequest) (*SubmitMessagesResponse, error)
-	DownloadMessages(context.Context, *DownloadMessagesRequest) (*DownloadMessagesResponse, error)
+func (m *Identity) Reset()                    { *m = Identity{} }
+func (m *Identity) String() string            { return proto.CompactTextString(m) }
+func (*Identity) ProtoMessage()               {}
+func (*Identity) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{10} }
+
+func (m *Identity) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
 }
 
-func RegisterClientServer(s *grpc.Server, srv ClientServer) {
-	s.RegisterService(&_Client_serviceDesc, srv)
+func (m *Identity) GetPrivateKey() []byte {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
 }
 
-func _Client_RegisterUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterUsersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Identity) GetContacts() []*Contact {
+	if m != nil {
+		return m.Contacts
 	}
-	if interceptor == nil {
-		return srv.(ClientServer).RegisterUsers(ctx, in)
+	return nil
+}
+
+type Contact struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// state of the conversation with the contact, opaque to the keystore
+	State []byte `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// state of the stream with the contact, opaque to the keystore
+	Stream []byte `protobuf:"bytes,4,opt,name=stream,proto3" json:"stream,omitempty"`
+}
+
+func (m *Contact) Reset()                    { *m = Contact{} }
+func (m *Contact) String() string            { return proto.CompactTextString(m) }
+func (*Contact) ProtoMessage()               {}
+func (*Contact) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{11} }
+
+func (m *Contact) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/client.Client/RegisterUsers",
+	return ""
+}
+
+func (m *Contact) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientServer).RegisterUsers(ctx, req.(*RegisterUsersRequest))
+	return nil
+}
+
+func (m *Contact) GetState() []byte {
+	if m != nil {
+		return m.State
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Client_GenerateMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GenerateMessagesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Contact) GetStream() []byte {
+	if m != nil {
+		return m.Stream
 	}
-	if interceptor == nil {
-		return srv.(ClientServer).GenerateMessages(ctx, in)
+	return nil
+}
+
+// Conversation is the ratchet state of a conversation with a contact.
+type Conversation struct {
+	Root []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// current ratchet key pair
+	PrivateKey []byte `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	PublicKey  []byte `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// sending chain: its key for send_round, and the round it started,
+	// which is the next round sent in if it is pending
+	SendChain   []byte `protobuf:"bytes,4,opt,name=send_chain,json=sendChain,proto3" json:"send_chain,omitempty"`
+	SendRound   uint64 `protobuf:"fixed64,5,opt,name=send_round,json=sendRound,proto3" json:"send_round,omitempty"`
+	SendStart   uint64 `protobuf:"fixed64,6,opt,name=send_start,json=sendStart,proto3" json:"send_start,omitempty"`
+	SendPending bool   `protobuf:"varint,7,opt,name=send_pending,json=sendPending,proto3" json:"send_pending,omitempty"`
+	// receiving chain of the peer's ratchet key: its key for recv_round,
+	// or for the round it starts in if it is not anchored yet
+	TheirKey     []byte `protobuf:"bytes,8,opt,name=their_key,json=theirKey,proto3" json:"their_key,omitempty"`
+	RecvChain    []byte `protobuf:"bytes,9,opt,name=recv_chain,json=recvChain,proto3" json:"recv_chain,omitempty"`
+	RecvRound    uint64 `protobuf:"fixed64,10,opt,name=recv_round,json=recvRound,proto3" json:"recv_round,omitempty"`
+	RecvAnchored bool   `protobuf:"varint,11,opt,name=recv_anchored,json=recvAnchored,proto3" json:"recv_anchored,omitempty"`
+}
+
+func (m *Conversation) Reset()                    { *m = Conversation{} }
+func (m *Conversation) String() string            { return proto.CompactTextString(m) }
+func (*Conversation) ProtoMessage()               {}
+func (*Conversation) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{12} }
+
+func (m *Conversation) GetRoot() []byte {
+	if m != nil {
+		return m.Root
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/client.Client/GenerateMessages",
+	return nil
+}
+
+func (m *Conversation) GetPrivateKey() []byte {
+	if m != nil {
+		return m.PrivateKey
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientServer).GenerateMessages(ctx, req.(*GenerateMessagesRequest))
+	return nil
+}
+
+func (m *Conversation) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Client_SubmitMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SubmitMessagesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Conversation) GetSendChain() []byte {
+	if m != nil {
+		return m.SendChain
 	}
-	if interceptor == nil {
-		return srv.(ClientServer).SubmitMessages(ctx, in)
+	return nil
+}
+
+func (m *Conversation) GetSendRound() uint64 {
+	if m != nil {
+		return m.SendRound
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/client.Client/SubmitMessages",
+	return 0
+}
+
+func (m *Conversation) GetSendStart() uint64 {
+	if m != nil {
+		return m.SendStart
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientServer).SubmitMessages(ctx, req.(*SubmitMessagesRequest))
+	return 0
+}
+
+func (m *Conversation) GetSendPending() bool {
+	if m != nil {
+		return m.SendPending
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _Client_DownloadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DownloadMessagesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Conversation) GetTheirKey() []byte {
+	if m != nil {
+		return m.TheirKey
 	}
-	if interceptor == nil {
-		return srv.(ClientServer).DownloadMessages(ctx, in)
+	return nil
+}
+
+func (m *Conversation) GetRecvChain() []byte {
+	if m != nil {
+		return m.RecvChain
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/client.Client/DownloadMessages",
+	return nil
+}
+
+func (m *Conversation) GetRecvRound() uint64 {
+	if m != nil {
+		return m.RecvRound
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientServer).DownloadMessages(ctx, req.(*DownloadMessagesRequest))
+	return 0
+}
+
+func (m *Conversation) GetRecvAnchored() bool {
+	if m != nil {
+		return m.RecvAnchored
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-var _Client_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "client.Client",
-	HandlerType: (*ClientServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "RegisterUsers",
-			Handler:    _Client_RegisterUsers_Handler,
-		},
-		{
-			MethodName: "GenerateMessages",
-			Handler:    _Client_GenerateMessages_Handler,
-		},
-		{
-			MethodName: "SubmitMessages",
-			Handler:    _Client_SubmitMessages_Handler,
-		},
-		{
-			MethodName: "DownloadMessages",
-			Handler:    _Client_DownloadMessages_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "client.proto",
+// Stream is the state of the fragments to and from a contact, see
+// fragment.go.
+type Stream struct {
+	// sending: the sequence numbers of the next fragment and of the last
+	// one sent, the payloads not done with, and the fragments the peer
+	// asked for again
+	NextSeq  uint32      `protobuf:"fixed32,1,opt,name=next_seq,json=nextSeq,proto3" json:"next_seq,omitempty"`
+	LastSent uint32      `protobuf:"fixed32,2,opt,name=last_sent,json=lastSent,proto3" json:"last_sent,omitempty"`
+	Outbox   []*Outgoing `protobuf:"bytes,3,rep,name=outbox" json:"outbox,omitempty"`
+	Resend   []uint32    `protobuf:"fixed32,4,rep,packed,name=resend" json:"resend,omitempty"`
+	// receiving: the last sequence number the peer sent, the first one not
+	// done with, and the state of the ones after it
+	PeerSent    uint32      `protobuf:"fixed32,5,opt,name=peer_sent,json=peerSent,proto3" json:"peer_sent,omitempty"`
+	Base        uint32      `protobuf:"fixed32,6,opt,name=base,proto3" json:"base,omitempty"`
+	Fragments   []*Fragment `protobuf:"bytes,7,rep,name=fragments" json:"fragments,omitempty"`
+	Reassembled []uint32    `protobuf:"fixed32,8,rep,packed,name=reassembled" json:"reassembled,omitempty"`
+	Requests    []*Request  `protobuf:"bytes,9,rep,name=requests" json:"requests,omitempty"`
+	// the highest sequence number received, and the last one
+	Highest uint32 `protobuf:"fixed32,10,opt,name=highest,proto3" json:"highest,omitempty"`
+	Last    uint32 `protobuf:"fixed32,11,opt,name=last,proto3" json:"last,omitempty"`
 }
 
-func (m *RegisterUsersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Stream) Reset()                    { *m = Stream{} }
+func (m *Stream) String() string            { return proto.CompactTextString(m) }
+func (*Stream) ProtoMessage()               {}
+func (*Stream) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{13} }
+
+func (m *Stream) GetNextSeq() uint32 {
+	if m != nil {
+		return m.NextSeq
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *RegisterUsersRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
-	}
-	if m.NumUsers != 0 {
-		dAtA[i] = 0x11
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.NumUsers))
-		i += 8
+func (m *Stream) GetLastSent() uint32 {
+	if m != nil {
+		return m.LastSent
 	}
-	return i, nil
+	return 0
 }
 
-func (m *RegisterUsersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Stream) GetOutbox() []*Outgoing {
+	if m != nil {
+		return m.Outbox
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *RegisterUsersResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+func (m *Stream) GetResend() []uint32 {
+	if m != nil {
+		return m.Resend
+	}
+	return nil
 }
 
-func (m *GenerateMessagesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Stream) GetPeerSent() uint32 {
+	if m != nil {
+		return m.PeerSent
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GenerateMessagesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
-	}
-	if m.MsgSize != 0 {
-		dAtA[i] = 0x11
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.MsgSize))
-		i += 8
+func (m *Stream) GetBase() uint32 {
+	if m != nil {
+		return m.Base
 	}
-	return i, nil
+	return 0
 }
 
-func (m *GenerateMessagesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Stream) GetFragments() []*Fragment {
+	if m != nil {
+		return m.Fragments
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GenerateMessagesResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+func (m *Stream) GetReassembled() []uint32 {
+	if m != nil {
+		return m.Reassembled
+	}
+	return nil
 }
 
-func (m *SubmitMessagesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Stream) GetRequests() []*Request {
+	if m != nil {
+		return m.Requests
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *SubmitMessagesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
+func (m *Stream) GetHighest() uint32 {
+	if m != nil {
+		return m.Highest
 	}
-	return i, nil
+	return 0
 }
 
-func (m *SubmitMessagesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Stream) GetLast() uint32 {
+	if m != nil {
+		return m.Last
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SubmitMessagesResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+// Outgoing is a payload in the outbox.
+type Outgoing struct {
+	Seq          uint32 `protobuf:"fixed32,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Payload      []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	FragmentSize uint32 `protobuf:"fixed32,3,opt,name=fragment_size,json=fragmentSize,proto3" json:"fragment_size,omitempty"`
+	// of each fragment, and the round it was last submitted in
+	Status []Status `protobuf:"varint,4,rep,packed,name=status,enum=client.Status" json:"status,omitempty"`
+	Rounds []uint64 `protobuf:"fixed64,5,rep,packed,name=rounds" json:"rounds,omitempty"`
 }
 
-func (m *DownloadMessagesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
+func (m *Outgoing) Reset()                    { *m = Outgoing{} }
+func (m *Outgoing) String() string            { return proto.CompactTextString(m) }
+func (*Outgoing) ProtoMessage()               {}
+func (*Outgoing) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{14} }
 
-func (m *DownloadMessagesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
+func (m *Outgoing) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
 	}
-	return i, nil
+	return 0
 }
 
-func (m *DownloadMessagesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Outgoing) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DownloadMessagesResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+func (m *Outgoing) GetFragmentSize() uint32 {
+	if m != nil {
+		return m.FragmentSize
+	}
+	return 0
 }
 
-func encodeVarintClient(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Outgoing) GetStatus() []Status {
+	if m != nil {
+		return m.Status
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return nil
 }
-func (m *RegisterUsersRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
-	}
-	if m.NumUsers != 0 {
-		n += 9
+
+func (m *Outgoing) GetRounds() []uint64 {
+	if m != nil {
+		return m.Rounds
 	}
-	return n
+	return nil
 }
 
-func (m *RegisterUsersResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+// Fragment is a fragment received, waiting for the rest of its payload.
+type Fragment struct {
+	Seq   uint32 `protobuf:"fixed32,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Count uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Data  []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (m *GenerateMessagesRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
-	}
-	if m.MsgSize != 0 {
-		n += 9
+func (m *Fragment) Reset()                    { *m = Fragment{} }
+func (m *Fragment) String() string            { return proto.CompactTextString(m) }
+func (*Fragment) ProtoMessage()               {}
+func (*Fragment) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{15} }
+
+func (m *Fragment) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
 	}
-	return n
+	return 0
 }
 
-func (m *GenerateMessagesResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (m *Fragment) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
 }
 
-func (m *SubmitMessagesRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
+func (m *Fragment) GetCount() uint32 {
+	if m != nil {
+		return m.Count
 	}
-	return n
+	return 0
 }
 
-func (m *SubmitMessagesResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (m *Fragment) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-func (m *DownloadMessagesRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
-	}
-	return n
+// Request is a fragment asked for again, in round.
+type Request struct {
+	Seq   uint32 `protobuf:"fixed32,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Round uint64 `protobuf:"fixed64,2,opt,name=round,proto3" json:"round,omitempty"`
 }
 
-func (m *DownloadMessagesResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (m *Request) Reset()                    { *m = Request{} }
+func (m *Request) String() string            { return proto.CompactTextString(m) }
+func (*Request) ProtoMessage()               {}
+func (*Request) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{16} }
+
+func (m *Request) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
 }
 
-func sovClient(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *Request) GetRound() uint64 {
+	if m != nil {
+		return m.Round
 	}
-	return n
+	return 0
 }
-func sozClient(x uint64) (n int) {
-	return sovClient(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func init() {
+	proto.RegisterType((*RegisterUsersRequest)(nil), "client.RegisterUsersRequest")
+	proto.RegisterType((*RegisterUsersResponse)(nil), "client.RegisterUsersResponse")
+	proto.RegisterType((*GenerateMessagesRequest)(nil), "client.GenerateMessagesRequest")
+	proto.RegisterType((*GenerateMessagesResponse)(nil), "client.GenerateMessagesResponse")
+	proto.RegisterType((*SubmitMessagesRequest)(nil), "client.SubmitMessagesRequest")
+	proto.RegisterType((*SubmitMessagesResponse)(nil), "client.SubmitMessagesResponse")
+	proto.RegisterType((*DownloadMessagesRequest)(nil), "client.DownloadMessagesRequest")
+	proto.RegisterType((*DownloadMessagesResponse)(nil), "client.DownloadMessagesResponse")
+	proto.RegisterType((*ChainCheck)(nil), "client.ChainCheck")
+	proto.RegisterType((*Identities)(nil), "client.Identities")
+	proto.RegisterType((*Identity)(nil), "client.Identity")
+	proto.RegisterType((*Contact)(nil), "client.Contact")
+	proto.RegisterType((*Conversation)(nil), "client.Conversation")
+	proto.RegisterType((*Stream)(nil), "client.Stream")
+	proto.RegisterType((*Outgoing)(nil), "client.Outgoing")
+	proto.RegisterType((*Fragment)(nil), "client.Fragment")
+	proto.RegisterType((*Request)(nil), "client.Request")
+	proto.RegisterEnum("client.Status", Status_name, Status_value)
 }
-func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Client service
+
+type ClientClient interface {
+	RegisterUsers(ctx context.Context, in *RegisterUsersRequest, opts ...grpc.CallOption) (*RegisterUsersResponse, error)
+	GenerateMessages(ctx context.Context, in *GenerateMessagesRequest, opts ...grpc.CallOption) (*GenerateMessagesResponse, error)
+	SubmitMessages(ctx context.Context, in *SubmitMessagesRequest, opts ...grpc.CallOption) (*SubmitMessagesResponse, error)
+	DownloadMessages(ctx context.Context, in *DownloadMessagesRequest, opts ...grpc.CallOption) (*DownloadMessagesResponse, error)
+}
+
+type clientClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClientClient(cc *grpc.ClientConn) ClientClient {
+	return &clientClient{cc}
+}
+
+func (c *clientClient) RegisterUsers(ctx context.Context, in *RegisterUsersRequest, opts ...grpc.CallOption) (*RegisterUsersResponse, error) {
+	out := new(RegisterUsersResponse)
+	err := grpc.Invoke(ctx, "/client.Client/RegisterUsers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) GenerateMessages(ctx context.Context, in *GenerateMessagesRequest, opts ...grpc.CallOption) (*GenerateMessagesResponse, error) {
+	out := new(GenerateMessagesResponse)
+	err := grpc.Invoke(ctx, "/client.Client/GenerateMessages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) SubmitMessages(ctx context.Context, in *SubmitMessagesRequest, opts ...grpc.CallOption) (*SubmitMessagesResponse, error) {
+	out := new(SubmitMessagesResponse)
+	err := grpc.Invoke(ctx, "/client.Client/SubmitMessages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) DownloadMessages(ctx context.Context, in *DownloadMessagesRequest, opts ...grpc.CallOption) (*DownloadMessagesResponse, error) {
+	out := new(DownloadMessagesResponse)
+	err := grpc.Invoke(ctx, "/client.Client/DownloadMessages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Client service
+
+type ClientServer interface {
+	RegisterUsers(context.Context, *RegisterUsersRequest) (*RegisterUsersResponse, error)
+	GenerateMessages(context.Context, *GenerateMessagesRequest) (*GenerateMessagesResponse, error)
+	SubmitMessages(context.Context, *SubmitMessagesRequest) (*SubmitMessagesResponse, error)
+	DownloadMessages(context.Context, *DownloadMessagesRequest) (*DownloadMessagesResponse, error)
+}
+
+func RegisterClientServer(s *grpc.Server, srv ClientServer) {
+	s.RegisterService(&_Client_serviceDesc, srv)
+}
+
+func _Client_RegisterUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).RegisterUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client.Client/RegisterUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).RegisterUsers(ctx, req.(*RegisterUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_GenerateMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).GenerateMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client.Client/GenerateMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).GenerateMessages(ctx, req.(*GenerateMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_SubmitMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).SubmitMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client.Client/SubmitMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).SubmitMessages(ctx, req.(*SubmitMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_DownloadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).DownloadMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client.Client/DownloadMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).DownloadMessages(ctx, req.(*DownloadMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Client_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "client.Client",
+	HandlerType: (*ClientServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterUsers",
+			Handler:    _Client_RegisterUsers_Handler,
+		},
+		{
+			MethodName: "GenerateMessages",
+			Handler:    _Client_GenerateMessages_Handler,
+		},
+		{
+			MethodName: "SubmitMessages",
+			Handler:    _Client_SubmitMessages_Handler,
+		},
+		{
+			MethodName: "DownloadMessages",
+			Handler:    _Client_DownloadMessages_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "client.proto",
+}
+
+func (m *RegisterUsersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisterUsersRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if m.NumUsers != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.NumUsers))
+		i += 8
+	}
+	if m.Dial {
+		dAtA[i] = 0x18
+		i++
+		if m.Dial {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.TokenKey) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.TokenKey)))
+		i += copy(dAtA[i:], m.TokenKey)
+	}
+	return i, nil
+}
+
+func (m *RegisterUsersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisterUsersResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GenerateMessagesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenerateMessagesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if m.MsgSize != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.MsgSize))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *GenerateMessagesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenerateMessagesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *SubmitMessagesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubmitMessagesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *SubmitMessagesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubmitMessagesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *DownloadMessagesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DownloadMessagesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *DownloadMessagesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DownloadMessagesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Expected != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Expected))
+		i += 8
+	}
+	if m.Received != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Received))
+		i += 8
+	}
+	if m.Incomplete != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Incomplete))
+		i += 8
+	}
+	if len(m.Chains) > 0 {
+		for _, msg := range m.Chains {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ChainCheck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainCheck) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Gid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Gid)))
+		i += copy(dAtA[i:], m.Gid)
+	}
+	if m.Expected != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Expected))
+		i += 8
+	}
+	if m.Delivered != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Delivered))
+		i += 8
+	}
+	if m.Missing != 0 {
+		dAtA[i] = 0x21
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Missing))
+		i += 8
+	}
+	if m.Corrupted != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Corrupted))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *Identities) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Identities) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Identities) > 0 {
+		for _, msg := range m.Identities {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Identity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Identity) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.PublicKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.PublicKey)))
+		i += copy(dAtA[i:], m.PublicKey)
+	}
+	if len(m.PrivateKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.PrivateKey)))
+		i += copy(dAtA[i:], m.PrivateKey)
+	}
+	if len(m.Contacts) > 0 {
+		for _, msg := range m.Contacts {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Contact) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Contact) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.PublicKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.PublicKey)))
+		i += copy(dAtA[i:], m.PublicKey)
+	}
+	if len(m.State) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.State)))
+		i += copy(dAtA[i:], m.State)
+	}
+	if len(m.Stream) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Stream)))
+		i += copy(dAtA[i:], m.Stream)
+	}
+	return i, nil
+}
+
+func (m *Conversation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Conversation) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Root) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Root)))
+		i += copy(dAtA[i:], m.Root)
+	}
+	if len(m.PrivateKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.PrivateKey)))
+		i += copy(dAtA[i:], m.PrivateKey)
+	}
+	if len(m.PublicKey) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.PublicKey)))
+		i += copy(dAtA[i:], m.PublicKey)
+	}
+	if len(m.SendChain) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.SendChain)))
+		i += copy(dAtA[i:], m.SendChain)
+	}
+	if m.SendRound != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.SendRound))
+		i += 8
+	}
+	if m.SendStart != 0 {
+		dAtA[i] = 0x31
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.SendStart))
+		i += 8
+	}
+	if m.SendPending {
+		dAtA[i] = 0x38
+		i++
+		if m.SendPending {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.TheirKey) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.TheirKey)))
+		i += copy(dAtA[i:], m.TheirKey)
+	}
+	if len(m.RecvChain) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.RecvChain)))
+		i += copy(dAtA[i:], m.RecvChain)
+	}
+	if m.RecvRound != 0 {
+		dAtA[i] = 0x51
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.RecvRound))
+		i += 8
+	}
+	if m.RecvAnchored {
+		dAtA[i] = 0x58
+		i++
+		if m.RecvAnchored {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Stream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Stream) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.NextSeq != 0 {
+		dAtA[i] = 0xd
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.NextSeq))
+		i += 4
+	}
+	if m.LastSent != 0 {
+		dAtA[i] = 0x15
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.LastSent))
+		i += 4
+	}
+	if len(m.Outbox) > 0 {
+		for _, msg := range m.Outbox {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Resend) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Resend)*4))
+		for _, num := range m.Resend {
+			binary.LittleEndian.PutUint32(dAtA[i:], uint32(num))
+			i += 4
+		}
+	}
+	if m.PeerSent != 0 {
+		dAtA[i] = 0x2d
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.PeerSent))
+		i += 4
+	}
+	if m.Base != 0 {
+		dAtA[i] = 0x35
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Base))
+		i += 4
+	}
+	if len(m.Fragments) > 0 {
+		for _, msg := range m.Fragments {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Reassembled) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Reassembled)*4))
+		for _, num := range m.Reassembled {
+			binary.LittleEndian.PutUint32(dAtA[i:], uint32(num))
+			i += 4
+		}
+	}
+	if len(m.Requests) > 0 {
+		for _, msg := range m.Requests {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Highest != 0 {
+		dAtA[i] = 0x55
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Highest))
+		i += 4
+	}
+	if m.Last != 0 {
+		dAtA[i] = 0x5d
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Last))
+		i += 4
+	}
+	return i, nil
+}
+
+func (m *Outgoing) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Outgoing) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		dAtA[i] = 0xd
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Seq))
+		i += 4
+	}
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if m.FragmentSize != 0 {
+		dAtA[i] = 0x1d
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.FragmentSize))
+		i += 4
+	}
+	if len(m.Status) > 0 {
+		dAtA2 := make([]byte, len(m.Status)*10)
+		var j1 int
+		for _, num := range m.Status {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(j1))
+		i += copy(dAtA[i:], dAtA2[:j1])
+	}
+	if len(m.Rounds) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Rounds)*8))
+		for _, num := range m.Rounds {
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(num))
+			i += 8
+		}
+	}
+	return i, nil
+}
+
+func (m *Fragment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Fragment) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		dAtA[i] = 0xd
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Seq))
+		i += 4
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Index))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Count))
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	return i, nil
+}
+
+func (m *Request) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		dAtA[i] = 0xd
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Seq))
+		i += 4
+	}
+	if m.Round != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func encodeVarintClient(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *RegisterUsersRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if m.NumUsers != 0 {
+		n += 9
+	}
+	if m.Dial {
+		n += 2
+	}
+	l = len(m.TokenKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *RegisterUsersResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GenerateMessagesRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if m.MsgSize != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *GenerateMessagesResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SubmitMessagesRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *SubmitMessagesResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *DownloadMessagesRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *DownloadMessagesResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Expected != 0 {
+		n += 9
+	}
+	if m.Received != 0 {
+		n += 9
+	}
+	if m.Incomplete != 0 {
+		n += 9
+	}
+	if len(m.Chains) > 0 {
+		for _, e := range m.Chains {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ChainCheck) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Gid)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.Expected != 0 {
+		n += 9
+	}
+	if m.Delivered != 0 {
+		n += 9
+	}
+	if m.Missing != 0 {
+		n += 9
+	}
+	if m.Corrupted != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *Identities) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Identities) > 0 {
+		for _, e := range m.Identities {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Identity) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.PrivateKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if len(m.Contacts) > 0 {
+		for _, e := range m.Contacts {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Contact) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.Stream)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *Conversation) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.PrivateKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.SendChain)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.SendRound != 0 {
+		n += 9
+	}
+	if m.SendStart != 0 {
+		n += 9
+	}
+	if m.SendPending {
+		n += 2
+	}
+	l = len(m.TheirKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.RecvChain)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.RecvRound != 0 {
+		n += 9
+	}
+	if m.RecvAnchored {
+		n += 2
+	}
+	return n
+}
+
+func (m *Stream) Size() (n int) {
+	var l int
+	_ = l
+	if m.NextSeq != 0 {
+		n += 5
+	}
+	if m.LastSent != 0 {
+		n += 5
+	}
+	if len(m.Outbox) > 0 {
+		for _, e := range m.Outbox {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	if len(m.Resend) > 0 {
+		n += 1 + sovClient(uint64(len(m.Resend)*4)) + len(m.Resend)*4
+	}
+	if m.PeerSent != 0 {
+		n += 5
+	}
+	if m.Base != 0 {
+		n += 5
+	}
+	if len(m.Fragments) > 0 {
+		for _, e := range m.Fragments {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	if len(m.Reassembled) > 0 {
+		n += 1 + sovClient(uint64(len(m.Reassembled)*4)) + len(m.Reassembled)*4
+	}
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	if m.Highest != 0 {
+		n += 5
+	}
+	if m.Last != 0 {
+		n += 5
+	}
+	return n
+}
+
+func (m *Outgoing) Size() (n int) {
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		n += 5
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.FragmentSize != 0 {
+		n += 5
+	}
+	if len(m.Status) > 0 {
+		l = 0
+		for _, e := range m.Status {
+			l += sovClient(uint64(e))
+		}
+		n += 1 + sovClient(uint64(l)) + l
+	}
+	if len(m.Rounds) > 0 {
+		n += 1 + sovClient(uint64(len(m.Rounds)*8)) + len(m.Rounds)*8
+	}
+	return n
+}
+
+func (m *Fragment) Size() (n int) {
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		n += 5
+	}
+	if m.Index != 0 {
+		n += 1 + sovClient(uint64(m.Index))
+	}
+	if m.Count != 0 {
+		n += 1 + sovClient(uint64(m.Count))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *Request) Size() (n int) {
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		n += 5
+	}
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovClient(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozClient(x uint64) (n int) {
+	return sovClient(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterUsersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumUsers", wireType)
+			}
+			m.NumUsers = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NumUsers = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dial", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Dial = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenKey = append(m.TokenKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.TokenKey == nil {
+				m.TokenKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterUsersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenerateMessagesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenerateMessagesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenerateMessagesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgSize", wireType)
+			}
+			m.MsgSize = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MsgSize = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenerateMessagesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenerateMessagesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenerateMessagesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitMessagesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitMessagesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitMessagesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitMessagesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitMessagesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitMessagesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DownloadMessagesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DownloadMessagesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DownloadMessagesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DownloadMessagesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DownloadMessagesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DownloadMessagesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			m.Expected = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expected = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Received", wireType)
+			}
+			m.Received = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Received = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Incomplete", wireType)
+			}
+			m.Incomplete = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Incomplete = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chains", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chains = append(m.Chains, &ChainCheck{})
+			if err := m.Chains[len(m.Chains)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChainCheck) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainCheck: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainCheck: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			m.Expected = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expected = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delivered", wireType)
+			}
+			m.Delivered = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Delivered = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Missing", wireType)
+			}
+			m.Missing = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Missing = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Corrupted", wireType)
+			}
+			m.Corrupted = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Corrupted = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Identities) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -626,32 +2664,358 @@ func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegisterUsersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Identities: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegisterUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Identities: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identities", wireType)
 			}
-			m.Round = 0
-			if (iNdEx + 8) > l {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identities = append(m.Identities, &Identity{})
+			if err := m.Identities[len(m.Identities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Identity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Identity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Identity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivateKey = append(m.PrivateKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrivateKey == nil {
+				m.PrivateKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contacts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contacts = append(m.Contacts, &Contact{})
+			if err := m.Contacts[len(m.Contacts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Contact) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Contact: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Contact: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumUsers", wireType)
+			m.State = append(m.State[:0], dAtA[iNdEx:postIndex]...)
+			if m.State == nil {
+				m.State = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
 			}
-			m.NumUsers = 0
-			if (iNdEx + 8) > l {
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NumUsers = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
+			m.Stream = append(m.Stream[:0], dAtA[iNdEx:postIndex]...)
+			if m.Stream == nil {
+				m.Stream = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -673,7 +3037,7 @@ func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
+func (m *Conversation) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -696,12 +3060,268 @@ func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegisterUsersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Conversation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegisterUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Conversation: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivateKey = append(m.PrivateKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrivateKey == nil {
+				m.PrivateKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendChain", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SendChain = append(m.SendChain[:0], dAtA[iNdEx:postIndex]...)
+			if m.SendChain == nil {
+				m.SendChain = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendRound", wireType)
+			}
+			m.SendRound = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SendRound = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendStart", wireType)
+			}
+			m.SendStart = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SendStart = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendPending", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SendPending = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheirKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TheirKey = append(m.TheirKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.TheirKey == nil {
+				m.TheirKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecvChain", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecvChain = append(m.RecvChain[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecvChain == nil {
+				m.RecvChain = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecvRound", wireType)
+			}
+			m.RecvRound = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecvRound = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecvAnchored", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RecvAnchored = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -723,7 +3343,7 @@ func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GenerateMessagesRequest) Unmarshal(dAtA []byte) error {
+func (m *Stream) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -746,82 +3366,253 @@ func (m *GenerateMessagesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GenerateMessagesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Stream: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GenerateMessagesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Stream: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextSeq", wireType)
+			}
+			m.NextSeq = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextSeq = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 2:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSent", wireType)
+			}
+			m.LastSent = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastSent = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outbox", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Outbox = append(m.Outbox, &Outgoing{})
+			if err := m.Outbox[len(m.Outbox)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType == 5 {
+				var v uint32
+				if (iNdEx + 4) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+				iNdEx += 4
+				m.Resend = append(m.Resend, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowClient
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthClient
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					if (iNdEx + 4) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+					iNdEx += 4
+					m.Resend = append(m.Resend, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resend", wireType)
+			}
+		case 5:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerSent", wireType)
+			}
+			m.PeerSent = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerSent = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 6:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			}
+			m.Base = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Base = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fragments", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fragments = append(m.Fragments, &Fragment{})
+			if err := m.Fragments[len(m.Fragments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType == 5 {
+				var v uint32
+				if (iNdEx + 4) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+				iNdEx += 4
+				m.Reassembled = append(m.Reassembled, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowClient
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthClient
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					if (iNdEx + 4) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+					iNdEx += 4
+					m.Reassembled = append(m.Reassembled, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reassembled", wireType)
 			}
-			m.Round = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
 			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MsgSize", wireType)
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.MsgSize = 0
-			if (iNdEx + 8) > l {
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MsgSize = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		default:
-			iNdEx = preIndex
-			skippy, err := skipClient(dAtA[iNdEx:])
-			if err != nil {
+			m.Requests = append(m.Requests, &Request{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthClient
+			iNdEx = postIndex
+		case 10:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Highest", wireType)
 			}
-			if (iNdEx + skippy) > l {
+			m.Highest = 0
+			if (iNdEx + 4) > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GenerateMessagesResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowClient
+			m.Highest = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 11:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
 			}
-			if iNdEx >= l {
+			m.Last = 0
+			if (iNdEx + 4) > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GenerateMessagesResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GenerateMessagesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Last = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -843,7 +3634,7 @@ func (m *GenerateMessagesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubmitMessagesRequest) Unmarshal(dAtA []byte) error {
+func (m *Outgoing) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -866,72 +3657,169 @@ func (m *SubmitMessagesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubmitMessagesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Outgoing: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubmitMessagesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Outgoing: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
 			}
-			m.Round = 0
-			if (iNdEx + 8) > l {
+			m.Seq = 0
+			if (iNdEx + 4) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		default:
-			iNdEx = preIndex
-			skippy, err := skipClient(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			m.Seq = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthClient
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SubmitMessagesResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowClient
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
 			}
-			if iNdEx >= l {
+			iNdEx = postIndex
+		case 3:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FragmentSize", wireType)
+			}
+			m.FragmentSize = 0
+			if (iNdEx + 4) > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.FragmentSize = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 4:
+			if wireType == 0 {
+				var v Status
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowClient
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (Status(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Status = append(m.Status, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowClient
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthClient
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v Status
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowClient
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (Status(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Status = append(m.Status, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+		case 5:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				m.Rounds = append(m.Rounds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowClient
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthClient
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					m.Rounds = append(m.Rounds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rounds", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SubmitMessagesResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubmitMessagesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -953,7 +3841,7 @@ func (m *SubmitMessagesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DownloadMessagesRequest) Unmarshal(dAtA []byte) error {
+func (m *Fragment) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -976,22 +3864,91 @@ func (m *DownloadMessagesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DownloadMessagesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Fragment: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DownloadMessagesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Fragment: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
 			}
-			m.Round = 0
-			if (iNdEx + 8) > l {
+			m.Seq = 0
+			if (iNdEx + 4) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
+			m.Seq = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -1013,7 +3970,7 @@ func (m *DownloadMessagesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DownloadMessagesResponse) Unmarshal(dAtA []byte) error {
+func (m *Request) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1036,12 +3993,32 @@ func (m *DownloadMessagesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DownloadMessagesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DownloadMessagesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Seq = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -1171,25 +4148,73 @@ var (
 func init() { proto.RegisterFile("client.proto", fileDescriptorClient) }
 
 var fileDescriptorClient = []byte{
-	// 312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x5d, 0x4a, 0xc3, 0x40,
-	0x10, 0xc7, 0x9b, 0x82, 0xb1, 0x0e, 0x2a, 0x65, 0x69, 0x4d, 0x8c, 0x1a, 0xcb, 0x3e, 0xf9, 0x62,
-	0x05, 0xbd, 0x81, 0x0a, 0xa2, 0xa0, 0x60, 0x8a, 0xf8, 0x58, 0x52, 0x3b, 0x84, 0x40, 0xb3, 0x5b,
-	0x33, 0xbb, 0x08, 0x3d, 0x89, 0x47, 0xf2, 0xd1, 0x0b, 0x08, 0x12, 0x2f, 0x22, 0x26, 0xb1, 0xb5,
-	0x69, 0x17, 0xfb, 0xb8, 0xcc, 0xfc, 0x3f, 0xf8, 0xcd, 0xc2, 0xe6, 0xd3, 0x28, 0x46, 0xa1, 0xba,
-	0xe3, 0x54, 0x2a, 0xc9, 0xec, 0xe2, 0xc5, 0xaf, 0xa1, 0x15, 0x60, 0x14, 0x93, 0xc2, 0xf4, 0x81,
-	0x30, 0xa5, 0x00, 0x9f, 0x35, 0x92, 0x62, 0x2d, 0x58, 0x4b, 0xa5, 0x16, 0x43, 0xd7, 0xea, 0x58,
-	0x47, 0x76, 0x50, 0x3c, 0xd8, 0x1e, 0x6c, 0x08, 0x9d, 0xf4, 0xf5, 0xcf, 0xa6, 0x5b, 0xcf, 0x27,
-	0x0d, 0xa1, 0x93, 0x5c, 0xc9, 0x1d, 0x68, 0x57, 0xac, 0x68, 0x2c, 0x05, 0x21, 0xbf, 0x01, 0xe7,
-	0x0a, 0x05, 0xa6, 0xa1, 0xc2, 0x5b, 0x24, 0x0a, 0x23, 0xfc, 0x27, 0x66, 0x17, 0x1a, 0x09, 0x45,
-	0x7d, 0x8a, 0x27, 0x58, 0xa6, 0xac, 0x27, 0x14, 0xf5, 0xe2, 0x09, 0x72, 0x0f, 0xdc, 0x45, 0xaf,
-	0x32, 0xe7, 0x18, 0xda, 0x3d, 0x3d, 0x48, 0x62, 0xb5, 0x52, 0x0a, 0x77, 0x61, 0xa7, 0xba, 0x5e,
-	0x1a, 0x9d, 0x80, 0x73, 0x29, 0x5f, 0xc4, 0x48, 0x86, 0xc3, 0xd5, 0xac, 0x3c, 0x70, 0x17, 0x05,
-	0x85, 0xd9, 0xe9, 0x47, 0x1d, 0xec, 0x8b, 0x1c, 0x36, 0xbb, 0x83, 0xad, 0x39, 0x42, 0x6c, 0xbf,
-	0x5b, 0x1e, 0x65, 0xd9, 0x0d, 0xbc, 0x03, 0xc3, 0xb4, 0x6c, 0x59, 0x63, 0x8f, 0xd0, 0xac, 0xc2,
-	0x60, 0x87, 0xbf, 0x22, 0x03, 0x72, 0xaf, 0x63, 0x5e, 0x98, 0x1a, 0xdf, 0xc3, 0xf6, 0x3c, 0x1a,
-	0x36, 0xed, 0xb2, 0x94, 0xb0, 0xe7, 0x9b, 0xc6, 0x7f, 0xbb, 0x56, 0x11, 0xcd, 0xba, 0x1a, 0x68,
-	0xcf, 0xba, 0x9a, 0xe8, 0xf2, 0xda, 0x79, 0xf3, 0x2d, 0xf3, 0xad, 0xf7, 0xcc, 0xb7, 0x3e, 0x33,
-	0xdf, 0x7a, 0xfd, 0xf2, 0x6b, 0x03, 0x3b, 0xff, 0xe2, 0x67, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xdc, 0x57, 0xe7, 0x7f, 0xf2, 0x02, 0x00, 0x00,
+	// 1079 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0xcf, 0xd9, 0xf1, 0xdd, 0x79, 0xe2, 0x04, 0x6b, 0x95, 0x36, 0x87, 0x69, 0x5c, 0x73, 0x48,
+	0xc8, 0x2a, 0x22, 0x40, 0x78, 0x47, 0x6a, 0x63, 0x53, 0x85, 0xf4, 0x0f, 0x5d, 0x37, 0xf4, 0x05,
+	0x29, 0x3a, 0x9f, 0x07, 0xfb, 0x88, 0xbd, 0xe7, 0xdc, 0xee, 0xa5, 0x49, 0x3e, 0x05, 0x8f, 0x48,
+	0x48, 0x7c, 0x1e, 0x1e, 0xf9, 0x02, 0x48, 0x55, 0xf8, 0x22, 0xd5, 0xec, 0xee, 0xd9, 0xb1, 0x13,
+	0xab, 0x7d, 0xdb, 0xf9, 0xcd, 0xec, 0xcc, 0xef, 0x66, 0xe7, 0xcf, 0x41, 0x2d, 0x1e, 0x27, 0x28,
+	0xd4, 0xde, 0x34, 0x4b, 0x55, 0xca, 0x5c, 0x23, 0x85, 0x57, 0xb0, 0xcd, 0x71, 0x98, 0x48, 0x85,
+	0xd9, 0xb1, 0xc4, 0x4c, 0x72, 0x3c, 0xcb, 0x51, 0x2a, 0xb6, 0x0d, 0x95, 0x2c, 0xcd, 0xc5, 0x20,
+	0x70, 0x5a, 0x4e, 0xdb, 0xe5, 0x46, 0x60, 0x9f, 0x41, 0x55, 0xe4, 0x93, 0x93, 0x9c, 0x2c, 0x83,
+	0x92, 0xd6, 0xf8, 0x22, 0x9f, 0xe8, 0x9b, 0x8c, 0xc1, 0xfa, 0x20, 0x89, 0xc6, 0x41, 0xb9, 0xe5,
+	0xb4, 0x7d, 0xae, 0xcf, 0x74, 0x41, 0xa5, 0xa7, 0x28, 0x4e, 0x4e, 0xf1, 0x32, 0x58, 0x6f, 0x39,
+	0xed, 0x1a, 0xf7, 0x35, 0x70, 0x84, 0x97, 0xe1, 0x0e, 0xdc, 0x5b, 0x8a, 0x2d, 0xa7, 0xa9, 0x90,
+	0x18, 0xfe, 0x04, 0x3b, 0x4f, 0x51, 0x60, 0x16, 0x29, 0x7c, 0x8e, 0x52, 0x46, 0x43, 0xfc, 0x00,
+	0xaf, 0x4f, 0xc1, 0x9f, 0xc8, 0xe1, 0x89, 0x4c, 0xae, 0xd0, 0xd2, 0xf2, 0x26, 0x72, 0xd8, 0x4b,
+	0xae, 0x30, 0x6c, 0x40, 0x70, 0xdb, 0x97, 0x8d, 0xf3, 0x35, 0xdc, 0xeb, 0xe5, 0xfd, 0x49, 0xa2,
+	0x3e, 0x2a, 0x4a, 0x18, 0xc0, 0xfd, 0x65, 0x73, 0xeb, 0xe8, 0x1b, 0xd8, 0xe9, 0xa4, 0x6f, 0xc5,
+	0x38, 0x8d, 0x06, 0x1f, 0xe7, 0xea, 0x6f, 0x07, 0x82, 0xdb, 0x37, 0x8c, 0x37, 0xd6, 0x00, 0x1f,
+	0x2f, 0xa6, 0x18, 0x2b, 0x2c, 0x6e, 0xcd, 0x64, 0xd2, 0x65, 0x18, 0x63, 0x72, 0x8e, 0x83, 0xe2,
+	0x01, 0x0a, 0x99, 0x35, 0x01, 0x12, 0x11, 0xa7, 0x93, 0xe9, 0x18, 0x15, 0xea, 0x67, 0x70, 0xf9,
+	0x0d, 0x84, 0x3d, 0x02, 0x37, 0x1e, 0x45, 0x89, 0x90, 0xc1, 0x7a, 0xab, 0xdc, 0xde, 0xd8, 0x67,
+	0x7b, 0xb6, 0x24, 0x0e, 0x08, 0x3d, 0x18, 0x61, 0x7c, 0xca, 0xad, 0x45, 0xf8, 0x87, 0x03, 0x30,
+	0x87, 0x59, 0x1d, 0xca, 0xc3, 0xc4, 0xb0, 0xa9, 0x72, 0x3a, 0x2e, 0x90, 0x2c, 0x2d, 0x91, 0x7c,
+	0x00, 0xd5, 0x01, 0x8e, 0x93, 0x73, 0xcc, 0x70, 0x60, 0x79, 0xcc, 0x01, 0x16, 0x80, 0x37, 0x49,
+	0xa4, 0x4c, 0xc4, 0x30, 0x58, 0xb7, 0x6f, 0x65, 0x44, 0xba, 0x17, 0xa7, 0x59, 0x96, 0x4f, 0xc9,
+	0x69, 0xc5, 0xdc, 0x9b, 0x01, 0xe1, 0x0f, 0x00, 0x87, 0x03, 0x14, 0x2a, 0x51, 0x09, 0x4a, 0xf6,
+	0x2d, 0x40, 0x32, 0x93, 0x02, 0x47, 0x7f, 0x50, 0xbd, 0xf8, 0x20, 0x6b, 0x77, 0xc9, 0x6f, 0xd8,
+	0x84, 0x6f, 0xc1, 0x2f, 0x70, 0xb6, 0x0b, 0x30, 0xcd, 0xfb, 0xe3, 0x24, 0xd6, 0x85, 0xe9, 0xe8,
+	0xc2, 0xac, 0x1a, 0xe4, 0x08, 0x2f, 0xd9, 0x43, 0xd8, 0x98, 0x66, 0xc9, 0x79, 0xa4, 0x50, 0xeb,
+	0x4b, 0x5a, 0x0f, 0x16, 0x22, 0x83, 0xaf, 0xc0, 0x8f, 0x53, 0xa1, 0xa2, 0x58, 0xc9, 0xa0, 0xac,
+	0x63, 0x7f, 0x32, 0x4b, 0xa6, 0xc1, 0xf9, 0xcc, 0x20, 0xfc, 0x1d, 0x3c, 0x0b, 0x52, 0x8f, 0x88,
+	0x68, 0x82, 0x36, 0x91, 0xfa, 0xbc, 0xc4, 0xa5, 0xb4, 0xcc, 0x65, 0x1b, 0x2a, 0x52, 0x45, 0xf6,
+	0x41, 0x6b, 0xdc, 0x08, 0xec, 0x3e, 0xb8, 0x52, 0x65, 0x18, 0x4d, 0x6c, 0x57, 0x59, 0x29, 0xbc,
+	0x2e, 0x41, 0xed, 0x20, 0x15, 0xe7, 0x98, 0xc9, 0x48, 0x25, 0xa9, 0xa0, 0x88, 0x59, 0x9a, 0x2a,
+	0xfb, 0x8d, 0xfa, 0xfc, 0xe1, 0xcf, 0x5b, 0xa4, 0x54, 0x5e, 0xa6, 0xb4, 0x0b, 0x20, 0x51, 0x0c,
+	0x4e, 0x74, 0xad, 0x58, 0x02, 0x55, 0x42, 0x74, 0xc5, 0xcc, 0xd4, 0xa6, 0xee, 0xed, 0x3b, 0x12,
+	0xc2, 0x09, 0x98, 0xa9, 0xa5, 0x8a, 0x32, 0x15, 0xb8, 0x73, 0x75, 0x8f, 0x00, 0xf6, 0x39, 0xd4,
+	0xb4, 0x7a, 0x8a, 0x62, 0x40, 0x35, 0xe2, 0xe9, 0x71, 0xb2, 0x41, 0xd8, 0xcf, 0x06, 0xd2, 0x53,
+	0x65, 0x84, 0x49, 0xa6, 0xd9, 0xf9, 0x76, 0xaa, 0x10, 0x60, 0xc9, 0x65, 0x18, 0x9f, 0x5b, 0x72,
+	0x55, 0x43, 0x8e, 0x90, 0x19, 0x39, 0xad, 0x36, 0xe4, 0xc0, 0x44, 0x27, 0xc4, 0x90, 0xfb, 0x02,
+	0x36, 0xb5, 0x3a, 0x12, 0xf1, 0x28, 0xa5, 0xf2, 0xdd, 0xd0, 0xe1, 0x6b, 0x04, 0x3e, 0xb6, 0x58,
+	0xf8, 0xae, 0x04, 0x6e, 0x4f, 0xe7, 0x9b, 0x26, 0x8f, 0xc0, 0x0b, 0x75, 0x22, 0xf1, 0x4c, 0xa7,
+	0xd8, 0xe3, 0x1e, 0xc9, 0x3d, 0x3c, 0x23, 0x96, 0xe3, 0x48, 0x92, 0x4a, 0x28, 0x9d, 0x63, 0x8f,
+	0xfb, 0x04, 0xf4, 0x50, 0x28, 0xd6, 0x06, 0x37, 0xcd, 0x55, 0x3f, 0xbd, 0x08, 0xca, 0x8b, 0xa5,
+	0xfb, 0x32, 0x57, 0xc3, 0x34, 0x11, 0x43, 0x6e, 0xf5, 0xf4, 0xd2, 0x19, 0xd2, 0xd7, 0xeb, 0xae,
+	0xf5, 0xb8, 0x95, 0xc8, 0xfd, 0x14, 0x31, 0x33, 0xee, 0x2b, 0xc6, 0x3d, 0x01, 0xda, 0x3d, 0x83,
+	0xf5, 0x7e, 0x24, 0x51, 0x67, 0xd7, 0xe3, 0xfa, 0xcc, 0xf6, 0xa0, 0xfa, 0x5b, 0x16, 0x0d, 0x27,
+	0x28, 0x94, 0x0c, 0xbc, 0xc5, 0xa8, 0x3f, 0x5a, 0x05, 0x9f, 0x9b, 0xb0, 0x16, 0x6c, 0x64, 0x18,
+	0x49, 0x89, 0x93, 0xfe, 0x18, 0x07, 0x81, 0xaf, 0xa3, 0xdf, 0x84, 0xa8, 0x0b, 0x32, 0x33, 0xe6,
+	0x64, 0x50, 0x5d, 0xec, 0x02, 0x3b, 0xfe, 0xf8, 0xcc, 0x80, 0xda, 0x7e, 0x94, 0x0c, 0x47, 0x28,
+	0x95, 0xce, 0xba, 0xc7, 0x0b, 0x91, 0xc8, 0x52, 0x5e, 0x74, 0xaa, 0x3d, 0xae, 0xcf, 0xe1, 0x5f,
+	0x0e, 0xf8, 0x45, 0x2a, 0x68, 0xfa, 0xcc, 0xf3, 0x4b, 0x47, 0x72, 0x36, 0x8d, 0x2e, 0x69, 0x7a,
+	0xda, 0xea, 0x2d, 0x44, 0x7a, 0xc0, 0xe2, 0x13, 0xcc, 0x3e, 0x28, 0xeb, 0x5b, 0xb5, 0x02, 0xa4,
+	0xa5, 0xc0, 0xbe, 0xa4, 0xee, 0x89, 0x54, 0x6e, 0x26, 0xe1, 0xd6, 0xfe, 0x56, 0x41, 0xbb, 0xa7,
+	0x51, 0x6e, 0xb5, 0x3a, 0xf7, 0x54, 0x16, 0x32, 0xa8, 0xb4, 0xca, 0x6d, 0x97, 0x5b, 0x29, 0xfc,
+	0x15, 0xfc, 0x22, 0x63, 0x77, 0x90, 0xdb, 0x86, 0x4a, 0x22, 0x06, 0x78, 0xa1, 0xa9, 0x6d, 0x72,
+	0x23, 0x10, 0x1a, 0xa7, 0xb9, 0x50, 0x9a, 0xd0, 0x26, 0x37, 0x82, 0x5e, 0x9a, 0x91, 0x8a, 0x6c,
+	0x13, 0xe9, 0x73, 0xf8, 0x1d, 0x78, 0xc5, 0xf6, 0xb8, 0xd3, 0xb9, 0x29, 0xdd, 0xd2, 0x8d, 0x7d,
+	0xf2, 0xa8, 0x43, 0x05, 0xa9, 0x29, 0x03, 0xb8, 0xaf, 0x8e, 0xbb, 0xc7, 0xdd, 0x4e, 0x7d, 0x8d,
+	0x6d, 0x42, 0xb5, 0x77, 0xfc, 0xe4, 0xf9, 0xe1, 0xeb, 0xd7, 0xdd, 0x4e, 0xdd, 0x21, 0xb1, 0xd3,
+	0x7d, 0x76, 0xf8, 0x4b, 0x97, 0x77, 0x3b, 0xf5, 0x12, 0xab, 0x43, 0xed, 0xf1, 0xc1, 0xd1, 0x8b,
+	0x97, 0x6f, 0x9e, 0x75, 0x3b, 0x4f, 0xbb, 0x9d, 0x7a, 0x79, 0xff, 0xbf, 0x12, 0xb8, 0x07, 0x3a,
+	0x11, 0xec, 0x05, 0x6c, 0x2e, 0xec, 0x66, 0xf6, 0x60, 0xfe, 0xb2, 0xb7, 0x7f, 0x17, 0x1a, 0xbb,
+	0x2b, 0xb4, 0x76, 0x3f, 0xae, 0xb1, 0x37, 0x50, 0x5f, 0x5e, 0xc3, 0xec, 0x61, 0x71, 0x69, 0xc5,
+	0xb2, 0x6f, 0xb4, 0x56, 0x1b, 0xcc, 0x1c, 0xbf, 0x82, 0xad, 0xc5, 0xa5, 0xcc, 0x66, 0x5c, 0xee,
+	0xdc, 0xed, 0x8d, 0xe6, 0x2a, 0xf5, 0x4d, 0xae, 0xcb, 0xbb, 0x79, 0xce, 0x75, 0xc5, 0x9e, 0x6f,
+	0xb4, 0x56, 0x1b, 0x14, 0x8e, 0x9f, 0xd4, 0xff, 0xb9, 0x6e, 0x3a, 0xff, 0x5e, 0x37, 0x9d, 0x77,
+	0xd7, 0x4d, 0xe7, 0xcf, 0xff, 0x9b, 0x6b, 0x7d, 0x57, 0xff, 0x8d, 0x7d, 0xff, 0x7e, 0x00, 0x87,
+	0x60, 0x5e, 0xf3, 0x9d, 0x09, 0x00, 0x00,
 }
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// Conversations encrypt the mails between two peers with keys that
+// change every round, so a compromised key only exposes a few rounds.
+//
+// The peers agree on a root key from their long-term keys once. Each
+// direction has a chain of keys, one per round, and the key of a mail
+// is derived from the key of its round and its chain (gid). A chain key
+// is erased once its round is over, for forward secrecy. The peers also
+// take turns replacing their ratchet key pairs: a peer that receives a
+// new ratchet key from the other mixes a Diffie-Hellman of the two into
+// the root key for its receiving chain, then picks a new key pair and
+// does the same for its sending chain. Once both peers have a new key
+// pair, an attacker who had the old state is locked out again.
+//
+// The peer with the smaller long-term key starts with a new key pair,
+// and the other one starts with its long-term key pair.
+//
+// A mail is a header, with the ratchet public key of the sender and the
+// round its sending chain started, under a key of the long-term keys,
+// followed by the payload under the key of the mail. Both are sealed
+// with the nonce of the round and chain. A conversation mail is as
+// long as a box of the full message, so it looks like a loopback.
+
+const (
+	headerSize = 32 + 8
+	// bytes of a message taken by the header and its authenticator
+	convOverhead = headerSize + secretbox.Overhead
+
+	// most rounds a chain is advanced by at once
+	maxSkip = 1 << 16
+)
+
+var errNotConversation = errors.New("Not a mail of the conversation")
+
+// newConversation returns the initial state of the conversation of pub
+// with peer.
+func newConversation(pub, priv, peer *[32]byte) (*Conversation, error) {
+	var shared [32]byte
+	box.Precompute(&shared, peer, priv)
+	initiator := bytes.Compare(pub[:], peer[:]) < 0
+	low, high := pub, peer
+	if !initiator {
+		low, high = peer, pub
+	}
+	info := append([]byte("xrd conversation"), low[:]...)
+	info = append(info, high[:]...)
+	root := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared[:], nil, info), root); err != nil {
+		return nil, err
+	}
+	// the first chain of the peer that starts with its long-term key
+	first := kdf(root, []byte("first chain"))
+
+	conv := &Conversation{Root: root}
+	if initiator {
+		conv.TheirKey = peer[:]
+		conv.RecvChain = first
+		if err := conv.ratchetSend(peer); err != nil {
+			return nil, err
+		}
+	} else {
+		conv.PrivateKey = priv[:]
+		conv.PublicKey = pub[:]
+		conv.SendChain = first
+		conv.SendPending = true
+	}
+	return conv, nil
+}
+
+func kdf(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// kdfRoot mixes a Diffie-Hellman into the root key, and returns the
+// next root key and the first key of a chain.
+func kdfRoot(root []byte, priv, pub *[32]byte) ([]byte, []byte, error) {
+	var dh [32]byte
+	box.Precompute(&dh, pub, priv)
+	out := make([]byte, 64)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, dh[:], root, []byte("xrd ratchet")), out); err != nil {
+		return nil, nil, err
+	}
+	return out[:32], out[32:], nil
+}
+
+// advance returns the key of the chain in round to, given its key in
+// round from.
+func advance(chain []byte, from, to uint64) []byte {
+	if to-from > maxSkip {
+		panic("Advancing a chain too far")
+	}
+	for r := from; r < to; r++ {
+		chain = kdf(chain, []byte{0})
+	}
+	return chain
+}
+
+func mailKey(chain []byte, gid string) *[32]byte {
+	var key [32]byte
+	copy(key[:], kdf(chain, append([]byte{1}, gid...)))
+	return &key
+}
+
+// headerKey is the key of the headers from sender, between the owner of
+// priv and peer.
+func headerKey(sender, peer, priv *[32]byte) *[32]byte {
+	var shared [32]byte
+	box.Precompute(&shared, peer, priv)
+	var key [32]byte
+	copy(key[:], kdf(shared[:], append([]byte("xrd header"), sender[:]...)))
+	return &key
+}
+
+// ratchetSend picks a new key pair, and starts a new sending chain
+// with the ratchet key of the peer.
+func (conv *Conversation) ratchetSend(theirs *[32]byte) error {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	root, chain, err := kdfRoot(conv.Root, priv, theirs)
+	if err != nil {
+		return err
+	}
+	conv.Root = root
+	conv.PrivateKey = priv[:]
+	conv.PublicKey = pub[:]
+	conv.SendChain = chain
+	conv.SendPending = true
+	return nil
+}
+
+// seal encrypts the packet msg to peer, for round through the chain
+// gid, and erases the key of the round. A round can only be sent in
+// once.
+func (conv *Conversation) seal(round uint64, gid string, pub, priv, peer *[32]byte, msg []byte) ([]byte, error) {
+	if conv.SendPending {
+		conv.SendStart = round
+		conv.SendRound = round
+		conv.SendPending = false
+	}
+	if !skippable(conv.SendRound, round) {
+		return nil, errors.New("Round already sent in, or too far ahead")
+	}
+	chain := advance(conv.SendChain, conv.SendRound, round)
+
+	header := make([]byte, headerSize)
+	copy(header, conv.PublicKey)
+	binary.BigEndian.PutUint64(header[32:], conv.SendStart)
+	nonce := mailNonce(round, gid)
+	sealed := secretbox.Seal(nil, header, nonce, headerKey(pub, peer, priv))
+	sealed = secretbox.Seal(sealed, msg, nonce, mailKey(chain, gid))
+
+	conv.SendChain = advance(chain, round, round+1)
+	conv.SendRound = round + 1
+	return sealed, nil
+}
+
+// open decrypts a mail from peer of round through the chain gid. The
+// state only changes if it is a mail of the conversation.
+func (conv *Conversation) open(round uint64, gid string, priv, peer *[32]byte, sealed []byte) ([]byte, error) {
+	if len(sealed) < convOverhead+secretbox.Overhead {
+		return nil, errNotConversation
+	}
+	nonce := mailNonce(round, gid)
+	header, ok := secretbox.Open(nil, sealed[:convOverhead], nonce, headerKey(peer, peer, priv))
+	if !ok {
+		return nil, errNotConversation
+	}
+	var theirs [32]byte
+	copy(theirs[:], header)
+	start := binary.BigEndian.Uint64(header[32:])
+
+	var root, chain []byte
+	ratchet := !bytes.Equal(theirs[:], conv.TheirKey)
+	switch {
+	case ratchet:
+		var mine [32]byte
+		copy(mine[:], conv.PrivateKey)
+		var err error
+		root, chain, err = kdfRoot(conv.Root, &mine, &theirs)
+		if err != nil {
+			return nil, err
+		}
+		if !skippable(start, round) {
+			return nil, errNotConversation
+		}
+		chain = advance(chain, start, round)
+	case conv.RecvAnchored:
+		if !skippable(conv.RecvRound, round) {
+			return nil, errNotConversation
+		}
+		chain = advance(conv.RecvChain, conv.RecvRound, round)
+	default:
+		if !skippable(start, round) {
+			return nil, errNotConversation
+		}
+		chain = advance(conv.RecvChain, start, round)
+	}
+
+	msg, ok := secretbox.Open(nil, sealed[convOverhead:], nonce, mailKey(chain, gid))
+	if !ok {
+		return nil, errNotConversation
+	}
+
+	if ratchet {
+		conv.Root = root
+		conv.TheirKey = theirs[:]
+		if err := conv.ratchetSend(&theirs); err != nil {
+			return nil, err
+		}
+	}
+	conv.RecvChain = advance(chain, round, round+1)
+	conv.RecvRound = round + 1
+	conv.RecvAnchored = true
+	return msg, nil
+}
+
+func skippable(from, to uint64) bool {
+	return from <= to && to-from <= maxSkip
+}
+
+// skip erases the receiving chain keys up to round, when nothing came
+// from the peer.
+func (conv *Conversation) skip(round uint64) {
+	if conv.RecvAnchored && skippable(conv.RecvRound, round) {
+		conv.RecvChain = advance(conv.RecvChain, conv.RecvRound, round+1)
+		conv.RecvRound = round + 1
+	}
+}
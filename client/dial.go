@@ -0,0 +1,261 @@
+package client
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"log"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/net/context"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mailbox"
+)
+
+// Dialing starts a conversation with a user whose key is known, without
+// anyone else learning that they talk.
+//
+// Dial rounds are the rounds the coordinator starts with NewDialRound,
+// and go through the same chains as the other rounds. In a dial round,
+// a user registers its invitation inbox instead of its inbox, and sends
+// one mail through each of its chains as usual: an invitation to the
+// invitation inbox of a user it dials, through the chain they share, or
+// a loopback to its own invitation inbox. A dial round looks the same
+// whether the user dials or not. The users dialed in a round are picked
+// when it is registered, at most one per chain.
+//
+// The invitation inbox of a user is indexed by a hash of its identity
+// key, so any caller can find it. The mailboxes only let the owner of a
+// key read its inbox, so the key pair of the inbox is derived from that
+// hash, and anyone can read it. The invitations are sealed to the
+// identity key of the callee with a fresh key pair, and the loopbacks
+// are invitations from the user to itself, so a reader only learns how
+// many mails there are, which the count of mails tells anyway.
+// An invitation inbox is registered for a mail through every chain
+// whether the user dials or not, an invitation it sends replacing its
+// loopback through that chain, and takes up to maxInvitations
+// invitations a round on top, through any chain. Downloading it only
+// waits for as many mails as the user sent loopbacks: an invitation
+// delivered after the last loopback of the callee (in a later batch of
+// the same chain) is missed.
+//
+// An invitation is
+//   [fresh public key (32)][box of [caller key (32)][authenticator (16)][padding]]
+// where the authenticator is an empty box from the caller to the
+// callee, so no one can dial on behalf of someone else. The callee
+// accepts every invitation, and the caller and the callee are peers
+// from the next round on.
+
+const (
+	maxInvitations = 64
+
+	// caller key and authenticator
+	invitationSize = 32 + box.Overhead
+)
+
+var ErrDialSelf = errors.New("Cannot dial oneself")
+
+// invitationKeys returns the key pair of the invitation inbox of key.
+func invitationKeys(key *[32]byte) (*[32]byte, *[32]byte) {
+	priv := new([32]byte)
+	*priv = sha256.Sum256(append([]byte("xrd invitation"), key[:]...))
+	pub := new([32]byte)
+	curve25519.ScalarBaseMult(pub, priv)
+	return pub, priv
+}
+
+// sealInvitation returns the invitation from caller to callee for round
+// through the chain gid, as a mail of msgSize.
+func sealInvitation(round uint64, gid string, caller, callerPriv, callee *[32]byte, msgSize int) (*mailbox.Mail, error) {
+	nonce := mailNonce(round, gid)
+	msg := make([]byte, msgSize-32)
+	copy(msg, caller[:])
+	box.Seal(msg[32:32], nil, nonce, callee, callerPriv)
+
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	sealed := box.Seal(append([]byte(nil), pub[:]...), msg, nonce, callee, priv)
+	wipe(priv[:])
+	inbox, _ := invitationKeys(callee)
+	return &mailbox.Mail{UserKey: inbox[:], Message: sealed}, nil
+}
+
+// openInvitation returns the caller of an invitation to the owner of
+// priv, of round through the chain gid.
+func openInvitation(round uint64, gid string, priv *[32]byte, sealed []byte) (*[32]byte, bool) {
+	if len(sealed) < 32+invitationSize+box.Overhead {
+		return nil, false
+	}
+	nonce := mailNonce(round, gid)
+	var pub [32]byte
+	copy(pub[:], sealed)
+	msg, ok := box.Open(nil, sealed[32:], nonce, &pub, priv)
+	if !ok {
+		return nil, false
+	}
+	caller := new([32]byte)
+	copy(caller[:], msg)
+	if _, ok := box.Open(nil, msg[32:invitationSize], nonce, caller, priv); !ok {
+		return nil, false
+	}
+	return caller, true
+}
+
+func (usr *user) Dial(peer *[32]byte) error {
+	if *peer == *usr.publicKey {
+		return ErrDialSelf
+	}
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+	for _, p := range usr.dials {
+		if *p == *peer {
+			return nil
+		}
+	}
+	if len(usr.peers)+len(usr.dials) >= usr.maxPeers() {
+		return ErrTooManyPeers
+	}
+	usr.dials = append(usr.dials, peer)
+	return nil
+}
+
+// planDials picks the users to dial in round, by the chain to send
+// their invitations through.
+func (usr *user) planDials(round uint64, chains []*config.Group) map[string]*[32]byte {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+
+	plan := make(map[string]*[32]byte)
+	var waiting []*[32]byte
+	for _, peer := range usr.dials {
+		chain, ok := sharedChain(chains, usr.chains(peer, round))
+		// no chain shared this round, or it is taken: dial it in the
+		// next dial round
+		if !ok || plan[chain.Gid] != nil {
+			waiting = append(waiting, peer)
+			continue
+		}
+		plan[chain.Gid] = peer
+	}
+	usr.dials = waiting
+	usr.dialRounds[round] = plan
+	return plan
+}
+
+// registerInvitations registers the invitation inbox of the user for
+// round, with as many mails as chains, so the mailboxes do not learn
+// how many users it dials.
+func (usr *user) registerInvitations(rpcs map[string]mailbox.MailboxClient, round uint64, chains []*config.Group) error {
+	plan := usr.planDials(round, chains)
+	expected := uint64(len(chains))
+	inbox, _ := invitationKeys(usr.publicKey)
+	err := registerAtReplicas(rpcs, usr.ring.Replicas(*inbox), round,
+		[][]byte{inbox[:]}, []uint64{expected},
+		[]uint64{expected + maxInvitations}, nil, false)
+	if err != nil { // dial them in the next dial round
+		usr.mu.Lock()
+		for _, peer := range plan {
+			usr.dials = append(usr.dials, peer)
+		}
+		delete(usr.dialRounds, round)
+		usr.mu.Unlock()
+	}
+	return err
+}
+
+// dialMails returns the mail of the dial round through each chain. The
+// users dialed become peers, and the others are dropped from plan.
+func (usr *user) dialMails(round uint64, chains []*config.Group, plan map[string]*[32]byte) (map[string]*mailbox.Mail, error) {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+
+	mails := make(map[string]*mailbox.Mail)
+	for _, group := range chains {
+		// callers may have taken the chains left since the round started
+		callee := usr.publicKey
+		if peer, ok := plan[group.Gid]; ok && usr.addPeer(peer) == nil {
+			callee = peer
+		} else {
+			delete(plan, group.Gid)
+		}
+		mail, err := sealInvitation(round, group.Gid, usr.publicKey, usr.privateKey, callee, usr.msgSize)
+		if err != nil {
+			return nil, err
+		}
+		mails[group.Gid] = mail
+	}
+	return mails, nil
+}
+
+// downloadInvitations gets the invitation inbox of the dial round, and
+// adds the callers as peers. The invitations the user sent count as
+// received, in place of their loopbacks.
+func (usr *user) downloadInvitations(ctx context.Context, rpcs map[string]mailbox.MailboxClient, round uint64, plan map[string]*[32]byte) (*DownloadMessagesResponse, error) {
+	chains := usr.chains(usr.publicKey, round)
+	loopbacks := uint64(len(chains) - len(plan))
+
+	inbox, inboxPriv := invitationKeys(usr.publicKey)
+	var inboxes []*mailbox.Inbox
+	var err error
+	for _, mid := range usr.ring.Replicas(*inbox) {
+		inboxes, err = getInboxes(rpcs[mid], round, []*[32]byte{inbox}, []*[32]byte{inboxPriv}, []uint64{loopbacks})
+		if err == nil {
+			break
+		}
+		log.Println("Could not get invitations from", mid, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the mails do not say which chain they came from
+	resp := &DownloadMessagesResponse{Expected: uint64(len(chains))}
+	var callers []*[32]byte
+	for _, in := range inboxes {
+	mails:
+		for _, ciphertext := range in.Messages {
+			for _, group := range chains {
+				caller, ok := openInvitation(round, group.Gid, usr.privateKey, ciphertext)
+				if !ok {
+					continue
+				}
+				if *caller == *usr.publicKey {
+					resp.Received++
+				} else {
+					callers = append(callers, caller)
+				}
+				continue mails
+			}
+		}
+	}
+	if resp.Received < loopbacks {
+		resp.Incomplete++
+	}
+	resp.Received += uint64(len(plan))
+
+	// a user with as many peers as chains turns callers down, and they
+	// wait for a message that never comes
+	usr.mu.Lock()
+	accepted := callers[:0]
+	for _, caller := range callers {
+		if err := usr.addPeer(caller); err != nil {
+			log.Println("Could not accept invitation:", err)
+			continue
+		}
+		accepted = append(accepted, caller)
+	}
+	usr.mu.Unlock()
+
+	for _, caller := range accepted {
+		select {
+		case usr.received <- &Message{Round: round, From: *caller, Dial: true}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mailbox"
+)
+
+func TestPlanDials(t *testing.T) {
+	groups := make(map[string]*config.Group)
+	for g := 0; g < 10; g++ {
+		gid := fmt.Sprintf("group:%d", g)
+		groups[gid] = &config.Group{Gid: gid, Row: uint32(g)}
+	}
+
+	tests := []struct {
+		name  string
+		dials int
+	}{
+		{"none", 0},
+		{"one", 1},
+		{"some", 3},
+		{"more than chains", 20},
+	}
+	for round, test := range tests {
+		usr := &user{
+			assigner:   config.NewAssigner(groups),
+			dialRounds: make(map[uint64]map[string]*[32]byte),
+		}
+		usr.publicKey, usr.privateKey, _ = box.GenerateKey(rand.Reader)
+		peers := make([]*[32]byte, test.dials)
+		for i := range peers {
+			peers[i], _, _ = box.GenerateKey(rand.Reader)
+		}
+		usr.dials = append([]*[32]byte(nil), peers...)
+
+		chains := usr.chains(usr.publicKey, uint64(round))
+		plan := usr.planDials(uint64(round), chains)
+		if len(usr.dialRounds[uint64(round)]) != len(plan) {
+			t.Fatal("Plan not kept", test.name)
+		}
+		if test.dials > 0 && len(plan) == 0 {
+			t.Fatal("No one dialed", test.name)
+		}
+
+		// every peer goes through its first shared chain, or waits if
+		// an earlier peer took it
+		taken := make(map[string]*[32]byte)
+		waiting := 0
+		for _, peer := range peers {
+			chain, ok := sharedChain(chains, usr.chains(peer, uint64(round)))
+			if !ok {
+				t.Fatal("Peers share no chain", test.name)
+			}
+			if taken[chain.Gid] != nil {
+				if usr.dials[waiting] != peer {
+					t.Fatal("Peer not waiting", test.name)
+				}
+				waiting++
+				continue
+			}
+			taken[chain.Gid] = peer
+			if plan[chain.Gid] != peer {
+				t.Fatal("Peer not dialed through its shared chain", test.name)
+			}
+		}
+		if len(taken) != len(plan) || waiting != len(usr.dials) {
+			t.Fatal("Wrong plan", test.name, len(plan), len(usr.dials))
+		}
+	}
+}
+
+func TestLoopbacksLookLikeInvitations(t *testing.T) {
+	msgSize := 256
+	callee, calleePriv, _ := box.GenerateKey(rand.Reader)
+	caller, callerPriv, _ := box.GenerateKey(rand.Reader)
+
+	loopback, err := sealInvitation(1, "group:0", callee, calleePriv, callee, msgSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invitation, err := sealInvitation(1, "group:0", caller, callerPriv, callee, msgSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(loopback.UserKey, invitation.UserKey) || len(loopback.Message) != len(invitation.Message) {
+		t.Fatal("Loopback and invitation differ in shape")
+	}
+
+	// the key of the inbox, which anyone can derive, opens neither
+	inbox, inboxPriv := invitationKeys(callee)
+	nonce := mailNonce(1, "group:0")
+	for _, mail := range []*mailbox.Mail{loopback, invitation} {
+		for _, from := range []*[32]byte{callee, caller, inbox} {
+			if mailbox.OpenMail(from, inboxPriv, nonce, mail.Message) != nil {
+				t.Fatal("Mail opened with the key of the inbox")
+			}
+		}
+		if _, ok := openInvitation(1, "group:0", inboxPriv, mail.Message); ok {
+			t.Fatal("Mail opened with the key of the inbox")
+		}
+	}
+
+	// only the owner tells them apart
+	if from, ok := openInvitation(1, "group:0", calleePriv, loopback.Message); !ok || *from != *callee {
+		t.Fatal("Loopback not opened by its owner")
+	}
+	if from, ok := openInvitation(1, "group:0", calleePriv, invitation.Message); !ok || *from != *caller {
+		t.Fatal("Invitation not opened by the callee")
+	}
+}
@@ -0,0 +1,401 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Payloads of any size go to a peer as fragments, one per round, in the
+// message of the conversation. Every fragment sent to a peer gets the
+// next sequence number, and a payload is the fragments of sequence
+// numbers seq-index to seq-index+count-1. A packet is
+//   [sent (4)][seq (4)][resend (4)][ack (4)][bitmap (4)][last (4)]
+//   [index (2)][count (2)][length (2)][data]
+// where sent is the last sequence number sent so far, seq is 0 when
+// there is no fragment, and resend is a sequence number to send again (0
+// for none). The fragments are authenticated by the encryption of the
+// conversation, and their header binds them to their place.
+//
+// Every packet acknowledges the fragments received from the peer: ack
+// is the highest sequence number received, bit i of bitmap is set if
+// ack-1-i was received too, and last is the fragment of the last packet
+// received, which may be an old one sent again. The fragments the peer
+// gave up on count as received.
+//
+// The payloads wait in the outbox of the stream, where each fragment is
+// queued, submitted in a round, delivered once the peer's message of
+// that round came back through the conversation, or acknowledged. A
+// fragment whose round failed, because it could not be submitted or no
+// message of the peer came back, is queued again, and so is a fragment
+// delivered but not acknowledged within ackRounds rounds. The queued
+// fragments go out oldest first, and a payload leaves the outbox once
+// all of its fragments are acknowledged. The outbox is saved with the
+// stream, and the fragments submitted when a user stops are queued
+// again when it restarts.
+//
+// A peer that learns from sent that it missed fragments asks for them
+// again, one per round, oldest first, and again every resendRounds
+// rounds while they are missing. The receiving side gives up on
+// fragments window sequence numbers older than the last one sent.
+
+const (
+	packetHeaderSize = 6*4 + 3*2
+	ackBits          = 32
+
+	window       = 256
+	resendRounds = 4
+	ackRounds    = 3
+)
+
+var (
+	ErrUnknownPayload = errors.New("Unknown payload")
+	errInvalidPacket  = errors.New("Invalid packet")
+)
+
+func newStream() *Stream {
+	return &Stream{
+		NextSeq: 1,
+		Base:    1,
+	}
+}
+
+// push queues payload in fragments that fit in packets of size, and
+// returns the sequence number of its first fragment.
+func (st *Stream) push(payload []byte, size int) (uint32, error) {
+	data := size - packetHeaderSize
+	count := (len(payload) + data - 1) / data
+	if count == 0 {
+		count = 1
+	}
+	if count > 0xffff {
+		return 0, ErrPayloadTooLarge
+	}
+	seq := st.NextSeq
+	st.Outbox = append(st.Outbox, &Outgoing{
+		Seq:          st.NextSeq,
+		Payload:      append([]byte(nil), payload...),
+		FragmentSize: uint32(data),
+		Status:       make([]Status, count),
+		Rounds:       make([]uint64, count),
+	})
+	st.NextSeq += uint32(count)
+	return seq, nil
+}
+
+// status returns the status of the payload whose first fragment is
+// seq: the least advanced status of its fragments.
+func (st *Stream) status(seq uint32) (Status, error) {
+	for _, out := range st.Outbox {
+		if out.Seq != seq {
+			continue
+		}
+		status := Status_ACKNOWLEDGED
+		for _, s := range out.Status {
+			if s < status {
+				status = s
+			}
+		}
+		return status, nil
+	}
+	if 0 < seq && seq < st.NextSeq {
+		return Status_ACKNOWLEDGED, nil
+	}
+	return Status_QUEUED, ErrUnknownPayload
+}
+
+// fragment returns the data of the fragment index of out.
+func (out *Outgoing) fragment(index int) []byte {
+	start := index * int(out.FragmentSize)
+	end := start + int(out.FragmentSize)
+	if end > len(out.Payload) {
+		end = len(out.Payload)
+	}
+	return out.Payload[start:end]
+}
+
+// outgoing returns the payload of the fragment seq and its index, if it
+// is still in the outbox.
+func (st *Stream) outgoing(seq uint32) (*Outgoing, int, bool) {
+	for _, out := range st.Outbox {
+		if out.Seq <= seq && seq < out.Seq+uint32(len(out.Status)) {
+			return out, int(seq - out.Seq), true
+		}
+	}
+	return nil, 0, false
+}
+
+// next returns the packet of size to send in round, with a fragment
+// asked for again or else the oldest queued one, and the sequence
+// number of the fragment (0 for none).
+func (st *Stream) next(round uint64, size int) ([]byte, uint32) {
+	st.expire(round)
+
+	var out *Outgoing
+	var index int
+	ok := false
+	for len(st.Resend) > 0 && !ok {
+		out, index, ok = st.outgoing(st.Resend[0])
+		ok = ok && out.Status[index] != Status_ACKNOWLEDGED
+		st.Resend = st.Resend[1:]
+	}
+	for i := 0; i < len(st.Outbox) && !ok; i++ {
+		for j, status := range st.Outbox[i].Status {
+			if status == Status_QUEUED {
+				out, index, ok = st.Outbox[i], j, true
+				break
+			}
+		}
+	}
+
+	packet := make([]byte, size)
+	var seq uint32
+	if ok {
+		seq = out.Seq + uint32(index)
+		out.Status[index] = Status_SUBMITTED
+		out.Rounds[index] = round
+		if seq > st.LastSent {
+			st.LastSent = seq
+		}
+		data := out.fragment(index)
+		binary.BigEndian.PutUint32(packet[4:], seq)
+		binary.BigEndian.PutUint16(packet[24:], uint16(index))
+		binary.BigEndian.PutUint16(packet[26:], uint16(len(out.Status)))
+		binary.BigEndian.PutUint16(packet[28:], uint16(len(data)))
+		copy(packet[packetHeaderSize:], data)
+	}
+	binary.BigEndian.PutUint32(packet, st.LastSent)
+	binary.BigEndian.PutUint32(packet[8:], st.missing(round))
+	binary.BigEndian.PutUint32(packet[12:], st.Highest)
+	binary.BigEndian.PutUint32(packet[16:], st.bitmap())
+	binary.BigEndian.PutUint32(packet[20:], st.Last)
+	return packet, seq
+}
+
+// expire queues again the fragments delivered but not acknowledged in
+// time.
+func (st *Stream) expire(round uint64) {
+	for _, out := range st.Outbox {
+		for i, status := range out.Status {
+			if status == Status_DELIVERED && out.Rounds[i]+ackRounds <= round {
+				out.Status[i] = Status_QUEUED
+			}
+		}
+	}
+}
+
+// acknowledge marks the fragment seq acknowledged.
+func (st *Stream) acknowledge(seq uint32) {
+	if out, index, ok := st.outgoing(seq); ok {
+		out.Status[index] = Status_ACKNOWLEDGED
+	}
+}
+
+// sent records whether the fragment seq submitted in round made it: it
+// is delivered, or queued again.
+func (st *Stream) sent(seq uint32, round uint64, ok bool) {
+	out, index, found := st.outgoing(seq)
+	if !found || out.Status[index] != Status_SUBMITTED || out.Rounds[index] != round {
+		return
+	}
+	if ok {
+		out.Status[index] = Status_DELIVERED
+	} else {
+		out.Status[index] = Status_QUEUED
+	}
+}
+
+// restart queues the submitted fragments again, as their rounds will
+// not be downloaded.
+func (st *Stream) restart() {
+	for _, out := range st.Outbox {
+		for i, status := range out.Status {
+			if status == Status_SUBMITTED {
+				out.Status[i] = Status_QUEUED
+			}
+		}
+	}
+}
+
+// prune drops the payloads acknowledged.
+func (st *Stream) prune() {
+	outbox := st.Outbox[:0]
+	for _, out := range st.Outbox {
+		for _, status := range out.Status {
+			if status != Status_ACKNOWLEDGED {
+				outbox = append(outbox, out)
+				break
+			}
+		}
+	}
+	st.Outbox = outbox
+}
+
+func (st *Stream) received(seq uint32) (*Fragment, bool) {
+	for _, frag := range st.Fragments {
+		if frag.Seq == seq {
+			return frag, true
+		}
+	}
+	return nil, false
+}
+
+func (st *Stream) reassembled(seq uint32) bool {
+	for _, s := range st.Reassembled {
+		if s == seq {
+			return true
+		}
+	}
+	return false
+}
+
+// bitmap returns the fragments received of the ackBits before Highest.
+func (st *Stream) bitmap() uint32 {
+	var bits uint32
+	for i := uint32(0); i < ackBits && i+1 < st.Highest; i++ {
+		seq := st.Highest - 1 - i
+		if _, ok := st.received(seq); ok || seq < st.Base || st.reassembled(seq) {
+			bits |= 1 << i
+		}
+	}
+	return bits
+}
+
+// missing returns the oldest fragment to ask for in round, or 0.
+func (st *Stream) missing(round uint64) uint32 {
+	requested := make(map[uint32]*Request)
+	for _, req := range st.Requests {
+		requested[req.Seq] = req
+	}
+	for seq := st.Base; seq <= st.PeerSent; seq++ {
+		if _, ok := st.received(seq); ok || st.reassembled(seq) {
+			continue
+		}
+		req, ok := requested[seq]
+		if ok && round < req.Round+resendRounds {
+			continue
+		}
+		if ok {
+			req.Round = round
+		} else {
+			st.Requests = append(st.Requests, &Request{Seq: seq, Round: round})
+		}
+		return seq
+	}
+	return 0
+}
+
+// receive handles a packet from the peer, and returns the payloads it
+// completes.
+func (st *Stream) receive(packet []byte) ([][]byte, error) {
+	if len(packet) < packetHeaderSize {
+		return nil, errInvalidPacket
+	}
+	sent := binary.BigEndian.Uint32(packet)
+	resend := binary.BigEndian.Uint32(packet[8:])
+	ack := binary.BigEndian.Uint32(packet[12:])
+	bits := binary.BigEndian.Uint32(packet[16:])
+	last := binary.BigEndian.Uint32(packet[20:])
+	frag := &Fragment{
+		Seq:   binary.BigEndian.Uint32(packet[4:]),
+		Index: uint32(binary.BigEndian.Uint16(packet[24:])),
+		Count: uint32(binary.BigEndian.Uint16(packet[26:])),
+	}
+	n := int(binary.BigEndian.Uint16(packet[28:]))
+	if packetHeaderSize+n > len(packet) || frag.Seq > sent || frag.Index >= frag.Count && frag.Seq != 0 {
+		return nil, errInvalidPacket
+	}
+	frag.Data = append([]byte(nil), packet[packetHeaderSize:packetHeaderSize+n]...)
+
+	st.acknowledge(ack)
+	st.acknowledge(last)
+	for i := uint32(0); i < ackBits && i+1 < ack; i++ {
+		if bits&(1<<i) != 0 {
+			st.acknowledge(ack - 1 - i)
+		}
+	}
+	st.prune()
+	if out, index, ok := st.outgoing(resend); ok && out.Status[index] != Status_ACKNOWLEDGED {
+		st.queueResend(resend)
+	}
+	if sent > st.PeerSent {
+		st.PeerSent = sent
+	}
+	st.forget()
+
+	if frag.Seq == 0 {
+		return nil, nil
+	}
+	st.Last = frag.Seq
+	if frag.Seq > st.Highest {
+		st.Highest = frag.Seq
+	}
+	if frag.Seq < st.Base || st.reassembled(frag.Seq) {
+		return nil, nil
+	}
+	if _, ok := st.received(frag.Seq); !ok {
+		st.Fragments = append(st.Fragments, frag)
+	}
+	return st.complete(frag), nil
+}
+
+func (st *Stream) queueResend(seq uint32) {
+	for _, s := range st.Resend {
+		if s == seq {
+			return
+		}
+	}
+	st.Resend = append(st.Resend, seq)
+}
+
+// complete returns the payload of frag, if all of its fragments are
+// there.
+func (st *Stream) complete(frag *Fragment) [][]byte {
+	start := frag.Seq - frag.Index
+	var payload []byte
+	for i := uint32(0); i < frag.Count; i++ {
+		f, ok := st.received(start + i)
+		if !ok || f.Count != frag.Count || f.Index != i {
+			return nil
+		}
+		payload = append(payload, f.Data...)
+	}
+	for i := uint32(0); i < frag.Count; i++ {
+		st.Reassembled = append(st.Reassembled, start+i)
+	}
+	st.forget()
+	return [][]byte{payload}
+}
+
+// forget moves Base past the fragments reassembled and the ones too old
+// to be sent again, and drops the state of the fragments done with.
+// PeerSent comes from the peer, so Base jumps to the window in one step
+// however far ahead it is.
+func (st *Stream) forget() {
+	if st.PeerSent >= window && st.Base < st.PeerSent-window+1 {
+		st.Base = st.PeerSent - window + 1
+	}
+	for st.Base <= st.PeerSent && st.reassembled(st.Base) {
+		st.Base++
+	}
+	frags := st.Fragments[:0]
+	for _, frag := range st.Fragments {
+		if frag.Seq >= st.Base && !st.reassembled(frag.Seq) {
+			frags = append(frags, frag)
+		}
+	}
+	st.Fragments = frags
+	reassembled := st.Reassembled[:0]
+	for _, seq := range st.Reassembled {
+		if seq >= st.Base {
+			reassembled = append(reassembled, seq)
+		}
+	}
+	st.Reassembled = reassembled
+	requests := st.Requests[:0]
+	for _, req := range st.Requests {
+		if _, ok := st.received(req.Seq); !ok && req.Seq >= st.Base && !st.reassembled(req.Seq) {
+			requests = append(requests, req)
+		}
+	}
+	st.Requests = requests
+}
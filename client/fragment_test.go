@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+type testFragment struct {
+	sent, seq    uint32
+	index, count uint16
+	data         string
+}
+
+func (f testFragment) packet() []byte {
+	packet := make([]byte, packetHeaderSize+len(f.data))
+	binary.BigEndian.PutUint32(packet, f.sent)
+	binary.BigEndian.PutUint32(packet[4:], f.seq)
+	binary.BigEndian.PutUint16(packet[24:], f.index)
+	binary.BigEndian.PutUint16(packet[26:], f.count)
+	binary.BigEndian.PutUint16(packet[28:], uint16(len(f.data)))
+	copy(packet[packetHeaderSize:], f.data)
+	return packet
+}
+
+func TestStreamReceive(t *testing.T) {
+	far := ^uint32(0)
+	tests := []struct {
+		name      string
+		fragments []testFragment
+		payloads  []string
+		base      uint32
+	}{
+		{"in order", []testFragment{
+			{3, 1, 0, 3, "a"}, {3, 2, 1, 3, "b"}, {3, 3, 2, 3, "c"},
+		}, []string{"abc"}, 4},
+		{"reordered", []testFragment{
+			{3, 3, 2, 3, "c"}, {3, 1, 0, 3, "a"}, {3, 2, 1, 3, "b"},
+		}, []string{"abc"}, 4},
+		{"resent", []testFragment{
+			{2, 1, 0, 2, "a"}, {2, 1, 0, 2, "a"}, {2, 2, 1, 2, "b"}, {2, 2, 1, 2, "b"},
+		}, []string{"ab"}, 3},
+		{"resent after reassembly", []testFragment{
+			{1, 1, 0, 1, "a"}, {1, 1, 0, 1, "a"},
+		}, []string{"a"}, 2},
+		{"interleaved", []testFragment{
+			{3, 3, 0, 1, "c"}, {3, 2, 1, 2, "b"}, {3, 1, 0, 2, "a"},
+		}, []string{"c", "ab"}, 4},
+		{"missing", []testFragment{
+			{3, 1, 0, 2, "a"}, {3, 3, 0, 1, "c"},
+		}, []string{"c"}, 1},
+		{"inconsistent counts", []testFragment{
+			{3, 1, 0, 2, "a"}, {3, 2, 1, 3, "b"},
+		}, nil, 1},
+		{"no fragment", []testFragment{
+			{0, 0, 0, 0, ""},
+		}, nil, 1},
+		{"given up", []testFragment{
+			{2, 1, 0, 2, "a"}, {2 + window, 0, 0, 0, ""}, {2 + window, 2, 1, 2, "b"},
+		}, nil, 3},
+		{"far ahead", []testFragment{
+			{1, 1, 0, 1, "a"}, {far, 0, 0, 0, ""},
+		}, []string{"a"}, far - window + 1},
+	}
+	for _, test := range tests {
+		st := newStream()
+		var payloads []string
+		for _, f := range test.fragments {
+			completed, err := st.receive(f.packet())
+			if err != nil {
+				t.Fatal(test.name, err)
+			}
+			for _, payload := range completed {
+				payloads = append(payloads, string(payload))
+			}
+		}
+		if len(payloads) != len(test.payloads) {
+			t.Fatal("Wrong payloads", test.name, payloads)
+		}
+		for i := range payloads {
+			if payloads[i] != test.payloads[i] {
+				t.Fatal("Wrong payloads", test.name, payloads)
+			}
+		}
+		if st.Base != test.base {
+			t.Fatal("Wrong base", test.name, st.Base)
+		}
+		for _, frag := range st.Fragments {
+			if frag.Seq < st.Base {
+				t.Fatal("Fragment kept before base", test.name, frag.Seq)
+			}
+		}
+		if len(st.Reassembled) > window || len(st.Requests) > window {
+			t.Fatal("State kept outside the window", test.name)
+		}
+	}
+}
+
+func TestStreamMalformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet []byte
+	}{
+		{"short", make([]byte, packetHeaderSize-1)},
+		{"seq after sent", testFragment{1, 2, 0, 1, "a"}.packet()},
+		{"index after count", testFragment{1, 1, 1, 1, "a"}.packet()},
+		{"no count", testFragment{1, 1, 0, 0, "a"}.packet()},
+		{"length after packet", func() []byte {
+			packet := testFragment{1, 1, 0, 1, "a"}.packet()
+			binary.BigEndian.PutUint16(packet[28:], 2)
+			return packet
+		}()},
+	}
+	for _, test := range tests {
+		st := newStream()
+		if _, err := st.receive(test.packet); err != errInvalidPacket {
+			t.Fatal("Malformed packet accepted", test.name, err)
+		}
+		if st.PeerSent != 0 || st.Base != 1 || len(st.Fragments) != 0 {
+			t.Fatal("Malformed packet changed the stream", test.name)
+		}
+	}
+}
+
+func TestStreamExchange(t *testing.T) {
+	size := packetHeaderSize + 4
+	sender, receiver := newStream(), newStream()
+	payloads := [][]byte{[]byte("first payload"), []byte("second")}
+	for _, payload := range payloads {
+		if _, err := sender.push(payload, size); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// every other packet is lost, and asked for again
+	var got [][]byte
+	for round := uint64(0); round < 32 && len(got) < len(payloads); round++ {
+		packet, seq := sender.next(round, size)
+		lost := round%2 == 1
+		sender.sent(seq, round, !lost)
+		if lost {
+			continue
+		}
+		completed, err := receiver.receive(packet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, completed...)
+
+		reply, _ := receiver.next(round, size)
+		if _, err := sender.receive(reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(got) != len(payloads) {
+		t.Fatal("Payloads not received", len(got))
+	}
+	for i := range got {
+		if !bytes.Equal(got[i], payloads[i]) {
+			t.Fatal("Wrong payload", string(got[i]))
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"crypto/rand"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// A keystore keeps the long-term keys of users, their contacts, and the
+// state of their conversations and streams, so a user keeps its inbox,
+// chains and outbox across rounds and restarts. The file is
+//   [salt (16)][nonce (24)][secretbox of the Identities message]
+// under a key derived from the passphrase with scrypt. It is rewritten
+// as a whole (to a temporary file, then renamed) on every change.
+
+var (
+	ErrWrongPassphrase  = errors.New("Wrong passphrase or corrupted keystore")
+	ErrUnknownIdentity  = errors.New("Unknown identity")
+	ErrUnknownContact   = errors.New("Unknown contact")
+	ErrDuplicateContact = errors.New("Contact already exists")
+)
+
+const (
+	saltSize = 16
+
+	// scrypt parameters for interactive logins
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+type Keystore interface {
+	// Identities returns the key pairs of the first n identities,
+	// creating the missing ones.
+	Identities(n int) ([]*[32]byte, []*[32]byte, error)
+	// AddContact adds a contact to the identity with public key owner.
+	AddContact(owner *[32]byte, name string, key *[32]byte) error
+	// RemoveContact removes a contact of owner, with the state of
+	// their conversation and stream.
+	RemoveContact(owner, key *[32]byte) error
+	// Contacts returns the contacts of owner.
+	Contacts(owner *[32]byte) ([]*Contact, error)
+	// SetState replaces the conversation state of owner with peer.
+	SetState(owner, peer *[32]byte, state []byte) error
+	// State returns the conversation state of owner with peer.
+	State(owner, peer *[32]byte) ([]byte, error)
+	// SetStream replaces the stream state of owner with peer.
+	SetStream(owner, peer *[32]byte, stream []byte) error
+	// Stream returns the stream state of owner with peer.
+	Stream(owner, peer *[32]byte) ([]byte, error)
+}
+
+type keystore struct {
+	file string
+	salt [saltSize]byte
+	key  [32]byte
+
+	mu    sync.Mutex
+	store *Identities
+}
+
+// OpenKeystore opens the keystore in file with passphrase, or creates
+// an empty one if file does not exist.
+func OpenKeystore(file string, passphrase []byte) (Keystore, error) {
+	ks := &keystore{
+		file:  file,
+		store: &Identities{},
+	}
+
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		if _, err := rand.Read(ks.salt[:]); err != nil {
+			return nil, err
+		}
+		if err := ks.deriveKey(passphrase); err != nil {
+			return nil, err
+		}
+		return ks, ks.save()
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(data) < saltSize+24 {
+		return nil, ErrWrongPassphrase
+	}
+	copy(ks.salt[:], data[:saltSize])
+	if err := ks.deriveKey(passphrase); err != nil {
+		return nil, err
+	}
+	var nonce [24]byte
+	copy(nonce[:], data[saltSize:])
+	plain, ok := secretbox.Open(nil, data[saltSize+24:], &nonce, &ks.key)
+	if !ok {
+		return nil, ErrWrongPassphrase
+	}
+	defer wipe(plain)
+	if err := proto.Unmarshal(plain, ks.store); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func (ks *keystore) deriveKey(passphrase []byte) error {
+	key, err := scrypt.Key(passphrase, ks.salt[:], scryptN, scryptR, scryptP, len(ks.key))
+	if err != nil {
+		return err
+	}
+	copy(ks.key[:], key)
+	wipe(key)
+	return nil
+}
+
+// save encrypts and writes the keystore. ks.mu must be held, unless ks
+// is not shared yet.
+func (ks *keystore) save() error {
+	plain, err := proto.Marshal(ks.store)
+	if err != nil {
+		return err
+	}
+	defer wipe(plain)
+
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return err
+	}
+	data := make([]byte, saltSize+24, saltSize+24+len(plain)+secretbox.Overhead)
+	copy(data, ks.salt[:])
+	copy(data[saltSize:], nonce[:])
+	data = secretbox.Seal(data, plain, &nonce, &ks.key)
+
+	tmp := ks.file + ".tmp"
+	if err := writeFileSync(tmp, data); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ks.file)
+}
+
+func writeFileSync(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func (ks *keystore) Identities(n int) ([]*[32]byte, []*[32]byte, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if old := len(ks.store.Identities); old < n {
+		for len(ks.store.Identities) < n {
+			pub, priv, err := box.GenerateKey(rand.Reader)
+			if err != nil {
+				return nil, nil, err
+			}
+			ks.store.Identities = append(ks.store.Identities, &Identity{
+				PublicKey:  pub[:],
+				PrivateKey: priv[:],
+			})
+		}
+		if err := ks.save(); err != nil {
+			ks.store.Identities = ks.store.Identities[:old]
+			return nil, nil, err
+		}
+	}
+
+	pubs, privs := make([]*[32]byte, n), make([]*[32]byte, n)
+	for i, id := range ks.store.Identities[:n] {
+		pubs[i], privs[i] = new([32]byte), new([32]byte)
+		copy(pubs[i][:], id.PublicKey)
+		copy(privs[i][:], id.PrivateKey)
+	}
+	return pubs, privs, nil
+}
+
+func (ks *keystore) identity(owner *[32]byte) (*Identity, error) {
+	for _, id := range ks.store.Identities {
+		if string(id.PublicKey) == string(owner[:]) {
+			return id, nil
+		}
+	}
+	return nil, ErrUnknownIdentity
+}
+
+func contact(id *Identity, key *[32]byte) *Contact {
+	for _, c := range id.Contacts {
+		if string(c.PublicKey) == string(key[:]) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (ks *keystore) AddContact(owner *[32]byte, name string, key *[32]byte) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	id, err := ks.identity(owner)
+	if err != nil {
+		return err
+	}
+	if contact(id, key) != nil {
+		return ErrDuplicateContact
+	}
+	id.Contacts = append(id.Contacts, &Contact{
+		Name:      name,
+		PublicKey: append([]byte(nil), key[:]...),
+	})
+	if err := ks.save(); err != nil {
+		id.Contacts = id.Contacts[:len(id.Contacts)-1]
+		return err
+	}
+	return nil
+}
+
+func (ks *keystore) RemoveContact(owner, key *[32]byte) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	id, err := ks.identity(owner)
+	if err != nil {
+		return err
+	}
+	for i, c := range id.Contacts {
+		if string(c.PublicKey) != string(key[:]) {
+			continue
+		}
+		contacts := id.Contacts
+		id.Contacts = append(append([]*Contact(nil), contacts[:i]...), contacts[i+1:]...)
+		if err := ks.save(); err != nil {
+			id.Contacts = contacts
+			return err
+		}
+		return nil
+	}
+	return ErrUnknownContact
+}
+
+func (ks *keystore) Contacts(owner *[32]byte) ([]*Contact, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	id, err := ks.identity(owner)
+	if err != nil {
+		return nil, err
+	}
+	contacts := make([]*Contact, len(id.Contacts))
+	for i, c := range id.Contacts {
+		contacts[i] = proto.Clone(c).(*Contact)
+	}
+	return contacts, nil
+}
+
+// setContact sets a field of the contact peer of owner to value, and
+// saves the keystore.
+func (ks *keystore) setContact(owner, peer *[32]byte, field func(*Contact) *[]byte, value []byte) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	id, err := ks.identity(owner)
+	if err != nil {
+		return err
+	}
+	c := contact(id, peer)
+	if c == nil {
+		return ErrUnknownContact
+	}
+	old := *field(c)
+	*field(c) = append([]byte(nil), value...)
+	if err := ks.save(); err != nil {
+		*field(c) = old
+		return err
+	}
+	return nil
+}
+
+// getContact returns a field of the contact peer of owner.
+func (ks *keystore) getContact(owner, peer *[32]byte, field func(*Contact) *[]byte) ([]byte, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	id, err := ks.identity(owner)
+	if err != nil {
+		return nil, err
+	}
+	c := contact(id, peer)
+	if c == nil {
+		return nil, ErrUnknownContact
+	}
+	return append([]byte(nil), *field(c)...), nil
+}
+
+func contactState(c *Contact) *[]byte  { return &c.State }
+func contactStream(c *Contact) *[]byte { return &c.Stream }
+
+func (ks *keystore) SetState(owner, peer *[32]byte, state []byte) error {
+	return ks.setContact(owner, peer, contactState, state)
+}
+
+func (ks *keystore) State(owner, peer *[32]byte) ([]byte, error) {
+	return ks.getContact(owner, peer, contactState)
+}
+
+func (ks *keystore) SetStream(owner, peer *[32]byte, stream []byte) error {
+	return ks.setContact(owner, peer, contactStream, stream)
+}
+
+func (ks *keystore) Stream(owner, peer *[32]byte) ([]byte, error) {
+	return ks.getContact(owner, peer, contactStream)
+}
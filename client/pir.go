@@ -0,0 +1,157 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"sort"
+
+	"golang.org/x/net/context"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mailbox"
+	"github.com/kwonalbert/xrd/span"
+)
+
+// registeredUsers returns the keys registered at a mailbox, in the
+// order of the PIR database.
+func registeredUsers(rpc mailbox.MailboxClient, round uint64) ([][]byte, error) {
+	stream, err := rpc.RegisteredUsers(context.Background(), &mailbox.RegisteredUsersRequest{
+		Round: round,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var keys [][]byte
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		keys = append(keys, resp.UserKeys...)
+	}
+	return keys, nil
+}
+
+// pirQuery sends all the queries to one mailbox, and returns the
+// answers in the same order.
+func pirQuery(rpc mailbox.MailboxClient, round uint64, queries [][]byte) ([][]byte, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	stream, err := rpc.PIRGetMails(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(chan error, 1)
+	go func() { // stream the requests, in case it's too big
+		spans := span.StreamSpan(len(queries), config.StreamSize, len(queries[0]))
+		for _, sspan := range spans {
+			req := &mailbox.PIRGetMailsRequest{
+				Round:   round,
+				Queries: queries[sspan.Start:sspan.End],
+			}
+			err := stream.Send(req)
+			if err != nil {
+				errs <- err
+				return
+			}
+		}
+		errs <- stream.CloseSend()
+	}()
+
+	answers := make([][]byte, 0, len(queries))
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		answers = append(answers, resp.Answers...)
+	}
+
+	err = <-errs
+	if err != nil {
+		return nil, err
+	}
+	if len(answers) != len(queries) {
+		return nil, errors.New("Missing PIR answers")
+	}
+	return answers, nil
+}
+
+// pirDownload fetches the inboxes of all the users with one query to
+// every mailbox per user.
+func (clt *client) pirDownload(round uint64, rpcs map[string]mailbox.MailboxClient) (*DownloadMessagesResponse, error) {
+	mids := make([]string, 0, len(rpcs))
+	for mid := range rpcs {
+		mids = append(mids, mid)
+	}
+	sort.Strings(mids)
+
+	// every mailbox has the same users, so ask any one of them
+	users, err := registeredUsers(rpcs[mids[0]], round)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[[32]byte]int)
+	var tmpKey [32]byte
+	for i, key := range users {
+		copy(tmpKey[:], key)
+		index[tmpKey] = i
+	}
+
+	queries := make([][][]byte, len(mids))
+	for _, key := range clt.publicKeys {
+		i, ok := index[*key]
+		if !ok {
+			return nil, errors.New("User not registered at the mailboxes")
+		}
+		qs := mailbox.PIRQueries(len(users), i, len(mids))
+		for m := range mids {
+			queries[m] = append(queries[m], qs[m])
+		}
+	}
+
+	answers := make([][][]byte, len(mids))
+	errs := make(chan error, len(mids))
+	for m, mid := range mids {
+		go func(m int, mid string) {
+			var err error
+			answers[m], err = pirQuery(rpcs[mid], round, queries[m])
+			errs <- err
+		}(m, mid)
+	}
+	for range mids {
+		err := <-errs
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &DownloadMessagesResponse{}
+	check := newDeliveryCheck()
+	msg := make([]byte, clt.msgSize)
+	ans := make([][]byte, len(mids))
+	for u, key := range clt.publicKeys {
+		for m := range mids {
+			ans[m] = answers[m][u]
+		}
+		inbox, err := mailbox.PIRDecode(*key, ans)
+		if err != nil {
+			return nil, err
+		}
+		resp.Expected += inbox.Expected
+		resp.Received += inbox.Received
+		if inbox.Received < inbox.Expected {
+			resp.Incomplete++
+		}
+		check.check(round, key, clt.privateKeys[u], clt.assignments[*key], msg, inbox)
+	}
+	resp.Chains = check.report()
+	return resp, nil
+}
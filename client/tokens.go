@@ -0,0 +1,108 @@
+package client
+
+import (
+	"crypto/rsa"
+	"errors"
+
+	"golang.org/x/net/context"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mailbox"
+	"github.com/kwonalbert/xrd/mixnet"
+	"github.com/kwonalbert/xrd/span"
+)
+
+// Submission tokens (see mixnet/token.go). In the rounds that need
+// them, the users get a token per chain from the primary mailbox of
+// their inbox when they register, and submit each with their
+// ciphertext to the first server of the chain.
+
+// requestTokens gets the tokens of round for the chains of each user
+// from rpc, the primary mailbox of all the users, in the order of the
+// chains.
+func requestTokens(rpc mailbox.MailboxClient, round uint64, key *rsa.PublicKey, pubs, privs []*[32]byte, chains [][]*config.Group) ([][][]byte, error) {
+	cresp, err := rpc.GetChallenge(context.Background(), &mailbox.GetChallengeRequest{
+		Round: round,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	most := 1
+	for _, groups := range chains {
+		if len(groups) > most {
+			most = len(groups)
+		}
+	}
+	size := 32 + mailbox.PROOF_SIZE + most*(key.Size()+16)
+
+	tokens := make([][][]byte, len(pubs))
+	for _, sspan := range span.StreamSpan(len(pubs), config.StreamSize, size) {
+		reqs := make([]*mailbox.TokenRequest, 0, sspan.End-sspan.Start)
+		blindings := make([][]*mixnet.TokenBlinding, 0, sspan.End-sspan.Start)
+		for u := sspan.Start; u < sspan.End; u++ {
+			req := &mailbox.TokenRequest{
+				UserKey: pubs[u][:],
+				Proof:   mailbox.ProveOwnership(pubs[u], privs[u], round, cresp.Challenge),
+			}
+			ublindings := make([]*mixnet.TokenBlinding, len(chains[u]))
+			for g, group := range chains[u] {
+				var blinded []byte
+				ublindings[g], blinded = mixnet.BlindToken(key, round, group.Gid)
+				req.Gids = append(req.Gids, group.Gid)
+				req.Blinded = append(req.Blinded, blinded)
+			}
+			reqs = append(reqs, req)
+			blindings = append(blindings, ublindings)
+		}
+
+		resp, err := rpc.IssueTokens(context.Background(), &mailbox.IssueTokensRequest{
+			Round: round,
+			Users: reqs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Users) != len(reqs) {
+			return nil, errors.New("Missing token signatures")
+		}
+		for k, signatures := range resp.Users {
+			if len(signatures.Signatures) != len(blindings[k]) {
+				return nil, errors.New("Missing token signatures")
+			}
+			utokens := make([][]byte, len(signatures.Signatures))
+			for g, signature := range signatures.Signatures {
+				utokens[g], err = blindings[k][g].Unblind(key, signature)
+				if err != nil {
+					return nil, err
+				}
+			}
+			tokens[sspan.Start+k] = utokens
+		}
+	}
+	return tokens, nil
+}
+
+// requestTokens gets the tokens of the user for its chains in round,
+// by gid.
+func (usr *user) requestTokens(rpcs map[string]mailbox.MailboxClient, round uint64, tokenKey []byte, chains []*config.Group) error {
+	key, err := mixnet.UnmarshalTokenPublicKey(tokenKey)
+	if err != nil {
+		return err
+	}
+	primary := usr.ring.Replicas(*usr.publicKey)[0]
+	tokens, err := requestTokens(rpcs[primary], round, key,
+		[]*[32]byte{usr.publicKey}, []*[32]byte{usr.privateKey}, [][]*config.Group{chains})
+	if err != nil {
+		return err
+	}
+
+	gtokens := make(map[string][]byte)
+	for g, group := range chains {
+		gtokens[group.Gid] = tokens[0][g]
+	}
+	usr.mu.Lock()
+	usr.tokens[round] = gtokens
+	usr.mu.Unlock()
+	return nil
+}
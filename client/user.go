@@ -0,0 +1,763 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mailbox"
+	"github.com/kwonalbert/xrd/mixnet"
+)
+
+// A user is the client of one real person, unlike the simulated users
+// of client. It has a long-term key pair, whose public key is also its
+// inbox, and takes part in the rounds driven by the coordinator in the
+// same way as the simulated clients.
+//
+// Every round, the user sends one message through each of its chains,
+// which change every round (see config.Assigner). The user talks with
+// every peer in every round, each through its own chain: the first free
+// chain it shares with the peer, or else its first free chain. The
+// message to a peer carries a packet of its stream (see fragment.go),
+// encrypted with the ratchet of the conversation. The chains left carry
+// loopback messages to the user itself, so every user sends and
+// receives one message per chain, whatever it talks about and with how
+// many peers. In the rounds that check it, each message goes with a
+// token of the chain, which the user gets when it registers (see
+// tokens.go).
+//
+// Peers are added directly, or by dialing (see dial.go), up to one per
+// chain of the rounds with the fewest chains. Both peers have to add
+// each other, or one gets a message too many and the other one waits
+// for a message that never comes. The chains a peer picks depend on its
+// other peers, so the inbox of a user is registered without its chains,
+// and takes a message more per peer than it waits for.
+
+var (
+	ErrPayloadTooLarge = errors.New("Payload too large for a stream")
+	ErrTooManyPeers    = errors.New("As many peers as chains already")
+)
+
+// Message is a payload received from a peer.
+type Message struct {
+	Round   uint64
+	From    [32]byte
+	Payload []byte
+	// an invitation from From, which is now a peer, with no payload
+	Dial bool
+}
+
+type User interface {
+	ClientServer
+
+	PublicKey() *[32]byte
+	// AddPeer starts a conversation with peer.
+	AddPeer(peer *[32]byte) error
+	// RemovePeer ends the conversation with peer, drops the payloads
+	// not sent yet, and removes peer from the contacts.
+	RemovePeer(peer *[32]byte)
+	// Dial invites peer to a conversation in the next dial round, and
+	// adds peer once the invitation is sent.
+	Dial(peer *[32]byte) error
+	// Send queues payload for peer in the outbox, to go out in
+	// fragments over the next rounds, one fragment per round, until
+	// the peer acknowledges them. It adds peer if needed, and returns
+	// the id of the payload for Status.
+	Send(peer *[32]byte, payload []byte) (uint32, error)
+	// Status returns how far the payload id sent to peer got.
+	Status(peer *[32]byte, id uint32) (Status, error)
+	// Receive returns the payloads received from the peers, once all
+	// of their fragments are. It should be read from continuously, as
+	// downloads wait for it otherwise.
+	Receive() <-chan *Message
+}
+
+const receiveBuffer = 1024
+
+type user struct {
+	publicKey  *[32]byte
+	privateKey *[32]byte
+	msgSize    int
+
+	mailboxes map[string]*config.Server
+	servers   map[string]*config.Server
+	groups    map[string]*config.Group
+	ring      *mailbox.Ring
+	assigner  *config.Assigner
+
+	// where the conversations are saved, if anywhere
+	keys Keystore
+
+	mu      sync.Mutex
+	peers   []*[32]byte
+	streams map[[32]byte]*Stream
+	convs   map[[32]byte]*Conversation
+	// users to dial, and the dials of the dial rounds not downloaded yet
+	dials      []*[32]byte
+	dialRounds map[uint64]map[string]*[32]byte
+	// conversations of the rounds not downloaded yet
+	talks map[uint64][]*talk
+	// per chain ciphertexts of the next submission
+	ciphertexts map[string][]byte
+	prfs        map[string][]byte
+	// per chain tokens of the rounds not submitted yet, if needed
+	tokens map[uint64]map[string][]byte
+
+	received chan *Message
+}
+
+type talk struct {
+	peer  *[32]byte
+	chain *config.Group
+	seq   uint32 // of the fragment sent
+	heard bool   // whether the message of the peer came back
+}
+
+// msgSize: size of the messages of every round, set by the coordinator
+func NewUser(publicKey, privateKey *[32]byte, mailboxes, servers map[string]*config.Server, groups map[string]*config.Group, replicas, msgSize int) User {
+	if msgSize <= convOverhead+packetHeaderSize || msgSize < 32+invitationSize || msgSize-convOverhead-packetHeaderSize > 0xffff {
+		panic("Invalid message size")
+	}
+	return &user{
+		publicKey:  publicKey,
+		privateKey: privateKey,
+		msgSize:    msgSize,
+
+		mailboxes: mailboxes,
+		servers:   servers,
+		groups:    groups,
+		ring:      mailbox.NewRing(mailboxes, replicas),
+		assigner:  config.NewAssigner(groups),
+
+		streams: make(map[[32]byte]*Stream),
+		convs:   make(map[[32]byte]*Conversation),
+		talks:   make(map[uint64][]*talk),
+		tokens:  make(map[uint64]map[string][]byte),
+
+		dialRounds: make(map[uint64]map[string]*[32]byte),
+
+		received: make(chan *Message, receiveBuffer),
+	}
+}
+
+// LoadUser returns the user of the first identity in keys, talking
+// with its contacts. The contacts and conversations of the user are
+// saved in keys.
+func LoadUser(keys Keystore, mailboxes, servers map[string]*config.Server, groups map[string]*config.Group, replicas, msgSize int) (User, error) {
+	pubs, privs, err := keys.Identities(1)
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := keys.Contacts(pubs[0])
+	if err != nil {
+		return nil, err
+	}
+	usr := NewUser(pubs[0], privs[0], mailboxes, servers, groups, replicas, msgSize).(*user)
+	usr.keys = keys
+	for _, contact := range contacts {
+		peer := new([32]byte)
+		copy(peer[:], contact.PublicKey)
+		if err := usr.addPeer(peer); err != nil {
+			return nil, err
+		}
+	}
+	return usr, nil
+}
+
+// chains returns the chains of key in round. Anyone can compute the
+// chains of any user, and the chains of any two users intersect.
+func (usr *user) chains(key *[32]byte, round uint64) []*config.Group {
+	return usr.assigner.Assign(key[:], round)
+}
+
+// sharedChains returns the chains of a that are also chains of b,
+// sorted by gid.
+func sharedChains(a, b []*config.Group) []*config.Group {
+	var shared []*config.Group
+	for _, ga := range a {
+		for _, gb := range b {
+			if ga == gb {
+				shared = append(shared, ga)
+			}
+		}
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i].Gid < shared[j].Gid })
+	return shared
+}
+
+// sharedChain returns the first chain two users share, which both of
+// them compute the same, if they share any.
+func sharedChain(a, b []*config.Group) (*config.Group, bool) {
+	shared := sharedChains(a, b)
+	if len(shared) == 0 {
+		return nil, false
+	}
+	return shared[0], true
+}
+
+// mailNonce is the nonce of the mails of a round through a chain. A
+// user sends at most one mail to a given key per chain and round, and
+// the keys of a conversation change every round.
+func mailNonce(round uint64, gid string) *[24]byte {
+	var nonce [24]byte
+	binary.BigEndian.PutUint64(nonce[:8], round)
+	h := sha256.Sum256([]byte(gid))
+	copy(nonce[8:], h[:16])
+	return &nonce
+}
+
+func (usr *user) PublicKey() *[32]byte {
+	return usr.publicKey
+}
+
+func (usr *user) AddPeer(peer *[32]byte) error {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+	return usr.addPeer(peer)
+}
+
+// maxPeers is the most peers a user talks with, one per chain in any
+// round.
+func (usr *user) maxPeers() int {
+	return usr.assigner.MinChains()
+}
+
+// isPeer returns whether the user talks with peer. usr.mu must be
+// held.
+func (usr *user) isPeer(peer *[32]byte) bool {
+	for _, p := range usr.peers {
+		if *p == *peer {
+			return true
+		}
+	}
+	return false
+}
+
+func (usr *user) addPeer(peer *[32]byte) error {
+	if usr.isPeer(peer) {
+		return nil
+	}
+	if len(usr.peers) >= usr.maxPeers() {
+		return ErrTooManyPeers
+	}
+	usr.peers = append(usr.peers, peer)
+	if usr.keys != nil {
+		err := usr.keys.AddContact(usr.publicKey, "", peer)
+		if err != nil && err != ErrDuplicateContact {
+			log.Println("Could not save contact:", err)
+		}
+	}
+	return nil
+}
+
+// conversation returns the conversation with peer, loading or starting
+// it if needed. usr.mu must be held.
+func (usr *user) conversation(peer *[32]byte) (*Conversation, error) {
+	if conv, ok := usr.convs[*peer]; ok {
+		return conv, nil
+	}
+	if usr.keys != nil {
+		state, err := usr.keys.State(usr.publicKey, peer)
+		if err != nil {
+			return nil, err
+		}
+		if len(state) > 0 {
+			conv := &Conversation{}
+			if err := proto.Unmarshal(state, conv); err != nil {
+				return nil, err
+			}
+			usr.convs[*peer] = conv
+			return conv, nil
+		}
+	}
+	conv, err := newConversation(usr.publicKey, usr.privateKey, peer)
+	if err != nil {
+		return nil, err
+	}
+	usr.convs[*peer] = conv
+	return conv, nil
+}
+
+// saveConversation saves the state of the conversation with peer, which
+// must happen before anything sealed with it is sent. usr.mu must be
+// held.
+func (usr *user) saveConversation(peer *[32]byte) error {
+	if usr.keys == nil {
+		return nil
+	}
+	state, err := proto.Marshal(usr.convs[*peer])
+	if err != nil {
+		return err
+	}
+	return usr.keys.SetState(usr.publicKey, peer, state)
+}
+
+// stream returns the stream with peer, loading or starting it if
+// needed. usr.mu must be held.
+func (usr *user) stream(peer *[32]byte) (*Stream, error) {
+	if st, ok := usr.streams[*peer]; ok {
+		return st, nil
+	}
+	st := newStream()
+	if usr.keys != nil {
+		state, err := usr.keys.Stream(usr.publicKey, peer)
+		if err != nil {
+			return nil, err
+		}
+		if len(state) > 0 {
+			if err := proto.Unmarshal(state, st); err != nil {
+				return nil, err
+			}
+			st.restart()
+		}
+	}
+	usr.streams[*peer] = st
+	return st, nil
+}
+
+// saveStream saves the state of the stream with peer. usr.mu must be
+// held.
+func (usr *user) saveStream(peer *[32]byte) error {
+	if usr.keys == nil {
+		return nil
+	}
+	state, err := proto.Marshal(usr.streams[*peer])
+	if err != nil {
+		return err
+	}
+	return usr.keys.SetStream(usr.publicKey, peer, state)
+}
+
+// settle records whether the fragments sent in the talks of round made
+// it, and saves the streams. usr.mu must be held.
+func (usr *user) settle(round uint64, talks []*talk, made func(*talk) bool) error {
+	for _, tk := range talks {
+		// the peer may have been removed since the round started
+		if tk.seq == 0 || !usr.isPeer(tk.peer) {
+			continue
+		}
+		st, err := usr.stream(tk.peer)
+		if err != nil {
+			return err
+		}
+		st.sent(tk.seq, round, made(tk))
+		if err := usr.saveStream(tk.peer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (usr *user) RemovePeer(peer *[32]byte) {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+	for i, p := range usr.peers {
+		if *p == *peer {
+			usr.peers = append(usr.peers[:i], usr.peers[i+1:]...)
+			break
+		}
+	}
+	delete(usr.streams, *peer)
+	delete(usr.convs, *peer)
+	if usr.keys != nil {
+		err := usr.keys.RemoveContact(usr.publicKey, peer)
+		if err != nil && err != ErrUnknownContact {
+			log.Println("Could not remove contact:", err)
+		}
+	}
+}
+
+func (usr *user) Send(peer *[32]byte, payload []byte) (uint32, error) {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+	if err := usr.addPeer(peer); err != nil {
+		return 0, err
+	}
+	st, err := usr.stream(peer)
+	if err != nil {
+		return 0, err
+	}
+	id, err := st.push(payload, usr.msgSize-convOverhead)
+	if err != nil {
+		return 0, err
+	}
+	return id, usr.saveStream(peer)
+}
+
+func (usr *user) Status(peer *[32]byte, id uint32) (Status, error) {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+	st, err := usr.stream(peer)
+	if err != nil {
+		return Status_QUEUED, err
+	}
+	return st.status(id)
+}
+
+func (usr *user) Receive() <-chan *Message {
+	return usr.received
+}
+
+func (usr *user) dialMailboxes() (map[string]mailbox.MailboxClient, func(), error) {
+	conns, err := config.DialServers(usr.mailboxes)
+	if err != nil {
+		return nil, nil, err
+	}
+	rpcs := make(map[string]mailbox.MailboxClient)
+	for mid, cfg := range usr.mailboxes {
+		rpcs[mid] = mailbox.NewMailboxClient(conns[cfg.Address])
+	}
+	return rpcs, func() { config.CloseConns(conns) }, nil
+}
+
+// RegisterUsers registers the inbox of the user for the round, or its
+// invitation inbox in a dial round. The number of users is ignored.
+func (usr *user) RegisterUsers(ctx context.Context, in *RegisterUsersRequest) (*RegisterUsersResponse, error) {
+	chains := usr.chains(usr.publicKey, in.Round)
+	rpcs, closeConns, err := usr.dialMailboxes()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConns()
+
+	if len(in.TokenKey) > 0 {
+		if err := usr.requestTokens(rpcs, in.Round, in.TokenKey, chains); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.Dial {
+		if err := usr.registerInvitations(rpcs, in.Round, chains); err != nil {
+			return nil, err
+		}
+		return &RegisterUsersResponse{}, nil
+	}
+
+	// the peers may use any chain (and pick them differently than the
+	// user thinks, until they all added each other)
+	err = registerAtReplicas(rpcs, usr.ring.Replicas(*usr.publicKey), in.Round,
+		[][]byte{usr.publicKey[:]}, []uint64{uint64(len(chains))},
+		[]uint64{uint64(len(chains) + usr.maxPeers())}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	return &RegisterUsersResponse{}, nil
+}
+
+// schedule picks the chain of every peer for round. usr.mu must be
+// held.
+func (usr *user) schedule(round uint64, chains []*config.Group) []*talk {
+	used := make(map[*config.Group]bool)
+	talks := make([]*talk, 0, len(usr.peers))
+	for _, peer := range usr.peers {
+		tk := &talk{peer: peer}
+		for _, group := range append(sharedChains(chains, usr.chains(peer, round)), chains...) {
+			if !used[group] {
+				tk.chain = group
+				break
+			}
+		}
+		used[tk.chain] = true
+		talks = append(talks, tk)
+	}
+	return talks
+}
+
+// roundMails returns the mail of the round through each chain, with
+// the next packet of the stream of each peer.
+func (usr *user) roundMails(round uint64, chains []*config.Group) (map[string]*mailbox.Mail, error) {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+
+	// rounds never downloaded failed
+	for r, talks := range usr.talks {
+		if r >= round {
+			continue
+		}
+		if err := usr.settle(r, talks, func(*talk) bool { return false }); err != nil {
+			return nil, err
+		}
+		delete(usr.talks, r)
+	}
+
+	mails := make(map[string]*mailbox.Mail)
+	talks := usr.schedule(round, chains)
+	for _, tk := range talks {
+		conv, err := usr.conversation(tk.peer)
+		if err != nil {
+			return nil, err
+		}
+		st, err := usr.stream(tk.peer)
+		if err != nil {
+			return nil, err
+		}
+		var packet []byte
+		packet, tk.seq = st.next(round, usr.msgSize-convOverhead)
+		sealed, err := conv.seal(round, tk.chain.Gid, usr.publicKey, usr.privateKey, tk.peer, packet)
+		if err != nil {
+			return nil, err
+		}
+		if err := usr.saveConversation(tk.peer); err != nil {
+			return nil, err
+		}
+		if err := usr.saveStream(tk.peer); err != nil {
+			return nil, err
+		}
+		mails[tk.chain.Gid] = &mailbox.Mail{UserKey: tk.peer[:], Message: sealed}
+	}
+	usr.talks[round] = talks
+
+	for _, group := range chains {
+		if mails[group.Gid] != nil {
+			continue
+		}
+		nonce := mailNonce(round, group.Gid)
+		mails[group.Gid] = mailbox.SealMail(usr.publicKey, usr.privateKey, nonce, make([]byte, usr.msgSize))
+	}
+	return mails, nil
+}
+
+func (usr *user) GenerateMessages(ctx context.Context, in *GenerateMessagesRequest) (*GenerateMessagesResponse, error) {
+	if int(in.MsgSize) != usr.msgSize {
+		return nil, errors.New("Message size differs from the user's")
+	}
+	round := int(in.Round)
+	chains := usr.chains(usr.publicKey, in.Round)
+	groups := make(map[string]*config.Group)
+	for _, group := range chains {
+		groups[group.Gid] = group
+	}
+	xs, ys, kems, err := getInnerKeys(usr.servers, groups, round)
+	if err != nil {
+		return nil, err
+	}
+
+	usr.mu.Lock()
+	plan, dial := usr.dialRounds[in.Round]
+	usr.mu.Unlock()
+	var mails map[string]*mailbox.Mail
+	if dial {
+		mails, err = usr.dialMails(in.Round, chains, plan)
+	} else {
+		mails, err = usr.roundMails(in.Round, chains)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ciphertexts := make(map[string][]byte)
+	prfs := make(map[string][]byte)
+	for _, group := range chains {
+		suite, err := mixnet.GroupSuite(group)
+		if err != nil {
+			return nil, err
+		}
+		envClient := mixnet.NewClient(suite)
+		envClient.NewRound(round, xs[group.Gid], ys[group.Gid], kems[group.Gid])
+		nonces := make([][]byte, len(group.Servers))
+		auxs := make([][]byte, len(group.Servers))
+		ciphertexts[group.Gid], prfs[group.Gid] = onionEncrypt(round, usr.servers, group, suite, envClient, mails[group.Gid], nonces, auxs)
+	}
+
+	usr.mu.Lock()
+	usr.ciphertexts = ciphertexts
+	usr.prfs = prfs
+	usr.mu.Unlock()
+	return &GenerateMessagesResponse{}, nil
+}
+
+func (usr *user) SubmitMessages(ctx context.Context, in *SubmitMessagesRequest) (*SubmitMessagesResponse, error) {
+	usr.mu.Lock()
+	ciphertexts, prfs := usr.ciphertexts, usr.prfs
+	usr.ciphertexts, usr.prfs = nil, nil
+	tokens := usr.tokens[in.Round]
+	for r := range usr.tokens {
+		if r <= in.Round {
+			delete(usr.tokens, r)
+		}
+	}
+	usr.mu.Unlock()
+	if ciphertexts == nil {
+		return nil, errors.New("Messages not generated yet")
+	}
+
+	failed := make(map[string]bool)
+	err := usr.submit(in.Round, ciphertexts, prfs, tokens, failed)
+	if err != nil {
+		// the fragments sent through the chains that failed go out
+		// again in a later round
+		usr.mu.Lock()
+		serr := usr.settle(in.Round, usr.talks[in.Round], func(tk *talk) bool {
+			return !failed[tk.chain.Gid]
+		})
+		usr.mu.Unlock()
+		if serr != nil {
+			log.Println("Could not save streams:", serr)
+		}
+		return nil, err
+	}
+	return &SubmitMessagesResponse{}, nil
+}
+
+// submit submits the ciphertexts of round through each chain, with
+// their tokens if any, and marks the chains that failed.
+func (usr *user) submit(round uint64, ciphertexts, prfs, tokens map[string][]byte, failed map[string]bool) error {
+	conns, err := config.DialServers(usr.servers)
+	if err != nil {
+		for gid := range ciphertexts {
+			failed[gid] = true
+		}
+		return err
+	}
+	defer config.CloseConns(conns)
+
+	mrpcs := make(map[string]mixnet.MixClient)
+	for id, cfg := range usr.servers {
+		mrpcs[id] = mixnet.NewMixClient(conns[cfg.Address])
+	}
+
+	var first error
+	for gid := range ciphertexts {
+		var gtokens [][]byte
+		if tokens != nil {
+			gtokens = [][]byte{tokens[gid]}
+		}
+		err = submitCiphertexts(mrpcs, round, usr.groups[gid], [][]byte{ciphertexts[gid]}, [][]byte{prfs[gid]}, gtokens)
+		if err != nil {
+			failed[gid] = true
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+func (usr *user) DownloadMessages(ctx context.Context, in *DownloadMessagesRequest) (*DownloadMessagesResponse, error) {
+	usr.mu.Lock()
+	talks := usr.talks[in.Round]
+	delete(usr.talks, in.Round)
+	plan, dial := usr.dialRounds[in.Round]
+	delete(usr.dialRounds, in.Round)
+	usr.mu.Unlock()
+
+	rpcs, closeConns, err := usr.dialMailboxes()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConns()
+
+	if dial {
+		return usr.downloadInvitations(ctx, rpcs, in.Round, plan)
+	}
+
+	// fetch from the first replica that answers
+	var inboxes []*mailbox.Inbox
+	for _, mid := range usr.ring.Replicas(*usr.publicKey) {
+		inboxes, err = getInboxes(rpcs[mid], in.Round, []*[32]byte{usr.publicKey}, []*[32]byte{usr.privateKey}, nil)
+		if err == nil {
+			break
+		}
+		log.Println("Could not get mails from", mid, err)
+	}
+	if err != nil {
+		usr.mu.Lock()
+		serr := usr.settle(in.Round, talks, func(*talk) bool { return false })
+		usr.mu.Unlock()
+		if serr != nil {
+			log.Println("Could not save streams:", serr)
+		}
+		return nil, err
+	}
+
+	resp := &DownloadMessagesResponse{}
+	var msgs []*Message
+	for _, inbox := range inboxes {
+		resp.Expected += inbox.Expected
+		resp.Received += inbox.Received
+		if inbox.Received < inbox.Expected {
+			resp.Incomplete++
+		}
+		// everything but the mails from the peers is a loopback
+		opened, err := usr.openMails(in.Round, talks, inbox.Messages)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, opened...)
+	}
+	usr.mu.Lock()
+	err = usr.settle(in.Round, talks, func(tk *talk) bool { return tk.heard })
+	usr.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, msg := range msgs {
+		select {
+		case usr.received <- msg:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return resp, nil
+}
+
+// openMails opens the mails from the peers of the round, and returns
+// the payloads they complete. The mails do not say which chain they
+// came from, so every chain of each peer is tried.
+func (usr *user) openMails(round uint64, talks []*talk, ciphertexts [][]byte) ([]*Message, error) {
+	usr.mu.Lock()
+	defer usr.mu.Unlock()
+
+	var msgs []*Message
+	opened := make([]bool, len(ciphertexts))
+	for _, tk := range talks {
+		// the peer may have been removed since the round started
+		if !usr.isPeer(tk.peer) {
+			continue
+		}
+		conv, err := usr.conversation(tk.peer)
+		if err != nil {
+			return nil, err
+		}
+		st, err := usr.stream(tk.peer)
+		if err != nil {
+			return nil, err
+		}
+		for c, ciphertext := range ciphertexts {
+			if opened[c] {
+				continue
+			}
+			for _, group := range usr.chains(tk.peer, round) {
+				msg, err := conv.open(round, group.Gid, usr.privateKey, tk.peer, ciphertext)
+				if err != nil {
+					continue
+				}
+				opened[c] = true
+				tk.heard = true
+				payloads, err := st.receive(msg)
+				if err != nil {
+					log.Println("Could not read packet:", err)
+				}
+				for _, payload := range payloads {
+					msgs = append(msgs, &Message{Round: round, From: *tk.peer, Payload: payload})
+				}
+				break
+			}
+		}
+		conv.skip(round)
+		if err := usr.saveConversation(tk.peer); err != nil {
+			return nil, err
+		}
+		if err := usr.saveStream(tk.peer); err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/kwonalbert/xrd/config"
+)
+
+func TestSharedChains(t *testing.T) {
+	groups := make([]*config.Group, 4)
+	for g := range groups {
+		groups[g] = &config.Group{Gid: fmt.Sprintf("group:%d", g)}
+	}
+
+	tests := []struct {
+		name   string
+		a, b   []*config.Group
+		shared []*config.Group
+	}{
+		{"none", groups[:2], groups[2:], nil},
+		{"empty", nil, groups, nil},
+		{"one", groups[:2], groups[1:3], groups[1:2]},
+		{"sorted", []*config.Group{groups[3], groups[0], groups[2]},
+			[]*config.Group{groups[2], groups[3], groups[0]},
+			[]*config.Group{groups[0], groups[2], groups[3]}},
+	}
+	for _, test := range tests {
+		for _, ab := range [][2][]*config.Group{{test.a, test.b}, {test.b, test.a}} {
+			shared := sharedChains(ab[0], ab[1])
+			if len(shared) != len(test.shared) {
+				t.Fatal("Wrong shared chains", test.name, shared)
+			}
+			for i := range shared {
+				if shared[i] != test.shared[i] {
+					t.Fatal("Wrong shared chains", test.name, shared)
+				}
+			}
+
+			chain, ok := sharedChain(ab[0], ab[1])
+			if ok != (len(test.shared) > 0) {
+				t.Fatal("Wrong shared chain", test.name, ok)
+			}
+			if ok && chain != test.shared[0] {
+				t.Fatal("Wrong shared chain", test.name, chain.Gid)
+			}
+		}
+	}
+}
+
+func TestRemovePeer(t *testing.T) {
+	groups := make(map[string]*config.Group)
+	for g := 0; g < 10; g++ {
+		gid := fmt.Sprintf("group:%d", g)
+		groups[gid] = &config.Group{Gid: gid, Row: uint32(g)}
+	}
+	file := filepath.Join(t.TempDir(), "keys")
+	load := func() *user {
+		keys, err := OpenKeystore(file, []byte("passphrase"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		usr, err := LoadUser(keys, nil, nil, groups, 1, 256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return usr.(*user)
+	}
+
+	usr := load()
+	peer, _, _ := box.GenerateKey(rand.Reader)
+	if _, err := usr.Send(peer, []byte("dropped")); err != nil {
+		t.Fatal(err)
+	}
+	usr.RemovePeer(peer)
+
+	// the peer and its payloads are gone, and stay gone after a restart
+	for _, restart := range []bool{false, true} {
+		if restart {
+			usr = load()
+		}
+		if usr.isPeer(peer) {
+			t.Fatal("Removed peer came back", restart)
+		}
+		if err := usr.AddPeer(peer); err != nil {
+			t.Fatal(err)
+		}
+		usr.mu.Lock()
+		st, err := usr.stream(peer)
+		usr.mu.Unlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(st.Outbox) != 0 {
+			t.Fatal("Dropped payload still queued", restart)
+		}
+		usr.RemovePeer(peer)
+	}
+}
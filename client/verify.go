@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mailbox"
+)
+
+// Delivery checks of the simulated users. A user sends the same message
+// through each of its chains, but sealed with the nonce of the round and
+// the chain, so a copy only opens with the nonce of the chain it went
+// through. Each user should get exactly one copy per chain:
+//   - a chain with no copy that opens missed the user, unless as many
+//     copies did not open as there are such chains, in which case the
+//     chains corrupted them
+//   - any other copy that does not open is corrupted, but cannot be
+//     traced to a chain
+//   - a copy that opens to something else than the message, or a
+//     second copy from the same chain, is corrupted too
+// The counts of all the users are reported by gid.
+
+type deliveryCheck struct {
+	mu     sync.Mutex
+	chains map[string]*ChainCheck
+}
+
+func newDeliveryCheck() *deliveryCheck {
+	return &deliveryCheck{
+		chains: make(map[string]*ChainCheck),
+	}
+}
+
+// chain returns the counts of gid. dc.mu must be held.
+func (dc *deliveryCheck) chain(gid string) *ChainCheck {
+	check, ok := dc.chains[gid]
+	if !ok {
+		check = &ChainCheck{Gid: gid}
+		dc.chains[gid] = check
+	}
+	return check
+}
+
+// check checks the inbox of a user that sent msg through chains.
+func (dc *deliveryCheck) check(round uint64, pub, priv *[32]byte, chains []*config.Group, msg []byte, inbox *mailbox.Inbox) {
+	good := make(map[string]int)
+	bad := make(map[string]int)
+	untraced := 0
+	for _, ciphertext := range inbox.Messages {
+		traced := false
+		for _, group := range chains {
+			plain := mailbox.OpenMail(pub, priv, mailNonce(round, group.Gid), ciphertext)
+			if plain == nil {
+				continue
+			}
+			traced = true
+			if bytes.Equal(plain, msg) {
+				good[group.Gid]++
+			} else {
+				bad[group.Gid]++
+			}
+			break
+		}
+		if !traced {
+			untraced++
+		}
+	}
+
+	var lost []string
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	for _, group := range chains {
+		check := dc.chain(group.Gid)
+		check.Expected++
+		n := good[group.Gid]
+		if n > 0 {
+			check.Delivered++
+			check.Corrupted += uint64(n - 1)
+		} else if bad[group.Gid] == 0 {
+			lost = append(lost, group.Gid)
+		}
+		check.Corrupted += uint64(bad[group.Gid])
+	}
+	if untraced == len(lost) {
+		for _, gid := range lost {
+			dc.chain(gid).Corrupted++
+		}
+		return
+	}
+	for _, gid := range lost {
+		dc.chain(gid).Missing++
+	}
+	if untraced > 0 {
+		dc.chain("").Corrupted += uint64(untraced)
+	}
+}
+
+// report returns the counts by gid.
+func (dc *deliveryCheck) report() []*ChainCheck {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	checks := make([]*ChainCheck, 0, len(dc.chains))
+	for _, check := range dc.chains {
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Gid < checks[j].Gid })
+	return checks
+}
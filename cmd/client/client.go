@@ -20,6 +20,10 @@ var (
 	groupFile   = flag.String("groups", "group.config", "Group configuration file name")
 	mailboxFile = flag.String("mailboxes", "mailbox.config", "Mailbox configuration file name")
 	clientFile  = flag.String("clients", "client.config", "Client configuration file name")
+
+	pir = flag.Bool("pir", false, "Download mails with private information retrieval (needs every inbox at every mailbox)")
+
+	keystoreFile = flag.String("keystore", "", "Keystore file of the users, with the passphrase in $XRD_PASSPHRASE (new users every round if empty)")
 )
 
 func main() {
@@ -34,7 +38,7 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	mcfgs, err := config.UnmarshalServersFromFile(*mailboxFile)
+	mcfgs, replicas, err := config.UnmarshalMailboxesFromFile(*mailboxFile)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -43,7 +47,15 @@ func main() {
 		log.Fatal(err)
 	}
 
-	clt := client.NewClient(mcfgs, scfgs, gcfgs)
+	var keys client.Keystore
+	if *keystoreFile != "" {
+		keys, err = client.OpenKeystore(*keystoreFile, []byte(os.Getenv("XRD_PASSPHRASE")))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	clt := client.NewClient(mcfgs, scfgs, gcfgs, replicas, *pir, keys)
 
 	cred := credentials.NewServerTLSFromCert(config.FindCertificate(*addr, ccfgs))
 	grpcServer := grpc.NewServer(grpc.Creds(cred),
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mixnet/verifiable_mixnet"
 )
 
 var (
@@ -17,6 +18,9 @@ var (
 	groupFile   = flag.String("groups", "group.config", "Group configuration file name")
 	mailboxFile = flag.String("mailboxes", "mailbox.config", "Mailbox configuration file name")
 	clientFile  = flag.String("clients", "client.config", "Client configuration file name")
+	hybrid      = flag.Bool("hybrid", false, "Add ML-KEM to the onion layers")
+	suite       = flag.String("suite", "", "Onion layer cipher suite (aes128-ctr-hmac-sha256, aes256-gcm-siv, chacha20-poly1305)")
+	replicas    = flag.Int("replicas", 1, "Number of mailboxes holding each inbox")
 )
 
 func main() {
@@ -49,6 +53,14 @@ func main() {
 	scfgs, gcfgs := config.CreateGroupConfig(len(servers), *f, servers)
 	//scfgs, gcfgs := config.CreateOneGroupConfig(servers)
 
+	if _, err := verifiable_mixnet.SuiteByName(*suite); err != nil {
+		log.Fatal(err)
+	}
+	for _, group := range gcfgs {
+		group.Suite = *suite
+		group.Hybrid = *hybrid
+	}
+
 	ccfgs := make(map[string]*config.Server)
 	for c := range clients {
 		cid := fmt.Sprintf("client:%d", c)
@@ -69,7 +81,10 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	err = config.MarshalServersToFile(*mailboxFile, mcfgs)
+	if *replicas < 1 || *replicas > len(mailboxes) {
+		log.Fatal("Invalid number of replicas")
+	}
+	err = config.MarshalMailboxesToFile(*mailboxFile, mcfgs, *replicas)
 	if err != nil {
 		log.Fatal(err)
 	}
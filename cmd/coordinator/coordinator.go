@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kwonalbert/xrd/config"
 	"github.com/kwonalbert/xrd/coordinator"
@@ -18,10 +19,14 @@ var (
 	groupFile   = flag.String("groups", "group.config", "Group configuration file name")
 	mailboxFile = flag.String("mailboxes", "mailbox.config", "Mailbox configuration file name")
 	clientFile  = flag.String("clients", "client.config", "Client configuration file name")
+
+	deliveryTimeout = flag.Duration("delivery-timeout", 10*time.Minute, "How long mailboxes wait for mails in a round (0 for forever)")
+	enforce         = flag.Bool("enforce", false, "Check that every user submits through all of its chains (mailboxes need -groups)")
 )
 
 func printHelp() {
 	fmt.Println("new <round_number> <num_users>: start a new round")
+	fmt.Println("dial <round_number> <num_users>: start a new dial round")
 	fmt.Println("generate <msg_size>: generate messages for submission")
 	fmt.Println("submit: submit generated messages")
 	fmt.Println("start: start the experiment")
@@ -66,7 +71,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs)
+	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs, *deliveryTimeout, *enforce)
 
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Println("Quark experiment coordinator")
@@ -87,6 +92,15 @@ func main() {
 			if err != nil {
 				fmt.Println("NewRound error:", err)
 			}
+		} else if strings.Compare("dial", line) == 0 || strings.Compare("d", line) == 0 {
+			fmt.Println("Round number: ")
+			round = int(readUint64(reader))
+			fmt.Println("Number of users: ")
+			numUsers := int(readUint64(reader))
+			err := coordinator.NewDialRound(round, numUsers)
+			if err != nil {
+				fmt.Println("NewDialRound error:", err)
+			}
 		} else if strings.Compare("generate", line) == 0 || strings.Compare("g", line) == 0 {
 			fmt.Print("Message size: ")
 			msgSize := int(readUint64(reader))
@@ -12,28 +12,77 @@ import (
 	"github.com/kwonalbert/xrd/config"
 	"github.com/kwonalbert/xrd/mailbox"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 var (
 	addr        = flag.String("addr", "localhost:9000", "Address of this mailbox")
 	mailboxFile = flag.String("mailboxes", "mailbox.config", "Mailbox configuration file name")
+	groupFile   = flag.String("groups", "", "Group configuration file name, to issue submission tokens (none if empty)")
+
+	storeDir     = flag.String("store", "", "Directory to keep the inboxes in (in memory if empty)")
+	keyFile      = flag.String("keys", "", "File to keep the keys of the stored rounds in, on different storage than the store (needed with -store)")
+	retainRounds = flag.Uint64("retain-rounds", 3, "Number of past rounds to keep (0 for no limit)")
+	retainTime   = flag.Duration("retain-time", 0, "How long to keep past rounds (0 for no limit)")
 )
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 	flag.Parse()
 
-	mcfgs, err := config.UnmarshalServersFromFile(*mailboxFile)
+	mcfgs, replicas, err := config.UnmarshalMailboxesFromFile(*mailboxFile)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	var coordinator ecdsa.PublicKey
 
-	mb := mailbox.NewMailboxServer(coordinator)
+	store := mailbox.NewMemoryStore()
+	if *storeDir != "" {
+		store, err = mailbox.NewDiskStore(*storeDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// encrypted at rest, so expired rounds are shredded with their keys
+		if *keyFile == "" {
+			log.Fatal("The store needs a key file (-keys)")
+		}
+		if *retainRounds == 0 && *retainTime == 0 {
+			log.Fatal("The store needs a retention limit to shred rounds")
+		}
+		keys, err := mailbox.NewKeyRing(*keyFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		store, err = mailbox.NewEncryptedStore(store, keys)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	defer store.Close()
+
+	retention := mailbox.Retention{
+		Rounds: *retainRounds,
+		Age:    *retainTime,
+	}
+	replication := mailbox.Replication{
+		Mailboxes: mcfgs,
+		Factor:    replicas,
+	}
+	for mid, cfg := range mcfgs {
+		if cfg.Address == *addr {
+			replication.Id = mid
+		}
+	}
+	var tokens mailbox.Tokens
+	if *groupFile != "" {
+		tokens.Groups, err = config.UnmarshalGroupsFromFile(*groupFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	mb := mailbox.NewMailboxServer(coordinator, store, retention, replication, tokens)
 
-	cred := credentials.NewServerTLSFromCert(config.FindCertificate(*addr, mcfgs))
+	cred := mailbox.ServerCredentials(*addr, mcfgs)
 	grpcServer := grpc.NewServer(grpc.Creds(cred),
 		grpc.MaxRecvMsgSize(2*config.StreamSize), grpc.MaxSendMsgSize(2*config.StreamSize))
 	mailbox.RegisterMailboxServer(grpcServer, mb)
@@ -45,7 +45,7 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	mcfgs, err := config.UnmarshalServersFromFile(*mailboxFile)
+	mcfgs, replicas, err := config.UnmarshalMailboxesFromFile(*mailboxFile)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -54,7 +54,7 @@ func main() {
 	var coordinator ecdsa.PublicKey
 
 	mixer := mixnet.NewMixServer(*addr, coordinator, scfgs, gcfgs)
-	serv := server.NewServer(*addr, coordinator, mcfgs, scfgs, gcfgs, mixer)
+	serv := server.NewServer(*addr, coordinator, mcfgs, scfgs, gcfgs, replicas, mixer)
 
 	cred := credentials.NewServerTLSFromCert(config.FindCertificate(*addr, scfgs))
 	grpcServer := grpc.NewServer(grpc.Creds(cred),
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"math"
 	"sort"
 )
@@ -67,3 +69,112 @@ func Assignments(groups map[string]*Group) [][]*Group {
 
 	return assigns
 }
+
+// An Assigner assigns users to chains. The chains of a user in a round
+// are a function of its key, the round and the topology, so anyone
+// knowing the groups can compute and check them: one of the
+// Assignments, picked by a hash of the three with the probabilities of
+// the LP that balances the load of the chains. The probabilities are
+// rounded to integer weights, so everyone picks the same.
+type Assigner struct {
+	topology    [32]byte
+	assignments [][]*Group
+	// cumulative weights of the assignments
+	weights []uint64
+}
+
+// precision of the weights of the assignments
+const assignmentWeight = 1 << 20
+
+func NewAssigner(groups map[string]*Group) *Assigner {
+	assignments := Assignments(groups)
+	division, _ := findOptimalAssignment(groups, assignments)
+
+	weights := make([]uint64, len(assignments))
+	total := uint64(0)
+	for a := range assignments {
+		if division[a] > 0 {
+			total += uint64(math.Round(division[a] * assignmentWeight))
+		}
+		weights[a] = total
+	}
+	if total == 0 {
+		panic("No assignment to pick")
+	}
+
+	return &Assigner{
+		topology:    topology(groups),
+		assignments: assignments,
+		weights:     weights,
+	}
+}
+
+// topology hashes the chains and their servers.
+func topology(groups map[string]*Group) [32]byte {
+	gids := make([]string, 0, len(groups))
+	for gid := range groups {
+		gids = append(gids, gid)
+	}
+	sort.Strings(gids)
+
+	h := sha256.New()
+	for _, gid := range gids {
+		binary.Write(h, binary.BigEndian, uint32(len(gid)))
+		h.Write([]byte(gid))
+		binary.Write(h, binary.BigEndian, uint32(len(groups[gid].Servers)))
+		for _, sid := range groups[gid].Servers {
+			binary.Write(h, binary.BigEndian, uint32(len(sid)))
+			h.Write([]byte(sid))
+		}
+	}
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
+
+// Assign returns the chains of key in round.
+func (a *Assigner) Assign(key []byte, round uint64) []*Group {
+	h := sha256.New()
+	h.Write([]byte("xrd assignment"))
+	h.Write(a.topology[:])
+	binary.Write(h, binary.BigEndian, round)
+	h.Write(key)
+	x := binary.BigEndian.Uint64(h.Sum(nil)) % a.weights[len(a.weights)-1]
+	i := sort.Search(len(a.weights), func(i int) bool { return x < a.weights[i] })
+	return a.assignments[i]
+}
+
+// Verify returns whether gids are the chains of key in round, in any
+// order, each exactly once.
+func (a *Assigner) Verify(key []byte, round uint64, gids []string) bool {
+	chains := a.Assign(key, round)
+	if len(gids) != len(chains) {
+		return false
+	}
+	want := make([]string, len(chains))
+	for i, group := range chains {
+		want[i] = group.Gid
+	}
+	got := append([]string(nil), gids...)
+	sort.Strings(want)
+	sort.Strings(got)
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MinChains is the fewest chains a user can get.
+func (a *Assigner) MinChains() int {
+	min := 0
+	prev := uint64(0)
+	for i, assignment := range a.assignments {
+		if a.weights[i] > prev && (min == 0 || len(assignment) < min) {
+			min = len(assignment)
+		}
+		prev = a.weights[i]
+	}
+	return min
+}
@@ -73,3 +73,53 @@ func TestAssignments(t *testing.T) {
 		}
 	}
 }
+
+func TestAssigner(t *testing.T) {
+	n := 40
+	groups := createGroups(n)
+	assigner := NewAssigner(groups)
+	other := NewAssigner(createGroups(n))
+
+	users := 20000
+	counts := make(map[string]int)
+	changed := 0
+	for u := 0; u < users; u++ {
+		key := []byte(fmt.Sprint("user", u))
+		chains := assigner.Assign(key, 1)
+		gids := make([]string, len(chains))
+		for i, group := range chains {
+			gids[i] = group.Gid
+			counts[group.Gid]++
+		}
+		if len(chains) < assigner.MinChains() {
+			t.Fatal("Fewer chains than the minimum")
+		}
+		if !other.Verify(key, 1, gids) {
+			t.Fatal("Assignment not verified by an assigner of the same groups")
+		}
+		if other.Verify(key, 1, gids[1:]) {
+			t.Fatal("Verified part of an assignment")
+		}
+		if len(gids) > 1 && other.Verify(key, 1, append([]string{gids[0]}, gids[:len(gids)-1]...)) {
+			t.Fatal("Verified an assignment with a duplicated chain")
+		}
+		if !assigner.Verify(key, 2, gids) {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Error("Assignments do not change across rounds")
+	}
+
+	// every chain gets about the load of the LP, and no more
+	_, maxLoad := findOptimalAssignment(groups, Assignments(groups))
+	max := maxLoad * float64(users)
+	for gid, count := range counts {
+		if float64(count) > 1.1*max {
+			t.Error("Chain overloaded", gid, count, max)
+		}
+	}
+	if len(counts) != n {
+		t.Error("Chains left without users")
+	}
+}
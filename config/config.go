@@ -41,7 +41,7 @@ func SelfSignedCertificate(name pkix.Name) ([]byte, *ecdsa.PrivateKey) {
 		Subject:      name,
 
 		PublicKeyAlgorithm: x509.ECDSA,
-		PublicKey:          priv.PublicKey,
+		PublicKey:          &priv.PublicKey,
 
 		IsCA:     true,
 		KeyUsage: x509.KeyUsageCertSign,
@@ -52,6 +52,8 @@ func SelfSignedCertificate(name pkix.Name) ([]byte, *ecdsa.PrivateKey) {
 
 	if ip := net.ParseIP(name.CommonName); ip != nil {
 		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{name.CommonName}
 	}
 
 	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
@@ -103,6 +105,8 @@ func CreateServerWithCertificate(addr string, id string, cert, key []byte) *Serv
 	copy(pub[64-len(yb):], yb)
 	copy(priv[32-len(pb):], pb)
 
+	kemPub, kemPriv := verifiable_mixnet.GenerateKEMKey()
+
 	s := &Server{
 		Address:         addr,
 		Id:              id,
@@ -111,6 +115,8 @@ func CreateServerWithCertificate(addr string, id string, cert, key []byte) *Serv
 		PublicKey:       pub,
 		PrivateKey:      priv,
 		Extensions:      nil,
+		KemPublicKey:    kemPub,
+		KemPrivateKey:   kemPriv,
 	}
 	return s
 }
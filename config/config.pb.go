@@ -48,6 +48,9 @@ type Server struct {
 	PrivateKey []byte `protobuf:"bytes,6,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
 	// extensions contain the blinding key
 	Extensions map[string][]byte `protobuf:"bytes,7,rep,name=extensions" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// ml-kem onion encryption key for hybrid groups
+	KemPublicKey  []byte `protobuf:"bytes,8,opt,name=kem_public_key,json=kemPublicKey,proto3" json:"kem_public_key,omitempty"`
+	KemPrivateKey []byte `protobuf:"bytes,9,opt,name=kem_private_key,json=kemPrivateKey,proto3" json:"kem_private_key,omitempty"`
 }
 
 func (m *Server) Reset()                    { *m = Server{} }
@@ -104,6 +107,20 @@ func (m *Server) GetExtensions() map[string][]byte {
 	return nil
 }
 
+func (m *Server) GetKemPublicKey() []byte {
+	if m != nil {
+		return m.KemPublicKey
+	}
+	return nil
+}
+
+func (m *Server) GetKemPrivateKey() []byte {
+	if m != nil {
+		return m.KemPrivateKey
+	}
+	return nil
+}
+
 type Group struct {
 	Gid   string `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
 	Layer uint32 `protobuf:"fixed32,2,opt,name=layer,proto3" json:"layer,omitempty"`
@@ -113,6 +130,10 @@ type Group struct {
 	// neighbors gids
 	Predecessors []string `protobuf:"bytes,5,rep,name=predecessors" json:"predecessors,omitempty"`
 	Successors   []string `protobuf:"bytes,6,rep,name=successors" json:"successors,omitempty"`
+	// onion layer cipher suite, default if empty
+	Suite string `protobuf:"bytes,7,opt,name=suite,proto3" json:"suite,omitempty"`
+	// whether onion layers also use ml-kem
+	Hybrid bool `protobuf:"varint,8,opt,name=hybrid,proto3" json:"hybrid,omitempty"`
 }
 
 func (m *Group) Reset()                    { *m = Group{} }
@@ -162,6 +183,20 @@ func (m *Group) GetSuccessors() []string {
 	return nil
 }
 
+func (m *Group) GetSuite() string {
+	if m != nil {
+		return m.Suite
+	}
+	return ""
+}
+
+func (m *Group) GetHybrid() bool {
+	if m != nil {
+		return m.Hybrid
+	}
+	return false
+}
+
 type Layer struct {
 	LayerId uint32 `protobuf:"fixed32,1,opt,name=layer_id,json=layerId,proto3" json:"layer_id,omitempty"`
 	// group ids of this layer
@@ -189,6 +224,8 @@ func (m *Layer) GetGroups() []string {
 
 type Servers struct {
 	Servers map[string]*Server `protobuf:"bytes,1,rep,name=servers" json:"servers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// number of mailboxes holding each inbox, for mailbox configs
+	Replicas uint32 `protobuf:"fixed32,2,opt,name=replicas,proto3" json:"replicas,omitempty"`
 }
 
 func (m *Servers) Reset()                    { *m = Servers{} }
@@ -203,6 +240,13 @@ func (m *Servers) GetServers() map[string]*Server {
 	return nil
 }
 
+func (m *Servers) GetReplicas() uint32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
 type Groups struct {
 	Groups map[string]*Group `protobuf:"bytes,1,rep,name=groups" json:"groups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -317,6 +361,18 @@ func (m *Server) MarshalTo(dAtA []byte) (int, error) {
 			}
 		}
 	}
+	if len(m.KemPublicKey) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.KemPublicKey)))
+		i += copy(dAtA[i:], m.KemPublicKey)
+	}
+	if len(m.KemPrivateKey) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.KemPrivateKey)))
+		i += copy(dAtA[i:], m.KemPrivateKey)
+	}
 	return i, nil
 }
 
@@ -398,6 +454,22 @@ func (m *Group) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if len(m.Suite) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Suite)))
+		i += copy(dAtA[i:], m.Suite)
+	}
+	if m.Hybrid {
+		dAtA[i] = 0x40
+		i++
+		if m.Hybrid {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -483,6 +555,12 @@ func (m *Servers) MarshalTo(dAtA []byte) (int, error) {
 			}
 		}
 	}
+	if m.Replicas != 0 {
+		dAtA[i] = 0x15
+		i++
+		binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Replicas))
+		i += 4
+	}
 	return i, nil
 }
 
@@ -610,6 +688,14 @@ func (m *Server) Size() (n int) {
 			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
 		}
 	}
+	l = len(m.KemPublicKey)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.KemPrivateKey)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
 	return n
 }
 
@@ -644,6 +730,13 @@ func (m *Group) Size() (n int) {
 			n += 1 + l + sovConfig(uint64(l))
 		}
 	}
+	l = len(m.Suite)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Hybrid {
+		n += 2
+	}
 	return n
 }
 
@@ -678,6 +771,9 @@ func (m *Servers) Size() (n int) {
 			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
 		}
 	}
+	if m.Replicas != 0 {
+		n += 5
+	}
 	return n
 }
 
@@ -1055,6 +1151,68 @@ func (m *Server) Unmarshal(dAtA []byte) error {
 			}
 			m.Extensions[mapkey] = mapvalue
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KemPublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KemPublicKey = append(m.KemPublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.KemPublicKey == nil {
+				m.KemPublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KemPrivateKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KemPrivateKey = append(m.KemPrivateKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.KemPrivateKey == nil {
+				m.KemPrivateKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1241,6 +1399,55 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 			}
 			m.Successors = append(m.Successors, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suite", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Suite = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hybrid", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Hybrid = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1503,6 +1710,16 @@ func (m *Servers) Unmarshal(dAtA []byte) error {
 			}
 			m.Servers[mapkey] = mapvalue
 			iNdEx = postIndex
+		case 2:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+			}
+			m.Replicas = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replicas = uint32(binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1886,35 +2103,39 @@ var (
 func init() { proto.RegisterFile("config.proto", fileDescriptorConfig) }
 
 var fileDescriptorConfig = []byte{
-	// 473 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xd1, 0x8a, 0xd4, 0x30,
-	0x14, 0x35, 0x9d, 0x6d, 0xbb, 0x7b, 0xa7, 0xee, 0x2e, 0x41, 0x24, 0x16, 0xad, 0x43, 0x55, 0x18,
-	0x5f, 0x46, 0x18, 0x41, 0x64, 0x41, 0x1f, 0x84, 0x45, 0x57, 0x7d, 0xea, 0x7e, 0xc0, 0x30, 0x3b,
-	0x89, 0x43, 0x70, 0x68, 0x4b, 0xd2, 0x19, 0xed, 0x0f, 0xf8, 0xe6, 0xbb, 0x6f, 0xfe, 0x86, 0x9f,
-	0xe0, 0xa3, 0x9f, 0x20, 0xe3, 0x8f, 0x48, 0x6e, 0x92, 0xda, 0xca, 0x3e, 0x35, 0xe7, 0x9e, 0x73,
-	0x92, 0x73, 0x0f, 0x14, 0x92, 0x55, 0x55, 0x7e, 0x90, 0xeb, 0x59, 0xad, 0xaa, 0xa6, 0xa2, 0x91,
-	0x45, 0xf9, 0x8f, 0x00, 0xa2, 0x4b, 0xa1, 0x76, 0x42, 0x51, 0x06, 0xf1, 0x92, 0x73, 0x25, 0xb4,
-	0x66, 0x64, 0x42, 0xa6, 0x47, 0x85, 0x87, 0xf4, 0x18, 0x02, 0xc9, 0x59, 0x80, 0xc3, 0x40, 0x72,
-	0x9a, 0xc2, 0xa1, 0xe4, 0xa2, 0x6c, 0x64, 0xd3, 0xb2, 0xd1, 0x84, 0x4c, 0x93, 0xa2, 0xc3, 0xf4,
-	0x31, 0x9c, 0xd6, 0x4a, 0xee, 0x96, 0x8d, 0x58, 0x74, 0x9a, 0x03, 0xd4, 0x9c, 0xb8, 0xf9, 0x85,
-	0x97, 0xde, 0x03, 0xa8, 0xb7, 0x57, 0x1b, 0xb9, 0x5a, 0x7c, 0x14, 0x2d, 0x0b, 0x51, 0x74, 0x64,
-	0x27, 0xef, 0x44, 0x4b, 0xef, 0xc3, 0xd8, 0xdf, 0x64, 0xf8, 0x08, 0x79, 0x70, 0x23, 0x23, 0x78,
-	0x09, 0x20, 0x3e, 0x37, 0xa2, 0xd4, 0xb2, 0x2a, 0x35, 0x8b, 0x27, 0xa3, 0xe9, 0x78, 0x9e, 0xcd,
-	0xdc, 0x9a, 0x76, 0xa9, 0xd9, 0x79, 0x27, 0x38, 0x2f, 0x1b, 0xd5, 0x16, 0x3d, 0x47, 0xfa, 0x02,
-	0x4e, 0xfe, 0xa3, 0xe9, 0x29, 0x8c, 0xcc, 0x5b, 0x76, 0x7f, 0x73, 0xa4, 0xb7, 0x20, 0xdc, 0x2d,
-	0x37, 0x5b, 0x81, 0xeb, 0x27, 0x85, 0x05, 0x67, 0xc1, 0x73, 0x92, 0x7f, 0x27, 0x10, 0xbe, 0x56,
-	0xd5, 0xb6, 0x36, 0xae, 0xb5, 0xe4, 0xde, 0xb5, 0x96, 0xdc, 0xb8, 0x36, 0xcb, 0x56, 0x28, 0x74,
-	0xc5, 0x85, 0x05, 0x46, 0xa7, 0xaa, 0x4f, 0x58, 0x59, 0x5c, 0x98, 0xa3, 0xe9, 0x5c, 0x63, 0x50,
-	0xcd, 0x0e, 0x26, 0x23, 0xd3, 0xb9, 0x83, 0x34, 0x87, 0xa4, 0x56, 0x82, 0x8b, 0x95, 0xd0, 0xba,
-	0x52, 0x9a, 0x85, 0x48, 0x0f, 0x66, 0x34, 0x03, 0xd0, 0xdb, 0x95, 0x57, 0x44, 0xa8, 0xe8, 0x4d,
-	0xf2, 0x33, 0x08, 0xdf, 0xe3, 0xc3, 0x77, 0xe0, 0x10, 0x13, 0x2c, 0x5c, 0xca, 0xb8, 0x88, 0x11,
-	0x5f, 0x70, 0x7a, 0x1b, 0xa2, 0xb5, 0x59, 0x42, 0xb3, 0x00, 0xfd, 0x0e, 0xe5, 0x5f, 0x09, 0xc4,
-	0x97, 0x2e, 0xcb, 0xb3, 0x7f, 0x29, 0x09, 0xb6, 0x7c, 0x77, 0xd8, 0xb2, 0xf6, 0x5f, 0xdb, 0xb1,
-	0x17, 0xa7, 0x6f, 0x21, 0xe9, 0x13, 0xd7, 0xb4, 0xfb, 0xb0, 0xdf, 0xee, 0x78, 0x7e, 0x3c, 0xbc,
-	0xb7, 0xdf, 0xf6, 0x17, 0x02, 0x11, 0xb6, 0xad, 0xe9, 0xbc, 0x8b, 0x6c, 0xd3, 0xa4, 0xde, 0x65,
-	0x79, 0xf7, 0xb1, 0x59, 0x9c, 0x32, 0x7d, 0x03, 0xe3, 0xde, 0xf8, 0x9a, 0x24, 0x0f, 0x86, 0x49,
-	0x6e, 0x0e, 0xee, 0xec, 0x07, 0x79, 0x02, 0x11, 0x96, 0xaa, 0xe9, 0x23, 0x88, 0xb0, 0x45, 0x9f,
-	0xa3, 0xf3, 0x20, 0x5f, 0x38, 0xf2, 0xd5, 0xe9, 0xcf, 0x7d, 0x46, 0x7e, 0xed, 0x33, 0xf2, 0x7b,
-	0x9f, 0x91, 0x6f, 0x7f, 0xb2, 0x1b, 0x57, 0x11, 0xfe, 0x83, 0x4f, 0xff, 0x06, 0x00, 0x00, 0xff,
-	0xff, 0xcc, 0xd4, 0x6c, 0xd0, 0x93, 0x03, 0x00, 0x00,
+	// 536 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0xd1, 0x8a, 0xd3, 0x40,
+	0x14, 0x75, 0xda, 0x6d, 0xd2, 0xbd, 0xcd, 0xb6, 0x65, 0x10, 0x19, 0x83, 0xc6, 0x52, 0x57, 0xa9,
+	0x2f, 0x15, 0x2a, 0x88, 0x2c, 0xe8, 0x83, 0xb0, 0xe8, 0xaa, 0x0f, 0x92, 0xfd, 0x80, 0xd2, 0x76,
+	0xc6, 0x3a, 0xb4, 0xdb, 0x84, 0x99, 0xa4, 0x9a, 0x1f, 0xd8, 0x6f, 0xf0, 0x17, 0xfc, 0x13, 0x5f,
+	0x04, 0x3f, 0x41, 0xea, 0x8f, 0xc8, 0xdc, 0x99, 0xc4, 0x44, 0xf6, 0x29, 0x39, 0xf7, 0x9e, 0x93,
+	0x7b, 0xee, 0x3d, 0x10, 0x08, 0x56, 0xc9, 0xee, 0x93, 0x5c, 0x4f, 0x53, 0x95, 0x64, 0x09, 0xf5,
+	0x2c, 0x1a, 0x5f, 0xb7, 0xc1, 0xbb, 0x14, 0x6a, 0x2f, 0x14, 0x65, 0xe0, 0x2f, 0x38, 0x57, 0x42,
+	0x6b, 0x46, 0x46, 0x64, 0x72, 0x1c, 0x97, 0x90, 0xf6, 0xa1, 0x25, 0x39, 0x6b, 0x61, 0xb1, 0x25,
+	0x39, 0x0d, 0xa1, 0x2b, 0xb9, 0xd8, 0x65, 0x32, 0x2b, 0x58, 0x7b, 0x44, 0x26, 0x41, 0x5c, 0x61,
+	0xfa, 0x04, 0x86, 0xa9, 0x92, 0xfb, 0x45, 0x26, 0xe6, 0x15, 0xe7, 0x08, 0x39, 0x03, 0x57, 0xbf,
+	0x28, 0xa9, 0xf7, 0x01, 0xd2, 0x7c, 0xb9, 0x95, 0xab, 0xf9, 0x46, 0x14, 0xac, 0x83, 0xa4, 0x63,
+	0x5b, 0x79, 0x2f, 0x0a, 0xfa, 0x00, 0x7a, 0xe5, 0x97, 0x4c, 0xdf, 0xc3, 0x3e, 0xb8, 0x92, 0x21,
+	0xbc, 0x02, 0x10, 0x5f, 0x33, 0xb1, 0xd3, 0x32, 0xd9, 0x69, 0xe6, 0x8f, 0xda, 0x93, 0xde, 0x2c,
+	0x9a, 0xba, 0x35, 0xed, 0x52, 0xd3, 0xf3, 0x8a, 0x70, 0xbe, 0xcb, 0x54, 0x11, 0xd7, 0x14, 0xf4,
+	0x14, 0xfa, 0x1b, 0x71, 0x35, 0xaf, 0x79, 0xe8, 0xe2, 0x8c, 0x60, 0x23, 0xae, 0x3e, 0x56, 0x36,
+	0x1e, 0xc3, 0x00, 0x59, 0x35, 0x2b, 0xc7, 0x48, 0x3b, 0x31, 0xb4, 0xca, 0x4d, 0xf8, 0x12, 0x06,
+	0xff, 0x0d, 0xa3, 0x43, 0x68, 0x1b, 0xba, 0xbd, 0xa6, 0x79, 0xa5, 0xb7, 0xa1, 0xb3, 0x5f, 0x6c,
+	0x73, 0x81, 0xc7, 0x0c, 0x62, 0x0b, 0xce, 0x5a, 0x2f, 0xc8, 0xf8, 0x27, 0x81, 0xce, 0x1b, 0x95,
+	0xe4, 0xa9, 0x51, 0xad, 0x25, 0x2f, 0x55, 0x6b, 0xc9, 0x8d, 0x6a, 0xbb, 0x28, 0x84, 0x42, 0x95,
+	0x1f, 0x5b, 0x60, 0x78, 0x2a, 0xf9, 0x82, 0x01, 0xf8, 0xb1, 0x79, 0x35, 0x09, 0x6a, 0x5c, 0x5b,
+	0xb3, 0xa3, 0x51, 0xdb, 0x24, 0xe8, 0x20, 0x1d, 0x43, 0x90, 0x2a, 0xc1, 0xc5, 0x4a, 0x68, 0x9d,
+	0x28, 0xcd, 0x3a, 0xd8, 0x6e, 0xd4, 0x68, 0x04, 0xa0, 0xf3, 0x55, 0xc9, 0xf0, 0x90, 0x51, 0xab,
+	0x18, 0x17, 0x3a, 0x97, 0x99, 0x60, 0x3e, 0x3a, 0xb3, 0x80, 0xde, 0x01, 0xef, 0x73, 0xb1, 0x54,
+	0x92, 0xe3, 0xf1, 0xba, 0xb1, 0x43, 0xe3, 0x33, 0xe8, 0x7c, 0x40, 0x9b, 0x77, 0xa1, 0x8b, 0x7e,
+	0xe7, 0x6e, 0x27, 0x3f, 0xf6, 0x11, 0x5f, 0x70, 0xa3, 0x5d, 0x9b, 0x95, 0x35, 0x6b, 0xe1, 0x34,
+	0x87, 0xc6, 0xdf, 0x09, 0xf8, 0x97, 0xce, 0xf9, 0xf3, 0x7f, 0x3b, 0x11, 0x4c, 0xf8, 0x5e, 0x33,
+	0x61, 0x5d, 0x3e, 0x6d, 0xbe, 0xd5, 0xc6, 0x21, 0x74, 0x95, 0x48, 0xb7, 0x72, 0xb5, 0xd0, 0xee,
+	0x6c, 0x15, 0x0e, 0xdf, 0x41, 0x50, 0x17, 0xdd, 0x90, 0xd3, 0x69, 0x3d, 0xa7, 0xde, 0xac, 0xdf,
+	0x9c, 0x59, 0xcf, 0xed, 0x9a, 0x80, 0x87, 0xb9, 0x69, 0x3a, 0xab, 0xd6, 0xb1, 0x4e, 0xc3, 0x52,
+	0x65, 0xfb, 0xee, 0x61, 0x7d, 0x3a, 0x66, 0xf8, 0x16, 0x7a, 0xb5, 0xf2, 0x0d, 0x4e, 0x1e, 0x36,
+	0x9d, 0x9c, 0x34, 0xbe, 0x59, 0x37, 0xf2, 0x14, 0x3c, 0x3c, 0xb8, 0xa6, 0x8f, 0xc0, 0xc3, 0x0b,
+	0x97, 0x3e, 0x2a, 0x0d, 0xf6, 0x63, 0xd7, 0x7c, 0x3d, 0xfc, 0x71, 0x88, 0xc8, 0xaf, 0x43, 0x44,
+	0x7e, 0x1f, 0x22, 0xf2, 0xed, 0x4f, 0x74, 0x6b, 0xe9, 0xe1, 0xbf, 0xe1, 0xd9, 0xdf, 0x01, 0x00,
+	0x75, 0x21, 0x6a, 0x02, 0x2b, 0x04, 0x00, 0x00,
 }
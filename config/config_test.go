@@ -67,3 +67,33 @@ func TestMarshaling(t *testing.T) {
 	}
 
 }
+
+func TestMarshalMailboxes(t *testing.T) {
+	mailboxes := createServers(3)
+
+	buf := new(bytes.Buffer)
+	err := MarshalMailboxes(buf, mailboxes, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mres, replicas, err := UnmarshalMailboxes(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mres) != len(mailboxes) || replicas != 2 {
+		t.Fatal("Wrong mailbox config")
+	}
+
+	// plain server configs have a single replica
+	err = MarshalServers(buf, mailboxes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, replicas, err = UnmarshalMailboxes(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replicas != 1 {
+		t.Fatal("Wrong default replicas")
+	}
+}
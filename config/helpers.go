@@ -9,6 +9,12 @@ import (
 )
 
 func DialServers(servers map[string]*Server) (map[string]*grpc.ClientConn, error) {
+	return DialServersWithCertificate(servers, nil)
+}
+
+// DialServersWithCertificate is DialServers, authenticating with cert
+// if it is not nil.
+func DialServersWithCertificate(servers map[string]*Server, cert *tls.Certificate) (map[string]*grpc.ClientConn, error) {
 	conns := make(map[string]*grpc.ClientConn)
 
 	for _, cfg := range servers {
@@ -22,6 +28,12 @@ func DialServers(servers map[string]*Server) (map[string]*grpc.ClientConn, error
 			panic("Could not create cert pool for TLS connection")
 		}
 		creds := credentials.NewClientTLSFromCert(pool, "")
+		if cert != nil {
+			creds = credentials.NewTLS(&tls.Config{
+				RootCAs:      pool,
+				Certificates: []tls.Certificate{*cert},
+			})
+		}
 
 		opts := []grpc.DialOption{
 			grpc.WithTransportCredentials(creds),
@@ -79,3 +91,11 @@ func GroupToKeys(servers map[string]*Server, group *Group) [][]byte {
 	}
 	return keys
 }
+
+func GroupToKEMKeys(servers map[string]*Server, group *Group) [][]byte {
+	keys := make([][]byte, len(group.Servers))
+	for i, sid := range group.Servers {
+		keys[i] = servers[sid].KemPublicKey
+	}
+	return keys
+}
@@ -1,13 +1,12 @@
-package client
+package config
 
 import (
 	"sort"
 
-	"github.com/kwonalbert/xrd/config"
 	"github.com/willauld/lpsimplex"
 )
 
-func findOptimalAssignment(groups map[string]*config.Group, assignments [][]*config.Group) ([]float64, float64) {
+func findOptimalAssignment(groups map[string]*Group, assignments [][]*Group) ([]float64, float64) {
 	l := len(assignments)
 	n := len(groups)
 
@@ -46,6 +46,52 @@ func UnmarshalServersFromFile(fn string) (map[string]*Server, error) {
 	return UnmarshalServers(file)
 }
 
+// MarshalMailboxes is MarshalServers, with the number of mailboxes
+// that hold each inbox.
+func MarshalMailboxes(writer io.Writer, mailboxes map[string]*Server, replicas int) error {
+	sp := &Servers{
+		Servers:  mailboxes,
+		Replicas: uint32(replicas),
+	}
+
+	return proto.MarshalText(writer, sp)
+}
+
+func MarshalMailboxesToFile(fn string, mailboxes map[string]*Server, replicas int) error {
+	file, err := os.Create(fn)
+	if err != nil {
+		log.Fatal("Could not create file")
+	}
+	return MarshalMailboxes(file, mailboxes, replicas)
+}
+
+// UnmarshalMailboxes returns the mailboxes and the number of replicas
+// of each inbox, which is 1 if not set.
+func UnmarshalMailboxes(reader io.Reader) (map[string]*Server, int, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	text := string(b)
+
+	sp := &Servers{}
+	err = proto.UnmarshalText(text, sp)
+
+	replicas := int(sp.Replicas)
+	if replicas == 0 {
+		replicas = 1
+	}
+	return sp.Servers, replicas, err
+}
+
+func UnmarshalMailboxesFromFile(fn string) (map[string]*Server, int, error) {
+	file, err := os.Open(fn)
+	if err != nil {
+		log.Fatal("Could not open mailbox file")
+	}
+	return UnmarshalMailboxes(file)
+}
+
 func MarshalGroups(writer io.Writer, groups map[string]*Group) error {
 	sp := &Groups{
 		Groups: groups,
@@ -4,14 +4,20 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
+	"errors"
 	"fmt"
 	"log"
 	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kwonalbert/xrd/client"
 	"github.com/kwonalbert/xrd/config"
 	"github.com/kwonalbert/xrd/mailbox"
+	"github.com/kwonalbert/xrd/mixnet"
 	"github.com/kwonalbert/xrd/server"
 	"github.com/kwonalbert/xrd/span"
 	"golang.org/x/net/context"
@@ -22,6 +28,11 @@ import (
 type Coordinator interface {
 	PublicKey() ecdsa.PublicKey
 	NewRound(round, numUsers int) error
+	// NewDialRound starts a round in which users send invitations to
+	// start conversations instead of conversation messages. Dial rounds
+	// go through the same chains as the other rounds, but should be
+	// less frequent.
+	NewDialRound(round, numUsers int) error
 	GenerateMessages(round, msgSize int) error
 	SubmitMessages(round int) error
 	StartExperiment(round int) error
@@ -34,13 +45,26 @@ type coordinator struct {
 	clients   map[string]*config.Server
 	servers   map[string]*config.Server
 	groups    map[string]*config.Group
+
+	// how long mailboxes wait for mails after a round starts (0 for forever)
+	deliveryTimeout time.Duration
+
+	// key of the submission tokens, nil if users are not checked to
+	// submit through all of their chains (see mixnet/token.go)
+	tokenKey *rsa.PrivateKey
 }
 
-func NewCoordinator(mailboxes, clients, servers map[string]*config.Server, groups map[string]*config.Group) Coordinator {
+// enforce: whether to check that every user submits through all of its
+// chains, which needs the mailboxes to know the chains
+func NewCoordinator(mailboxes, clients, servers map[string]*config.Server, groups map[string]*config.Group, deliveryTimeout time.Duration, enforce bool) Coordinator {
 	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		panic("Could not generate ecdsa key")
 	}
+	var tokenKey *rsa.PrivateKey
+	if enforce {
+		tokenKey = mixnet.GenerateTokenKey()
+	}
 
 	c := &coordinator{
 		key: key,
@@ -49,6 +73,10 @@ func NewCoordinator(mailboxes, clients, servers map[string]*config.Server, group
 		clients:   clients,
 		servers:   servers,
 		groups:    groups,
+
+		deliveryTimeout: deliveryTimeout,
+
+		tokenKey: tokenKey,
 	}
 	return c
 }
@@ -58,6 +86,14 @@ func (coord *coordinator) PublicKey() ecdsa.PublicKey {
 }
 
 func (coord *coordinator) NewRound(round, numUsers int) error {
+	return coord.newRound(round, numUsers, false)
+}
+
+func (coord *coordinator) NewDialRound(round, numUsers int) error {
+	return coord.newRound(round, numUsers, true)
+}
+
+func (coord *coordinator) newRound(round, numUsers int, dial bool) error {
 	mconss, err := config.DialServers(coord.mailboxes)
 	if err != nil {
 		log.Println("Could not dial mailbox")
@@ -78,6 +114,13 @@ func (coord *coordinator) NewRound(round, numUsers int) error {
 	defer config.CloseConns(cconss)
 	defer config.CloseConns(sconss)
 
+	// the mailboxes sign the tokens, the others check them
+	var tokenKey, tokenPublicKey []byte
+	if coord.tokenKey != nil {
+		tokenKey = mixnet.MarshalTokenKey(coord.tokenKey)
+		tokenPublicKey = mixnet.MarshalTokenPublicKey(&coord.tokenKey.PublicKey)
+	}
+
 	for id, cfg := range coord.mailboxes {
 		rpc := mailbox.NewMailboxClient(mconss[cfg.Address])
 		md := metadata.Pairs(
@@ -85,7 +128,9 @@ func (coord *coordinator) NewRound(round, numUsers int) error {
 		)
 		ctx := metadata.NewOutgoingContext(context.Background(), md)
 		_, err := rpc.NewRound(ctx, &mailbox.NewRoundRequest{
-			Round: uint64(round),
+			Round:    uint64(round),
+			Timeout:  uint64(coord.deliveryTimeout),
+			TokenKey: tokenKey,
 		})
 		if err != nil {
 			log.Println("Mailbox failed to start a new round")
@@ -103,6 +148,8 @@ func (coord *coordinator) NewRound(round, numUsers int) error {
 			_, err := rpc.RegisterUsers(context.Background(), &client.RegisterUsersRequest{
 				Round:    uint64(round),
 				NumUsers: uint64(spans[i].End - spans[i].Start),
+				Dial:     dial,
+				TokenKey: tokenPublicKey,
 			})
 			errs <- err
 		}(i, cfg)
@@ -118,13 +165,21 @@ func (coord *coordinator) NewRound(round, numUsers int) error {
 
 	log.Println("Users registered")
 
+	// the servers check the suites of their chains against these
+	suites := make(map[string]string)
+	for gid, group := range coord.groups {
+		suites[gid] = group.Suite
+	}
+
 	// there should only be one server per address,
 	// so loop through sconss rather than coord.servers
 	for _, cc := range sconss {
 		go func(cc *grpc.ClientConn) {
 			rpc := server.NewXRDClient(cc)
 			_, err := rpc.NewRound(context.Background(), &server.NewRoundRequest{
-				Round: uint64(round),
+				Round:    uint64(round),
+				TokenKey: tokenPublicKey,
+				Suites:   suites,
 			})
 			if err != nil {
 				log.Println("Server failed to start a new round:", err)
@@ -235,6 +290,9 @@ func (coord *coordinator) StartExperiment(round int) error {
 
 	start := time.Now()
 
+	var dropped int32
+	var cmu sync.Mutex
+	checks := make(map[string]*client.ChainCheck)
 	errs := make(chan error, len(coord.clients)+len(sconss))
 	for _, cc := range sconss {
 		go func(cc *grpc.ClientConn) {
@@ -252,11 +310,20 @@ func (coord *coordinator) StartExperiment(round int) error {
 	for _, cfg := range coord.clients {
 		go func(cfg *config.Server) {
 			rpc := client.NewClientClient(cconss[cfg.Address])
-			_, err := rpc.DownloadMessages(context.Background(), &client.DownloadMessagesRequest{
+			resp, err := rpc.DownloadMessages(context.Background(), &client.DownloadMessagesRequest{
 				Round: uint64(round),
 			})
 			if err != nil {
 				log.Println("Could not dial client for download")
+			} else if resp.Received < resp.Expected {
+				log.Printf("Client %s received %d/%d msgs, %d users incomplete",
+					cfg.Address, resp.Received, resp.Expected, resp.Incomplete)
+				atomic.StoreInt32(&dropped, 1)
+			}
+			if err == nil {
+				cmu.Lock()
+				mergeChecks(checks, resp.Chains)
+				cmu.Unlock()
 			}
 			errs <- err
 		}(cfg)
@@ -272,6 +339,15 @@ func (coord *coordinator) StartExperiment(round int) error {
 	fmt.Println("Experiment took:", time.Since(start))
 	debug.FreeOSMemory()
 
+	failed := reportChecks(round, checks)
+	if atomic.LoadInt32(&dropped) == 1 || failed {
+		coord.reportChains(round)
+	}
+	violated := false
+	if coord.tokenKey != nil {
+		violated = coord.checkTokens(round, sconss)
+	}
+
 	for _, cc := range sconss {
 		go func(cc *grpc.ClientConn) {
 			rpc := server.NewXRDClient(cc)
@@ -289,5 +365,164 @@ func (coord *coordinator) StartExperiment(round int) error {
 		}
 	}
 
+	if failed {
+		return errors.New("Mails of the simulated users were lost or corrupted")
+	}
+	if violated {
+		return errors.New("Users did not submit through all of their chains")
+	}
 	return nil
 }
+
+// mergeChecks adds the delivery checks of a client to the ones by gid.
+func mergeChecks(checks map[string]*client.ChainCheck, add []*client.ChainCheck) {
+	for _, c := range add {
+		check, ok := checks[c.Gid]
+		if !ok {
+			check = &client.ChainCheck{Gid: c.Gid}
+			checks[c.Gid] = check
+		}
+		check.Expected += c.Expected
+		check.Delivered += c.Delivered
+		check.Missing += c.Missing
+		check.Corrupted += c.Corrupted
+	}
+}
+
+// reportChecks logs the correctness of the deliveries of the round by
+// chain, and returns whether any mail was lost or corrupted.
+func reportChecks(round int, checks map[string]*client.ChainCheck) bool {
+	gids := make([]string, 0, len(checks))
+	for gid := range checks {
+		gids = append(gids, gid)
+	}
+	sort.Strings(gids)
+
+	failed := false
+	var expected, delivered uint64
+	for _, gid := range gids {
+		check := checks[gid]
+		expected += check.Expected
+		delivered += check.Delivered
+		if check.Missing == 0 && check.Corrupted == 0 {
+			continue
+		}
+		failed = true
+		if gid == "" {
+			log.Printf("Round %d: %d mails could not be traced to a chain", round, check.Corrupted)
+			continue
+		}
+		log.Printf("Round %d: chain %s delivered %d/%d, %d missing, %d corrupted",
+			round, gid, check.Delivered, check.Expected, check.Missing, check.Corrupted)
+	}
+	if len(gids) > 0 {
+		log.Printf("Round %d: %d/%d mails of the simulated users checked correct", round, delivered, expected)
+	}
+	return failed
+}
+
+// checkTokens compares the tokens issued by the mailboxes for each
+// chain with the ones spent at its first server, logs the chains that
+// differ, and returns whether there were any. A user that skipped a
+// chain leaves a token unspent, and a ciphertext with no valid token
+// was submitted by no one entitled to it. Neither tells which user it
+// was.
+func (coord *coordinator) checkTokens(round int, sconss map[string]*grpc.ClientConn) bool {
+	issued := make(map[string]uint64)
+	redeemed := make(map[string]*mixnet.TokenRedemption)
+
+	mconss, err := config.DialServers(coord.mailboxes)
+	if err != nil {
+		log.Println("Could not dial mailbox")
+		return true
+	}
+	defer config.CloseConns(mconss)
+
+	for id, cfg := range coord.mailboxes {
+		rpc := mailbox.NewMailboxClient(mconss[cfg.Address])
+		resp, err := rpc.IssuedTokens(context.Background(), &mailbox.IssuedTokensRequest{
+			Round: uint64(round),
+		})
+		if err != nil {
+			log.Println("Could not get issued tokens from", id, err)
+			return true
+		}
+		for _, chain := range resp.Chains {
+			issued[chain.Gid] += chain.Issued
+		}
+	}
+
+	for addr, cc := range sconss {
+		rpc := mixnet.NewMixClient(cc)
+		resp, err := rpc.TokenReport(context.Background(), &mixnet.TokenReportRequest{
+			Round: uint64(round),
+		})
+		if err != nil {
+			log.Println("Could not get spent tokens from", addr, err)
+			return true
+		}
+		for _, chain := range resp.Chains {
+			redeemed[chain.Gid] = chain
+		}
+	}
+
+	gids := make([]string, 0, len(coord.groups))
+	for gid := range coord.groups {
+		gids = append(gids, gid)
+	}
+	sort.Strings(gids)
+
+	violated := false
+	for _, gid := range gids {
+		chain, ok := redeemed[gid]
+		if !ok {
+			chain = &mixnet.TokenRedemption{Gid: gid}
+		}
+		if chain.Redeemed == issued[gid] && chain.Invalid == 0 {
+			continue
+		}
+		violated = true
+		log.Printf("Round %d: chain %s got %d/%d tokens issued, and %d ciphertexts without a valid token",
+			round, gid, chain.Redeemed, issued[gid], chain.Invalid)
+	}
+	return violated
+}
+
+// reportChains logs the chains that did not deliver to all their users,
+// and the deliveries each mailbox rejected.
+func (coord *coordinator) reportChains(round int) {
+	mconss, err := config.DialServers(coord.mailboxes)
+	if err != nil {
+		log.Println("Could not dial mailbox")
+		return
+	}
+	defer config.CloseConns(mconss)
+
+	for id, cfg := range coord.mailboxes {
+		rpc := mailbox.NewMailboxClient(mconss[cfg.Address])
+		resp, err := rpc.ChainReport(context.Background(), &mailbox.ChainReportRequest{
+			Round:          uint64(round),
+			UnderDelivered: true,
+		})
+		if err != nil {
+			log.Println("Could not get chain report from", id, err)
+			continue
+		}
+		for _, chain := range resp.Chains {
+			log.Printf("Chain %s (last servers %v) missed %d/%d users at %s",
+				chain.Gid, chain.Servers, chain.Missing, chain.Expected, id)
+		}
+
+		aresp, err := rpc.GetAnomalies(context.Background(), &mailbox.GetAnomaliesRequest{
+			Round: uint64(round),
+		})
+		if err != nil {
+			log.Println("Could not get anomalies from", id, err)
+			continue
+		}
+		for _, anomaly := range aresp.Anomalies {
+			log.Printf("Mailbox %s rejected %d mails from chain %s (%s): %s",
+				id, anomaly.Mails, anomaly.Gid, anomaly.Server, anomaly.Reason)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 module github.com/kwonalbert/xrd
 
-go 1.14
+// go 1.24 for crypto/mlkem (hybrid onion layers), and because
+// filippo.io/nistec, filippo.io/edwards25519 and golang.org/x/sys
+// all require it
+go 1.24.0
 
 require (
+	filippo.io/edwards25519 v1.2.0
+	filippo.io/nistec v0.0.4
 	github.com/gogo/protobuf v1.3.1
 	github.com/golang/protobuf v1.4.2
 	github.com/willauld/lpsimplex v0.4.11
@@ -10,3 +15,10 @@ require (
 	golang.org/x/net v0.0.0-20200602114024-627f9648deb9
 	google.golang.org/grpc v1.29.1
 )
+
+require (
+	golang.org/x/sys v0.36.0 // indirect
+	golang.org/x/text v0.3.0 // indirect
+	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55 // indirect
+	google.golang.org/protobuf v1.23.0 // indirect
+)
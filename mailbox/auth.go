@@ -0,0 +1,156 @@
+package mailbox
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+// Proof of ownership for mailbox reads. User keys are X25519 (nacl/box)
+// keys, so they are used to sign directly as in XEdDSA: the X25519
+// private key is used as an Ed25519 scalar, negated if needed so that
+// the Edwards public key has a zero sign bit, which makes the Edwards
+// key recoverable from the X25519 public key. The signed message binds
+// the per round challenge of the mailbox, the round, and the user key,
+// so a proof cannot be replayed to another mailbox or round.
+//
+// Proofs are Schnorr signatures (R, s), so a batch of n proofs is
+// checked with a single multi-scalar multiplication of size 2n+1.
+
+const PROOF_SIZE = 64
+const CHALLENGE_SIZE = 32
+
+const ownershipLabel = "xrd mailbox read"
+
+var errNoChallenge = errors.New("No challenge for the round")
+
+func ownershipMessage(key *[32]byte, round uint64, challenge []byte) []byte {
+	msg := make([]byte, len(ownershipLabel)+8+len(challenge)+32)
+	n := copy(msg, ownershipLabel)
+	binary.BigEndian.PutUint64(msg[n:], round)
+	n += 8
+	n += copy(msg[n:], challenge)
+	copy(msg[n:], key[:])
+	return msg
+}
+
+// ownershipHash returns H(R || A || msg) as a scalar.
+func ownershipHash(R, A, msg []byte) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write(R)
+	h.Write(A)
+	h.Write(msg)
+	s, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// edwardsKey converts an X25519 public key to the Edwards point with a
+// zero sign bit, y = (u - 1) / (u + 1).
+func edwardsKey(key *[32]byte) (*edwards25519.Point, error) {
+	u, err := new(field.Element).SetBytes(key[:])
+	if err != nil {
+		return nil, err
+	}
+	one := new(field.Element).One()
+	den := new(field.Element).Add(u, one)
+	if den.Equal(new(field.Element).Zero()) == 1 {
+		return nil, errors.New("Invalid user key")
+	}
+	y := new(field.Element).Subtract(u, one)
+	y.Multiply(y, new(field.Element).Invert(den))
+	return new(edwards25519.Point).SetBytes(y.Bytes())
+}
+
+// ProveOwnership signs the challenge of a mailbox for round with the
+// private key of a user.
+func ProveOwnership(publicKey, privateKey *[32]byte, round uint64, challenge []byte) []byte {
+	a, err := edwards25519.NewScalar().SetBytesWithClamping(privateKey[:])
+	if err != nil {
+		panic(err)
+	}
+	A := new(edwards25519.Point).ScalarBaseMult(a)
+	Ab := A.Bytes()
+	if Ab[31]>>7 == 1 {
+		a.Negate(a)
+		Ab = A.Negate(A).Bytes()
+	}
+
+	msg := ownershipMessage(publicKey, round, challenge)
+
+	var z [64]byte
+	rand.Read(z[:])
+	h := sha512.New()
+	h.Write(a.Bytes())
+	h.Write(msg)
+	h.Write(z[:])
+	r, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	R := new(edwards25519.Point).ScalarBaseMult(r).Bytes()
+
+	c := ownershipHash(R, Ab, msg)
+	s := edwards25519.NewScalar().MultiplyAdd(c, a, r)
+
+	return append(R, s.Bytes()...)
+}
+
+// VerifyOwnership checks all the proofs at once. It only tells whether
+// all of them are valid, so on failure the caller should fall back to
+// checking smaller batches if it needs to know which ones are bad.
+func VerifyOwnership(keys []*[32]byte, round uint64, challenge []byte, proofs [][]byte) bool {
+	if len(keys) != len(proofs) {
+		return false
+	}
+	if len(keys) == 0 {
+		return true
+	}
+
+	// sum z_i (s_i B - R_i - c_i A_i) = 0, for random 128 bit z_i
+	scalars := make([]*edwards25519.Scalar, 0, 2*len(keys)+1)
+	points := make([]*edwards25519.Point, 0, 2*len(keys)+1)
+	sum := edwards25519.NewScalar()
+	var zb [32]byte
+	for i, key := range keys {
+		if len(proofs[i]) != PROOF_SIZE {
+			return false
+		}
+		A, err := edwardsKey(key)
+		if err != nil {
+			return false
+		}
+		R, err := new(edwards25519.Point).SetBytes(proofs[i][:32])
+		if err != nil {
+			return false
+		}
+		s, err := edwards25519.NewScalar().SetCanonicalBytes(proofs[i][32:])
+		if err != nil {
+			return false
+		}
+		c := ownershipHash(proofs[i][:32], A.Bytes(), ownershipMessage(key, round, challenge))
+
+		rand.Read(zb[:16])
+		z, err := edwards25519.NewScalar().SetCanonicalBytes(zb[:])
+		if err != nil {
+			panic(err)
+		}
+		sum.MultiplyAdd(z, s, sum)
+
+		scalars = append(scalars, edwards25519.NewScalar().Negate(z),
+			edwards25519.NewScalar().Negate(edwards25519.NewScalar().Multiply(z, c)))
+		points = append(points, R, A)
+	}
+	scalars = append(scalars, sum)
+	points = append(points, edwards25519.NewGeneratorPoint())
+
+	check := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	check.MultByCofactor(check)
+	return check.Equal(edwards25519.NewIdentityPoint()) == 1
+}
@@ -0,0 +1,86 @@
+package mailbox
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func ownershipProofs(n int, round uint64, challenge []byte) ([]*[32]byte, [][]byte) {
+	keys := make([]*[32]byte, n)
+	proofs := make([][]byte, n)
+	for i := range keys {
+		pub, priv, err := box.GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		keys[i] = pub
+		proofs[i] = ProveOwnership(pub, priv, round, challenge)
+	}
+	return keys, proofs
+}
+
+func TestOwnership(t *testing.T) {
+	challenge := make([]byte, CHALLENGE_SIZE)
+	rand.Read(challenge)
+	keys, proofs := ownershipProofs(20, 1, challenge)
+
+	for i := range keys {
+		if !VerifyOwnership(keys[i:i+1], 1, challenge, proofs[i:i+1]) {
+			t.Fatal("Ownership proof failed")
+		}
+	}
+	if !VerifyOwnership(keys, 1, challenge, proofs) {
+		t.Fatal("Batch ownership proof failed")
+	}
+
+	if VerifyOwnership(keys, 2, challenge, proofs) {
+		t.Fatal("Proof replayed to another round")
+	}
+	other := make([]byte, CHALLENGE_SIZE)
+	rand.Read(other)
+	if VerifyOwnership(keys, 1, other, proofs) {
+		t.Fatal("Proof replayed to another challenge")
+	}
+	if VerifyOwnership(keys, 1, challenge, proofs[:len(proofs)-1]) {
+		t.Fatal("Missing proof accepted")
+	}
+
+	swapped := make([][]byte, len(proofs))
+	copy(swapped, proofs)
+	swapped[3], swapped[4] = swapped[4], swapped[3]
+	if VerifyOwnership(keys, 1, challenge, swapped) {
+		t.Fatal("Swapped proofs accepted")
+	}
+
+	// someone without the private key
+	pub, _, _ := box.GenerateKey(rand.Reader)
+	_, priv, _ := box.GenerateKey(rand.Reader)
+	forged := append([][]byte{ProveOwnership(pub, priv, 1, challenge)}, proofs...)
+	if VerifyOwnership(append([]*[32]byte{pub}, keys...), 1, challenge, forged) {
+		t.Fatal("Forged proof accepted")
+	}
+}
+
+func BenchmarkVerifyOwnership(b *testing.B) {
+	challenge := make([]byte, CHALLENGE_SIZE)
+	keys, proofs := ownershipProofs(100, 0, challenge)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range keys {
+			VerifyOwnership(keys[j:j+1], 0, challenge, proofs[j:j+1])
+		}
+	}
+}
+
+func BenchmarkBatchVerifyOwnership(b *testing.B) {
+	challenge := make([]byte, CHALLENGE_SIZE)
+	keys, proofs := ownershipProofs(100, 0, challenge)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyOwnership(keys, 0, challenge, proofs)
+	}
+}
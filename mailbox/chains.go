@@ -0,0 +1,123 @@
+package mailbox
+
+import (
+	"sort"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// Per chain delivery counts. Users register the chains they send
+// through, and every delivery is tagged with the chain it comes out
+// of, so a user missing a mail can be traced to the chain that dropped
+// it. The counts are kept in memory, until the round is pruned.
+
+type chainStats struct {
+	mu sync.Mutex
+	// registered users of each chain, and the mails each got from it
+	users    map[string]map[[32]byte]uint64
+	received map[string]uint64
+	servers  map[string]map[string]bool
+	// users that registered their chains
+	chained map[[32]byte]bool
+}
+
+func newChainStats() *chainStats {
+	return &chainStats{
+		users:    make(map[string]map[[32]byte]uint64),
+		received: make(map[string]uint64),
+		servers:  make(map[string]map[string]bool),
+		chained:  make(map[[32]byte]bool),
+	}
+}
+
+func (stats *chainStats) register(key [32]byte, gids []string) {
+	stats.chained[key] = true
+	for _, gid := range gids {
+		users, ok := stats.users[gid]
+		if !ok {
+			users = make(map[[32]byte]uint64)
+			stats.users[gid] = users
+		}
+		if _, ok := users[key]; !ok {
+			users[key] = 0
+		}
+	}
+}
+
+func (stats *chainStats) deliver(gid, server string, mails []*Mail) {
+	var key [32]byte
+	stats.received[gid] += uint64(len(mails))
+	if _, ok := stats.servers[gid]; !ok {
+		stats.servers[gid] = make(map[string]bool)
+	}
+	stats.servers[gid][server] = true
+	users := stats.users[gid]
+	for _, mail := range mails {
+		copy(key[:], mail.UserKey)
+		if _, ok := users[key]; ok {
+			users[key]++
+		}
+	}
+}
+
+func (stats *chainStats) report(underDelivered bool) []*ChainDelivery {
+	gids := make(map[string]bool)
+	for gid := range stats.users {
+		gids[gid] = true
+	}
+	for gid := range stats.received {
+		gids[gid] = true
+	}
+
+	var chains []*ChainDelivery
+	for gid := range gids {
+		chain := &ChainDelivery{
+			Gid:      gid,
+			Expected: uint64(len(stats.users[gid])),
+			Received: stats.received[gid],
+		}
+		for _, count := range stats.users[gid] {
+			if count == 0 {
+				chain.Missing++
+			}
+		}
+		for server := range stats.servers[gid] {
+			chain.Servers = append(chain.Servers, server)
+		}
+		sort.Strings(chain.Servers)
+		if underDelivered && chain.Missing == 0 {
+			continue
+		}
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].Gid < chains[j].Gid })
+	return chains
+}
+
+// chainStats returns the counts of round, creating them if needed.
+func (mb *mailbox) chainStats(round uint64, create bool) *chainStats {
+	mb.chmu.Lock()
+	defer mb.chmu.Unlock()
+	stats, ok := mb.chains[round]
+	if !ok && create {
+		stats = newChainStats()
+		mb.chains[round] = stats
+	}
+	return stats
+}
+
+func (mb *mailbox) ChainReport(ctx context.Context, in *ChainReportRequest) (*ChainReportResponse, error) {
+	stats := mb.chainStats(in.Round, false)
+	if stats == nil {
+		if _, err := mb.store.Users(in.Round); err != nil {
+			return nil, err
+		}
+		return &ChainReportResponse{}, nil
+	}
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	return &ChainReportResponse{
+		Chains: stats.report(in.UnderDelivered),
+	}, nil
+}
@@ -0,0 +1,130 @@
+package mailbox
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"io"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	grpc "google.golang.org/grpc"
+)
+
+func TestChainReport(t *testing.T) {
+	server := NewMailboxServer(ecdsa.PublicKey{}, NewMemoryStore(), Retention{}, Replication{}, Tokens{})
+
+	go func() {
+		grpcServer := grpc.NewServer()
+		RegisterMailboxServer(grpcServer, server)
+
+		lis, err := net.Listen("tcp", ":8504")
+		if err != nil {
+			log.Fatal("Could not listen:", err)
+		}
+
+		err = grpcServer.Serve(lis)
+		if err != grpc.ErrServerStopped {
+			log.Fatal("Serve err:", err)
+		}
+	}()
+
+	time.Sleep(time.Millisecond)
+
+	cc, err := grpc.Dial("localhost:8504", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	mailbox := NewMailboxClient(cc)
+
+	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{Round: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([][]byte, 3)
+	for i := range keys {
+		keys[i] = make([]byte, 32)
+		rand.Read(keys[i])
+	}
+	stream, err := mailbox.RegisterUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&RegisterUsersRequest{
+		Round:    0,
+		UserKeys: keys,
+		Expected: []uint64{2, 2, 1},
+		Chains: []*Chains{
+			{Gids: []string{"group:0", "group:1"}},
+			{Gids: []string{"group:0", "group:1"}},
+			{Gids: []string{"group:1"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.CloseAndRecv()
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+
+	// group:1 drops the mail of the second user
+	deliveries := []*DeliverMailsRequest{
+		{Round: 0, Gid: "group:0", Server: "server:(0,2)", Mails: []*Mail{
+			{UserKey: keys[0], Message: []byte("a")},
+			{UserKey: keys[1], Message: []byte("b")},
+		}},
+		{Round: 0, Gid: "group:1", Server: "server:(1,2)", Mails: []*Mail{
+			{UserKey: keys[0], Message: []byte("c")},
+			{UserKey: keys[2], Message: []byte("d")},
+		}},
+	}
+	for _, req := range deliveries {
+		out, err := mailbox.DeliverMails(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := out.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		_, err = out.CloseAndRecv()
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := mailbox.ChainReport(context.Background(), &ChainReportRequest{Round: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Chains) != 2 {
+		t.Fatal("Wrong number of chains")
+	}
+	g0, g1 := resp.Chains[0], resp.Chains[1]
+	if g0.Gid != "group:0" || g0.Expected != 2 || g0.Missing != 0 || g0.Received != 2 {
+		t.Fatal("Wrong counts for group:0", g0)
+	}
+	if g1.Gid != "group:1" || g1.Expected != 3 || g1.Missing != 1 || g1.Received != 2 {
+		t.Fatal("Wrong counts for group:1", g1)
+	}
+	if len(g1.Servers) != 1 || g1.Servers[0] != "server:(1,2)" {
+		t.Fatal("Wrong last server", g1.Servers)
+	}
+
+	resp, err = mailbox.ChainReport(context.Background(), &ChainReportRequest{Round: 0, UnderDelivered: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Chains) != 1 || resp.Chains[0].Gid != "group:1" {
+		t.Fatal("Wrong under-delivering chains", resp.Chains)
+	}
+
+	_, err = mailbox.ChainReport(context.Background(), &ChainReportRequest{Round: 5})
+	if err == nil {
+		t.Fatal("Report for a missing round")
+	}
+}
@@ -0,0 +1,427 @@
+package mailbox
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The disk store keeps one directory per round, with three files:
+//   meta:  creation time of the round (8 bytes, unix nanoseconds)
+//   users: registered keys, [key (32)][expected (8)] records
+//   mails: delivered mails, [key (32)][length (4)][message] records
+// Both record files are append only, and synced before Register and
+// Append return. A partially written record at the end of a file
+// (from a crash mid-write) is dropped when the round is loaded.
+// Only the location of each mail is kept in memory.
+
+const (
+	userRecordSize = 32 + 8
+	mailHeaderSize = 32 + 4
+)
+
+type diskStore struct {
+	dir string
+
+	mu     sync.RWMutex
+	rounds map[uint64]*diskRound
+}
+
+type diskRound struct {
+	mu      sync.RWMutex
+	dir     string
+	created time.Time
+	users   *os.File
+	mails   *os.File
+	size    int64 // valid bytes in mails
+	inboxes map[[32]byte]*diskInbox
+}
+
+type diskInbox struct {
+	expected uint64
+	extents  []extent
+}
+
+// location of a message in the mails file
+type extent struct {
+	offset int64
+	length uint32
+}
+
+// NewDiskStore opens (or creates) a store in dir, and loads all the
+// rounds already in it.
+func NewDiskStore(dir string) (Store, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := &diskStore{
+		dir:    dir,
+		rounds: make(map[uint64]*diskRound),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		round, err := strconv.ParseUint(entry.Name(), 10, 64)
+		if !entry.IsDir() || err != nil {
+			continue
+		}
+		rdir := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(rdir, "meta")); os.IsNotExist(err) {
+			// crashed while creating the round, so nothing was delivered
+			os.RemoveAll(rdir)
+			continue
+		}
+		r, err := loadDiskRound(rdir)
+		if err != nil {
+			ds.Close()
+			return nil, fmt.Errorf("Could not load round %d: %v", round, err)
+		}
+		ds.rounds[round] = r
+	}
+	return ds, nil
+}
+
+func roundDir(dir string, round uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d", round))
+}
+
+func openDiskRound(dir string) (*diskRound, error) {
+	users, err := os.OpenFile(filepath.Join(dir, "users"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	mails, err := os.OpenFile(filepath.Join(dir, "mails"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		users.Close()
+		return nil, err
+	}
+	return &diskRound{
+		dir:     dir,
+		users:   users,
+		mails:   mails,
+		inboxes: make(map[[32]byte]*diskInbox),
+	}, nil
+}
+
+func loadDiskRound(dir string) (*diskRound, error) {
+	meta, err := os.ReadFile(filepath.Join(dir, "meta"))
+	if err != nil {
+		return nil, err
+	}
+	if len(meta) != 8 {
+		return nil, errors.New("Invalid round metadata")
+	}
+
+	r, err := openDiskRound(dir)
+	if err != nil {
+		return nil, err
+	}
+	r.created = time.Unix(0, int64(binary.BigEndian.Uint64(meta)))
+
+	users, err := io.ReadAll(r.users)
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	var key [32]byte
+	n := len(users) / userRecordSize * userRecordSize
+	for off := 0; off < n; off += userRecordSize {
+		copy(key[:], users[off:off+32])
+		r.register(key, binary.BigEndian.Uint64(users[off+32:]))
+	}
+	if err := truncateTo(r.users, int64(n), int64(len(users))); err != nil {
+		r.close()
+		return nil, err
+	}
+
+	// only read the headers, the messages stay on disk
+	stat, err := r.mails.Stat()
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	var header [mailHeaderSize]byte
+	for {
+		if r.size+mailHeaderSize > stat.Size() {
+			break
+		}
+		_, err := r.mails.ReadAt(header[:], r.size)
+		if err != nil {
+			r.close()
+			return nil, err
+		}
+		length := binary.BigEndian.Uint32(header[32:])
+		if r.size+mailHeaderSize+int64(length) > stat.Size() {
+			break
+		}
+		copy(key[:], header[:32])
+		inbox, ok := r.inboxes[key]
+		if !ok {
+			r.close()
+			return nil, ErrNotRegistered
+		}
+		inbox.extents = append(inbox.extents, extent{r.size + mailHeaderSize, length})
+		r.size += mailHeaderSize + int64(length)
+	}
+	if err := truncateTo(r.mails, r.size, stat.Size()); err != nil {
+		r.close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// truncateTo drops a partial record at the end of a file.
+func truncateTo(f *os.File, valid, size int64) error {
+	if valid == size {
+		return nil
+	}
+	return f.Truncate(valid)
+}
+
+// register creates an inbox, or updates the number of expected mails
+// of an existing one. The last registration wins, as in the users file.
+func (r *diskRound) register(key [32]byte, expected uint64) {
+	inbox, ok := r.inboxes[key]
+	if !ok {
+		inbox = new(diskInbox)
+		r.inboxes[key] = inbox
+	}
+	inbox.expected = expected
+}
+
+func (r *diskRound) close() error {
+	err := r.users.Close()
+	if merr := r.mails.Close(); err == nil {
+		err = merr
+	}
+	return err
+}
+
+func (ds *diskStore) round(round uint64) (*diskRound, error) {
+	ds.mu.RLock()
+	r, ok := ds.rounds[round]
+	ds.mu.RUnlock()
+	if !ok {
+		return nil, ErrRoundNotFound
+	}
+	return r, nil
+}
+
+func (ds *diskStore) NewRound(round uint64, created time.Time) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if _, ok := ds.rounds[round]; ok {
+		return nil
+	}
+
+	dir := roundDir(ds.dir, round)
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+	r, err := openDiskRound(dir)
+	if err != nil {
+		return err
+	}
+	r.created = created
+
+	// write the metadata last, so a round without one is incomplete
+	var meta [8]byte
+	binary.BigEndian.PutUint64(meta[:], uint64(created.UnixNano()))
+	err = writeFileSync(filepath.Join(dir, "meta"), meta[:])
+	if err != nil {
+		r.close()
+		return err
+	}
+
+	ds.rounds[round] = r
+	return nil
+}
+
+func writeFileSync(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (ds *diskStore) Register(round uint64, keys [][32]byte, expected []uint64) error {
+	r, err := ds.round(round)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, len(keys)*userRecordSize)
+	for i, key := range keys {
+		rec := buf[i*userRecordSize : (i+1)*userRecordSize]
+		copy(rec, key[:])
+		if i < len(expected) {
+			binary.BigEndian.PutUint64(rec[32:], expected[i])
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.users.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	if _, err := r.users.Write(buf); err != nil {
+		return err
+	}
+	if err := r.users.Sync(); err != nil {
+		return err
+	}
+	for i, key := range keys {
+		r.register(key, binary.BigEndian.Uint64(buf[i*userRecordSize+32:]))
+	}
+	return nil
+}
+
+func (ds *diskStore) Append(round uint64, mails []*Mail) error {
+	r, err := ds.round(round)
+	if err != nil {
+		return err
+	}
+
+	size := 0
+	for _, mail := range mails {
+		size += mailHeaderSize + len(mail.Message)
+	}
+	buf := make([]byte, size)
+	off := 0
+	for _, mail := range mails {
+		copy(buf[off:off+32], mail.UserKey)
+		binary.BigEndian.PutUint32(buf[off+32:], uint32(len(mail.Message)))
+		copy(buf[off+mailHeaderSize:], mail.Message)
+		off += mailHeaderSize + len(mail.Message)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var key [32]byte
+	for _, mail := range mails {
+		copy(key[:], mail.UserKey)
+		if _, ok := r.inboxes[key]; !ok {
+			return ErrNotRegistered
+		}
+	}
+
+	if _, err := r.mails.WriteAt(buf, r.size); err != nil {
+		return err
+	}
+	if err := r.mails.Sync(); err != nil {
+		return err
+	}
+
+	off = 0
+	for _, mail := range mails {
+		copy(key[:], mail.UserKey)
+		inbox := r.inboxes[key]
+		inbox.extents = append(inbox.extents, extent{r.size + int64(off) + mailHeaderSize, uint32(len(mail.Message))})
+		off += mailHeaderSize + len(mail.Message)
+	}
+	r.size += int64(size)
+	return nil
+}
+
+func (ds *diskStore) Inbox(round uint64, key [32]byte) ([][]byte, uint64, error) {
+	r, err := ds.round(round)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inbox, ok := r.inboxes[key]
+	if !ok {
+		return nil, 0, ErrNotRegistered
+	}
+	msgs := make([][]byte, len(inbox.extents))
+	for i, ext := range inbox.extents {
+		msgs[i] = make([]byte, ext.length)
+		if _, err := r.mails.ReadAt(msgs[i], ext.offset); err != nil {
+			return nil, 0, err
+		}
+	}
+	return msgs, inbox.expected, nil
+}
+
+func (ds *diskStore) Users(round uint64) ([][32]byte, error) {
+	r, err := ds.round(round)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	keys := make([][32]byte, 0, len(r.inboxes))
+	for key := range r.inboxes {
+		keys = append(keys, key)
+	}
+	r.mu.RUnlock()
+	sortKeys(keys)
+	return keys, nil
+}
+
+func (ds *diskStore) Rounds() ([]RoundInfo, error) {
+	ds.mu.RLock()
+	infos := make([]RoundInfo, 0, len(ds.rounds))
+	for round, r := range ds.rounds {
+		infos = append(infos, RoundInfo{round, r.created})
+	}
+	ds.mu.RUnlock()
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Round < infos[j].Round })
+	return infos, nil
+}
+
+func (ds *diskStore) DeleteRound(round uint64) error {
+	ds.mu.Lock()
+	r, ok := ds.rounds[round]
+	delete(ds.rounds, round)
+	ds.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	// wait for any pending reads and writes
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.close()
+	return os.RemoveAll(r.dir)
+}
+
+func (ds *diskStore) Close() error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	var err error
+	for round, r := range ds.rounds {
+		r.mu.Lock()
+		if cerr := r.close(); err == nil {
+			err = cerr
+		}
+		r.mu.Unlock()
+		delete(ds.rounds, round)
+	}
+	return err
+}
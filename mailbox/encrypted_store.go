@@ -0,0 +1,387 @@
+package mailbox
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// The encrypted store wraps another store, so that what it keeps at
+// rest is useless without a key of the round. Every round gets a fresh
+// random key when it is created:
+//   - user keys are stored permuted under the round key, so an inbox
+//     is only linked to its owner while the round key exists
+//   - mails are sealed under the round key, bound to the round and the
+//     permuted user key
+// Deleting a round destroys its key first, so an expired round is
+// unreadable even if the wrapped store did not erase it completely
+// (or crashed before it could).
+//
+// The keys are kept in a KeyRing, which should be on different storage
+// than the wrapped store. Shredding is only as good as the erasure of
+// the key file: it is replaced by a rename, so the blocks of the old
+// file (with the destroyed keys) stay on disk until the file system
+// reuses them, and journals, snapshots and flash remapping may keep
+// copies regardless. The key file should be on storage that does not
+// keep old data around, such as a tmpfs or a volume whose own key is
+// thrown away. Rounds are only shredded when they expire, so nothing is
+// shredded without a retention limit. Expected counts and creation
+// times are not encrypted.
+
+var ErrKeyNotFound = errors.New("Round key not found")
+
+const roundKeySize = 32
+
+// KeyRing keeps the key of every stored round.
+type KeyRing interface {
+	// Key returns a copy of the key of round. If create is set, a
+	// missing key is created.
+	Key(round uint64, create bool) ([roundKeySize]byte, error)
+	// Destroy erases the key of round.
+	Destroy(round uint64) error
+	Close() error
+}
+
+type keyRing struct {
+	file string // empty if the keys are only in memory
+
+	mu   sync.Mutex
+	keys map[uint64]*[roundKeySize]byte
+}
+
+// The key file is [round (8)][key (32)] records, and is rewritten as a
+// whole (to a temporary file, then renamed) whenever a key changes.
+const keyRecordSize = 8 + roundKeySize
+
+// NewKeyRing opens (or creates) the key ring in file. The keys are
+// only kept in memory if file is empty.
+func NewKeyRing(file string) (KeyRing, error) {
+	kr := &keyRing{
+		file: file,
+		keys: make(map[uint64]*[roundKeySize]byte),
+	}
+	if file == "" {
+		return kr, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return kr, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if len(data)%keyRecordSize != 0 {
+		return nil, errors.New("Invalid key file")
+	}
+	for off := 0; off < len(data); off += keyRecordSize {
+		key := new([roundKeySize]byte)
+		copy(key[:], data[off+8:off+keyRecordSize])
+		kr.keys[binary.BigEndian.Uint64(data[off:])] = key
+	}
+	wipe(data)
+	return kr, nil
+}
+
+func (kr *keyRing) Key(round uint64, create bool) ([roundKeySize]byte, error) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	key, ok := kr.keys[round]
+	if ok {
+		return *key, nil
+	} else if !create {
+		return [roundKeySize]byte{}, ErrKeyNotFound
+	}
+
+	key = new([roundKeySize]byte)
+	if _, err := rand.Read(key[:]); err != nil {
+		return [roundKeySize]byte{}, err
+	}
+	kr.keys[round] = key
+	if err := kr.save(); err != nil {
+		delete(kr.keys, round)
+		return [roundKeySize]byte{}, err
+	}
+	return *key, nil
+}
+
+func (kr *keyRing) Destroy(round uint64) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	key, ok := kr.keys[round]
+	if !ok {
+		return nil
+	}
+	delete(kr.keys, round)
+	if err := kr.save(); err != nil {
+		kr.keys[round] = key
+		return err
+	}
+	wipe(key[:])
+	return nil
+}
+
+// save writes all the keys to the key file. kr.mu must be held.
+func (kr *keyRing) save() error {
+	if kr.file == "" {
+		return nil
+	}
+	rounds := make([]uint64, 0, len(kr.keys))
+	for round := range kr.keys {
+		rounds = append(rounds, round)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] < rounds[j] })
+
+	data := make([]byte, len(rounds)*keyRecordSize)
+	for i, round := range rounds {
+		rec := data[i*keyRecordSize : (i+1)*keyRecordSize]
+		binary.BigEndian.PutUint64(rec, round)
+		copy(rec[8:], kr.keys[round][:])
+	}
+	defer wipe(data)
+
+	tmp := kr.file + ".tmp"
+	if err := writeFileSync(tmp, data); err != nil {
+		return err
+	}
+	return os.Rename(tmp, kr.file)
+}
+
+func (kr *keyRing) Close() error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	for round, key := range kr.keys {
+		wipe(key[:])
+		delete(kr.keys, round)
+	}
+	return nil
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+type encryptedStore struct {
+	store Store
+	keys  KeyRing
+}
+
+// NewEncryptedStore returns a store that encrypts everything it puts
+// in store under keys from keys. Rounds of store without a key (whose
+// deletion was interrupted) are deleted.
+func NewEncryptedStore(store Store, keys KeyRing) (Store, error) {
+	es := &encryptedStore{
+		store: store,
+		keys:  keys,
+	}
+	infos, err := store.Rounds()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if _, err := keys.Key(info.Round, false); err != ErrKeyNotFound {
+			continue
+		}
+		if err := store.DeleteRound(info.Round); err != nil {
+			return nil, err
+		}
+	}
+	return es, nil
+}
+
+// roundCipher holds the keys derived from a round key.
+type roundCipher struct {
+	round uint64
+	users [4][]byte // keys of the rounds of the permutation
+	mails []byte
+}
+
+func (es *encryptedStore) cipher(round uint64) (*roundCipher, error) {
+	key, err := es.keys.Key(round, false)
+	if err == ErrKeyNotFound {
+		return nil, ErrRoundNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer wipe(key[:])
+	c := &roundCipher{round: round}
+	for i := range c.users {
+		c.users[i] = derive(key[:], "users", byte(i))
+	}
+	c.mails = derive(key[:], "mails", 0)
+	return c, nil
+}
+
+func derive(key []byte, label string, i byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(label))
+	mac.Write([]byte{i})
+	return mac.Sum(nil)
+}
+
+// permute is a four round Feistel network over the 32 byte user keys,
+// which makes it a pseudorandom permutation.
+func (c *roundCipher) permute(key [32]byte, inverse bool) [32]byte {
+	l, r := key[:16], key[16:]
+	for i := range c.users {
+		if inverse {
+			k := len(c.users) - 1 - i
+			l, r = xor(r, c.feistel(k, l)), l
+		} else {
+			l, r = r, xor(l, c.feistel(i, r))
+		}
+	}
+	var out [32]byte
+	copy(out[:16], l)
+	copy(out[16:], r)
+	return out
+}
+
+func (c *roundCipher) feistel(i int, half []byte) []byte {
+	mac := hmac.New(sha256.New, c.users[i])
+	mac.Write(half)
+	return mac.Sum(nil)[:16]
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+func (c *roundCipher) ad(pkey [32]byte) []byte {
+	ad := make([]byte, 8+32)
+	binary.BigEndian.PutUint64(ad, c.round)
+	copy(ad[8:], pkey[:])
+	return ad
+}
+
+func (c *roundCipher) seal(pkey [32]byte, msg []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(c.mails)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, aead.NonceSize(), aead.NonceSize()+len(msg)+aead.Overhead())
+	if _, err := rand.Read(out); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, out, msg, c.ad(pkey)), nil
+}
+
+func (c *roundCipher) open(pkey [32]byte, sealed []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(c.mails)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("Invalid stored mail")
+	}
+	nonce := sealed[:aead.NonceSize()]
+	return aead.Open(nil, nonce, sealed[aead.NonceSize():], c.ad(pkey))
+}
+
+func (es *encryptedStore) NewRound(round uint64, created time.Time) error {
+	if _, err := es.keys.Key(round, true); err != nil {
+		return err
+	}
+	return es.store.NewRound(round, created)
+}
+
+func (es *encryptedStore) Register(round uint64, keys [][32]byte, expected []uint64) error {
+	c, err := es.cipher(round)
+	if err != nil {
+		return err
+	}
+	pkeys := make([][32]byte, len(keys))
+	for i := range keys {
+		pkeys[i] = c.permute(keys[i], false)
+	}
+	return es.store.Register(round, pkeys, expected)
+}
+
+func (es *encryptedStore) Append(round uint64, mails []*Mail) error {
+	c, err := es.cipher(round)
+	if err != nil {
+		return err
+	}
+	var key [32]byte
+	sealed := make([]*Mail, len(mails))
+	for i, mail := range mails {
+		copy(key[:], mail.UserKey)
+		pkey := c.permute(key, false)
+		msg, err := c.seal(pkey, mail.Message)
+		if err != nil {
+			return err
+		}
+		sealed[i] = &Mail{UserKey: pkey[:], Message: msg}
+	}
+	return es.store.Append(round, sealed)
+}
+
+func (es *encryptedStore) Inbox(round uint64, key [32]byte) ([][]byte, uint64, error) {
+	c, err := es.cipher(round)
+	if err != nil {
+		return nil, 0, err
+	}
+	pkey := c.permute(key, false)
+	inbox, expected, err := es.store.Inbox(round, pkey)
+	if err != nil {
+		return nil, 0, err
+	}
+	msgs := make([][]byte, len(inbox))
+	for i := range inbox {
+		msgs[i], err = c.open(pkey, inbox[i])
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return msgs, expected, nil
+}
+
+func (es *encryptedStore) Users(round uint64) ([][32]byte, error) {
+	c, err := es.cipher(round)
+	if err != nil {
+		return nil, err
+	}
+	pkeys, err := es.store.Users(round)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][32]byte, len(pkeys))
+	for i := range pkeys {
+		keys[i] = c.permute(pkeys[i], true)
+	}
+	sortKeys(keys)
+	return keys, nil
+}
+
+func (es *encryptedStore) Rounds() ([]RoundInfo, error) {
+	return es.store.Rounds()
+}
+
+// DeleteRound destroys the key of round before deleting its data.
+func (es *encryptedStore) DeleteRound(round uint64) error {
+	if err := es.keys.Destroy(round); err != nil {
+		return err
+	}
+	return es.store.DeleteRound(round)
+}
+
+func (es *encryptedStore) Close() error {
+	err := es.store.Close()
+	if kerr := es.keys.Close(); err == nil {
+		err = kerr
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package mailbox
+
+import (
+	"crypto/sha256"
+	"errors"
+	"log"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Integrity checks on deliveries. Every DeliverMails batch is checked
+// as a whole before anything is stored, and is either stored entirely
+// or rejected entirely:
+//   - every key is registered in the round
+//   - mails are not empty, at most MaxMailSize, and of one size per round
+//   - no user gets more mails than it registered as expected, or as its
+//     capacity if it has one
+//   - a tagged batch only has mails for users on the chain, one each
+//   - no chain delivers the same mail twice in a round (a user may send
+//     the same mail through several chains, so that is not a duplicate)
+// A rejected batch goes into the anomaly log of the round, and the rest
+// of the stream is still processed.
+
+const MaxMailSize = 1 << 16
+
+var (
+	errInvalidKey       = errors.New("Invalid user key")
+	errInvalidMailSize  = errors.New("Invalid mail size")
+	errMailSizeMismatch = errors.New("Mail size differs from the round")
+	errTooManyMails     = errors.New("More mails than expected")
+	errNotOnChain       = errors.New("Mail for a user not on the chain")
+	errChainDuplicate   = errors.New("More than one mail from the chain")
+	errDuplicateMail    = errors.New("Mail delivered twice")
+)
+
+func mailDigest(gid string, mail *Mail) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(gid))
+	h.Write([]byte{0})
+	h.Write(mail.UserKey)
+	h.Write(mail.Message)
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
+
+// checkBatch validates a batch against what the round has so far, and
+// returns the digests of its mails. state.dmu must be held, so that no
+// other batch is accepted in between, and state.mu read locked.
+func (mb *mailbox) checkBatch(state *roundState, req *DeliverMailsRequest) ([][32]byte, error) {
+	var key [32]byte
+	counts := make(map[[32]byte]uint64)
+	digests := make([][32]byte, len(req.Mails))
+	batch := make(map[[32]byte]bool)
+	size := state.mailSize
+	for i, mail := range req.Mails {
+		if len(mail.UserKey) != 32 {
+			return nil, errInvalidKey
+		}
+		if len(mail.Message) == 0 || len(mail.Message) > MaxMailSize {
+			return nil, errInvalidMailSize
+		}
+		if size == 0 {
+			size = len(mail.Message)
+		} else if len(mail.Message) != size {
+			return nil, errMailSizeMismatch
+		}
+
+		copy(key[:], mail.UserKey)
+		expected, ok := state.expected[key]
+		if !ok {
+			return nil, ErrNotRegistered
+		}
+		if capacity, ok := state.capacity[key]; ok {
+			expected = capacity
+		}
+		counts[key]++
+		if state.received[key]+counts[key] > expected {
+			return nil, errTooManyMails
+		}
+
+		digests[i] = mailDigest(req.Gid, mail)
+		if _, ok := state.digests[digests[i]]; ok || batch[digests[i]] {
+			return nil, errDuplicateMail
+		}
+		batch[digests[i]] = true
+	}
+
+	if req.Gid == "" {
+		return digests, nil
+	}
+	stats := mb.chainStats(req.Round, false)
+	if stats == nil {
+		return digests, nil
+	}
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	users := stats.users[req.Gid]
+	for key, count := range counts {
+		if !stats.chained[key] { // the user did not say which chains it uses
+			continue
+		}
+		got, ok := users[key]
+		if !ok {
+			return nil, errNotOnChain
+		}
+		if got+count > 1 {
+			return nil, errChainDuplicate
+		}
+	}
+	return digests, nil
+}
+
+// anomaly records a rejected batch.
+func (mb *mailbox) anomaly(req *DeliverMailsRequest, reason error) {
+	log.Printf("Rejected %d mails of round %d from %s (%s): %v",
+		len(req.Mails), req.Round, req.Gid, req.Server, reason)
+	mb.amu.Lock()
+	defer mb.amu.Unlock()
+	mb.anomalies[req.Round] = append(mb.anomalies[req.Round], &Anomaly{
+		Gid:    req.Gid,
+		Server: req.Server,
+		Reason: reason.Error(),
+		Mails:  uint64(len(req.Mails)),
+		Time:   uint64(time.Now().UnixNano()),
+	})
+}
+
+func (mb *mailbox) GetAnomalies(ctx context.Context, in *GetAnomaliesRequest) (*GetAnomaliesResponse, error) {
+	mb.amu.Lock()
+	anomalies := append([]*Anomaly(nil), mb.anomalies[in.Round]...)
+	mb.amu.Unlock()
+	if len(anomalies) == 0 {
+		if _, err := mb.store.Users(in.Round); err != nil {
+			return nil, err
+		}
+	}
+	return &GetAnomaliesResponse{
+		Anomalies: anomalies,
+	}, nil
+}
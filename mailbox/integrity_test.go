@@ -0,0 +1,154 @@
+package mailbox
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"io"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	grpc "google.golang.org/grpc"
+)
+
+func TestDeliveryIntegrity(t *testing.T) {
+	store := NewMemoryStore()
+	server := NewMailboxServer(ecdsa.PublicKey{}, store, Retention{}, Replication{}, Tokens{})
+
+	go func() {
+		grpcServer := grpc.NewServer()
+		RegisterMailboxServer(grpcServer, server)
+
+		lis, err := net.Listen("tcp", ":8505")
+		if err != nil {
+			log.Fatal("Could not listen:", err)
+		}
+
+		err = grpcServer.Serve(lis)
+		if err != grpc.ErrServerStopped {
+			log.Fatal("Serve err:", err)
+		}
+	}()
+
+	time.Sleep(time.Millisecond)
+
+	cc, err := grpc.Dial("localhost:8505", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	mailbox := NewMailboxClient(cc)
+
+	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{Round: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([][]byte, 4)
+	for i := range keys {
+		keys[i] = make([]byte, 32)
+		rand.Read(keys[i])
+	}
+
+	// a bad registration is refused without taking the mailbox down
+	reg, err := mailbox.RegisterUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg.Send(&RegisterUsersRequest{Round: 0, UserKeys: keys[:2], Expected: []uint64{1}})
+	if _, err = reg.CloseAndRecv(); err == nil {
+		t.Fatal("Registered with missing expected counts")
+	}
+
+	reg, err = mailbox.RegisterUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reg.Send(&RegisterUsersRequest{
+		Round:    0,
+		UserKeys: keys[:2],
+		Expected: []uint64{1, 2},
+		Chains: []*Chains{
+			{Gids: []string{"group:0"}},
+			{Gids: []string{"group:0", "group:1"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// user 3 waits for one mail, but takes two
+	err = reg.Send(&RegisterUsersRequest{
+		Round:    0,
+		UserKeys: keys[3:],
+		Expected: []uint64{1},
+		Capacity: []uint64{2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = reg.CloseAndRecv()
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+
+	mail := func(user int, msg string) *Mail {
+		return &Mail{UserKey: keys[user], Message: []byte(msg)}
+	}
+	batches := []*DeliverMailsRequest{
+		{Gid: "group:0", Mails: []*Mail{mail(0, "mail 0")}},
+		// too many for user 0, so user 1's mail is dropped too
+		{Gid: "group:1", Mails: []*Mail{mail(1, "mail 1"), mail(0, "mail 2")}},
+		{Gid: "group:1", Mails: []*Mail{mail(1, "long mail")}},
+		{Gid: "group:2", Mails: []*Mail{mail(1, "mail 3")}},
+		{Gid: "group:0", Mails: []*Mail{mail(2, "mail 4")}},
+		{Mails: []*Mail{mail(1, "mail 5"), mail(1, "mail 5")}},
+		{Gid: "group:1", Mails: []*Mail{mail(1, "mail 6")}},
+		{Gid: "group:1", Mails: []*Mail{mail(1, "mail 7")}},
+		{Gid: "group:2", Mails: []*Mail{mail(3, "mail 8"), mail(3, "mail 9")}},
+		{Gid: "group:3", Mails: []*Mail{mail(3, "mail a")}},
+	}
+	reasons := []error{errTooManyMails, errMailSizeMismatch, errNotOnChain,
+		ErrNotRegistered, errDuplicateMail, errChainDuplicate, errTooManyMails}
+
+	out, err := mailbox.DeliverMails(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range batches {
+		req.Round = 0
+		if err := out.Send(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = out.CloseAndRecv(); err == nil {
+		t.Fatal("Bad batches not reported")
+	}
+
+	inbox, _, _ := store.Inbox(0, keyOf(mail(0, "")))
+	if len(inbox) != 1 || string(inbox[0]) != "mail 0" {
+		t.Fatal("Wrong mails for user 0")
+	}
+	inbox, _, _ = store.Inbox(0, keyOf(mail(1, "")))
+	if len(inbox) != 1 || string(inbox[0]) != "mail 6" {
+		t.Fatal("Wrong mails for user 1")
+	}
+	inbox, expected, _ := store.Inbox(0, keyOf(mail(3, "")))
+	if len(inbox) != 2 || expected != 1 {
+		t.Fatal("Wrong mails for user 3")
+	}
+
+	resp, err := mailbox.GetAnomalies(context.Background(), &GetAnomaliesRequest{Round: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Anomalies) != len(reasons) {
+		t.Fatal("Wrong number of anomalies", resp.Anomalies)
+	}
+	for i, anomaly := range resp.Anomalies {
+		if anomaly.Reason != reasons[i].Error() {
+			t.Fatal("Wrong anomaly", i, anomaly.Reason)
+		}
+	}
+}
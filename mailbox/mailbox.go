@@ -2,9 +2,13 @@ package mailbox
 
 import (
 	"crypto/ecdsa"
+	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
+	"log"
 	"sync"
+	"time"
 
 	"github.com/kwonalbert/xrd/config"
 	"github.com/kwonalbert/xrd/span"
@@ -15,21 +19,159 @@ import (
 type mailbox struct {
 	coordinator ecdsa.PublicKey
 
+	store     Store
+	retention Retention
+
+	replication Replication
+	ring        *Ring
+	syncOnce    sync.Once
+
 	smu    sync.RWMutex
 	states map[uint64]*roundState
+
+	pmu    sync.Mutex
+	pirDBs map[uint64]*pirEntry
+
+	cmu        sync.Mutex
+	challenges map[uint64][]byte
+	current    uint64 // latest round started
+
+	submu sync.RWMutex
+	subs  map[[32]byte][]*subscriber
+
+	chmu   sync.Mutex
+	chains map[uint64]*chainStats
+
+	amu       sync.Mutex
+	anomalies map[uint64][]*Anomaly
+
+	// to check the chains users ask tokens for, nil without tokens
+	assigner    *config.Assigner
+	tmu         sync.Mutex
+	tokenRounds map[uint64]*tokenRound
 }
 
+// roundState is what's needed while a round is in progress. The
+// mails themselves are in the store.
 type roundState struct {
 	mu       sync.RWMutex
-	inboxes  map[[32]byte][][]byte
-	inboxwgs map[[32]byte]*sync.WaitGroup
+	expected map[[32]byte]uint64
+	received map[[32]byte]uint64
+	// users that take more mails than they wait for
+	capacity map[[32]byte]uint64
+	// closed once all the expected mails of a user are delivered
+	inboxDone map[[32]byte]chan struct{}
+	// closed (and replaced) whenever mails are delivered
+	delivered chan struct{}
+
+	// deliveries of the round are checked, stored and published one
+	// batch at a time under dmu, without holding mu while storing
+	dmu sync.Mutex
+	// digests of the delivered mails, and their size
+	digests  map[[32]byte]struct{}
+	mailSize int
+	// number of batches stored, for subscriptions
+	batches uint64
+
+	// GetMails stops waiting after the deadline, or when the round ends
+	deadline time.Time
+	ended    chan struct{}
+	endOnce  sync.Once
+}
+
+func (state *roundState) end() {
+	state.endOnce.Do(func() { close(state.ended) })
+}
+
+// register (re)sets the number of mails expected for key, and the
+// most it takes.
+func (state *roundState) register(key [32]byte, expected, capacity uint64) {
+	state.expected[key] = expected
+	if capacity > expected {
+		state.capacity[key] = capacity
+	} else {
+		delete(state.capacity, key)
+	}
+	done := make(chan struct{})
+	if state.received[key] >= expected {
+		close(done)
+	}
+	state.inboxDone[key] = done
 }
 
-func NewMailboxServer(coordinator ecdsa.PublicKey) MailboxServer {
+// deliver counts a mail for key.
+func (state *roundState) deliver(key [32]byte) {
+	state.received[key]++
+	if state.received[key] == state.expected[key] {
+		close(state.inboxDone[key])
+	}
+}
+
+// wait waits until all expected mails of the keys arrived, or counts
+// of them if given, or until the round deadline passes or the round
+// ends, whichever is first.
+func (state *roundState) wait(keys [][32]byte, counts []uint64) error {
+	timeout := make(chan struct{})
+	if !state.deadline.IsZero() {
+		timer := time.AfterFunc(time.Until(state.deadline), func() { close(timeout) })
+		defer timer.Stop()
+	}
+
+	for i, key := range keys {
+		for {
+			state.mu.RLock()
+			done, ok := state.inboxDone[key]
+			received, delivered := state.received[key], state.delivered
+			state.mu.RUnlock()
+			if !ok {
+				return ErrNotRegistered
+			}
+			if len(counts) == 0 {
+				delivered = nil
+			} else if received >= counts[i] {
+				break
+			}
+			select {
+			case <-delivered:
+				continue
+			case <-done:
+			case <-timeout:
+			case <-state.ended:
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// NewMailboxServer creates a mailbox that keeps its inboxes in store,
+// deletes past rounds according to retention, catches up with the
+// other replicas after a restart, and issues submission tokens for the
+// chains of tokens.
+func NewMailboxServer(coordinator ecdsa.PublicKey, store Store, retention Retention, replication Replication, tokens Tokens) MailboxServer {
 	mb := &mailbox{
 		coordinator: coordinator,
 
+		store:     store,
+		retention: retention,
+
+		replication: replication,
+		ring:        NewRing(replication.Mailboxes, replication.Factor),
+
 		states: make(map[uint64]*roundState),
+		pirDBs: make(map[uint64]*pirEntry),
+
+		challenges: make(map[uint64][]byte),
+
+		subs: make(map[[32]byte][]*subscriber),
+
+		chains:    make(map[uint64]*chainStats),
+		anomalies: make(map[uint64][]*Anomaly),
+
+		tokenRounds: make(map[uint64]*tokenRound),
+	}
+	if tokens.Groups != nil {
+		mb.assigner = config.NewAssigner(tokens.Groups)
 	}
 	return mb
 }
@@ -38,21 +180,99 @@ func NewMailboxServer(coordinator ecdsa.PublicKey) MailboxServer {
 // TODO: Add authentication for all functions
 
 func (mb *mailbox) NewRound(ctx context.Context, in *NewRoundRequest) (*NewRoundResponse, error) {
+	err := mb.store.NewRound(in.Round, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(in.TokenKey) > 0 {
+		if err := mb.newTokenRound(in.Round, in.TokenKey); err != nil {
+			return nil, err
+		}
+	}
+
 	state := &roundState{
-		inboxes:  make(map[[32]byte][][]byte),
-		inboxwgs: make(map[[32]byte]*sync.WaitGroup),
+		expected:  make(map[[32]byte]uint64),
+		received:  make(map[[32]byte]uint64),
+		capacity:  make(map[[32]byte]uint64),
+		inboxDone: make(map[[32]byte]chan struct{}),
+		delivered: make(chan struct{}),
+		digests:   make(map[[32]byte]struct{}),
+		ended:     make(chan struct{}),
+	}
+	if in.Timeout > 0 {
+		state.deadline = time.Now().Add(time.Duration(in.Timeout))
 	}
 	mb.smu.Lock()
 	mb.states[in.Round] = state
 	mb.smu.Unlock()
+	mb.cmu.Lock()
+	if in.Round > mb.current {
+		mb.current = in.Round
+	}
+	mb.cmu.Unlock()
+
+	err = mb.prune(in.Round)
+	if err != nil {
+		log.Println("Could not prune old rounds:", err)
+	}
+
+	if mb.replication.Factor > 1 {
+		mb.syncOnce.Do(func() { go mb.resync(in.Round) })
+	}
 	return &NewRoundResponse{}, nil
 }
 
+// prune deletes the rounds that are past the retention policy.
+func (mb *mailbox) prune(current uint64) error {
+	infos, err := mb.store.Rounds()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, info := range infos {
+		if !mb.retention.expired(info, current, now) {
+			continue
+		}
+		mb.endRound(info.Round)
+		mb.pmu.Lock()
+		delete(mb.pirDBs, info.Round)
+		mb.pmu.Unlock()
+		mb.cmu.Lock()
+		delete(mb.challenges, info.Round)
+		mb.cmu.Unlock()
+		mb.chmu.Lock()
+		delete(mb.chains, info.Round)
+		mb.chmu.Unlock()
+		mb.amu.Lock()
+		delete(mb.anomalies, info.Round)
+		mb.amu.Unlock()
+		mb.tmu.Lock()
+		delete(mb.tokenRounds, info.Round)
+		mb.tmu.Unlock()
+		err = mb.store.DeleteRound(info.Round)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EndRound stops accepting mails for the round, and releases any
+// pending GetMails with what has arrived so far. The inboxes are kept
+// until they expire.
 func (mb *mailbox) EndRound(ctx context.Context, in *EndRoundRequest) (*EndRoundResponse, error) {
+	mb.endRound(in.Round)
+	return &EndRoundResponse{}, nil
+}
+
+func (mb *mailbox) endRound(round uint64) {
 	mb.smu.Lock()
-	delete(mb.states, in.Round)
+	state, ok := mb.states[round]
+	delete(mb.states, round)
 	mb.smu.Unlock()
-	return &EndRoundResponse{}, nil
+	if ok {
+		state.end()
+	}
 }
 
 func (mb *mailbox) RegisterUsers(stream Mailbox_RegisterUsersServer) error {
@@ -68,43 +288,63 @@ func (mb *mailbox) RegisterUsers(stream Mailbox_RegisterUsersServer) error {
 		state, ok := mb.states[in.Round]
 		mb.smu.RUnlock()
 		if !ok {
-			return errors.New("Round not initialized")
+			return ErrRoundNotFound
 		}
 
-		var tmpKey [32]byte
-		state.mu.Lock()
+		if len(in.Expected) != len(in.UserKeys) {
+			return errors.New("Mismatched expected counts")
+		}
+		if len(in.Chains) != 0 && len(in.Chains) != len(in.UserKeys) {
+			return errors.New("Mismatched chains")
+		}
+		if len(in.Capacity) != 0 && len(in.Capacity) != len(in.UserKeys) {
+			return errors.New("Mismatched capacities")
+		}
+		keys := make([][32]byte, len(in.UserKeys))
 		for i, key := range in.UserKeys {
-			copy(tmpKey[:], key)
-			state.inboxes[tmpKey] = nil
-			wg := new(sync.WaitGroup)
-			wg.Add(int(in.Expected[i]))
-			state.inboxwgs[tmpKey] = wg
+			if len(key) != 32 {
+				return errInvalidKey
+			}
+			copy(keys[i][:], key)
+		}
+		err = mb.store.Register(in.Round, keys, in.Expected)
+		if err != nil {
+			return err
+		}
+
+		state.mu.Lock()
+		for i, key := range keys {
+			var capacity uint64
+			if len(in.Capacity) > 0 {
+				capacity = in.Capacity[i]
+			}
+			state.register(key, in.Expected[i], capacity)
 		}
 		state.mu.Unlock()
+
+		if len(in.Chains) > 0 {
+			stats := mb.chainStats(in.Round, true)
+			stats.mu.Lock()
+			for i, key := range keys {
+				stats.register(key, in.Chains[i].Gids)
+			}
+			stats.mu.Unlock()
+		}
 	}
 	return nil
 }
 
+// RegisteredUsers returns the keys registered in a round, sorted.
 func (mb *mailbox) RegisteredUsers(in *RegisteredUsersRequest, stream Mailbox_RegisteredUsersServer) error {
-	mb.smu.RLock()
-	state, ok := mb.states[in.Round]
-	mb.smu.RUnlock()
-	if !ok {
-		return errors.New("Round not initialized")
-	}
-
-	keys := make([][]byte, len(state.inboxes))
-	state.mu.RLock()
-	i := 0
-	for key := range state.inboxes {
-		keys[i] = make([]byte, 32)
-		// TODO: seems to be a weird bug, when you assign keys[i] = key[:],
-		// the keys[j] changes to for j < i. maybe my misunderstanding of
-		// for loops and array as keys..?
-		copy(keys[i], key[:])
-		i++
-	}
-	state.mu.RUnlock()
+	users, err := mb.store.Users(in.Round)
+	if err != nil {
+		return err
+	}
+
+	keys := make([][]byte, len(users))
+	for i := range users {
+		keys[i] = users[i][:]
+	}
 
 	spans := span.StreamSpan(len(keys), config.StreamSize, 32)
 	for _, span := range spans {
@@ -118,8 +358,11 @@ func (mb *mailbox) RegisteredUsers(in *RegisteredUsersRequest, stream Mailbox_Re
 	return nil
 }
 
+// DeliverMails stores the batches that pass the integrity checks (see
+// integrity.go), and logs the others as anomalies of the round.
 func (mb *mailbox) DeliverMails(stream Mailbox_DeliverMailsServer) error {
 	var tmpKey [32]byte
+	rejected := 0
 
 	for {
 		req, err := stream.Recv()
@@ -133,24 +376,98 @@ func (mb *mailbox) DeliverMails(stream Mailbox_DeliverMailsServer) error {
 		state, ok := mb.states[req.Round]
 		mb.smu.RUnlock()
 		if !ok {
-			return errors.New("Round not initialized")
+			return ErrRoundNotFound
 		}
 
+		state.dmu.Lock()
+		state.mu.RLock()
+		digests, err := mb.checkBatch(state, req)
+		state.mu.RUnlock()
+		if err != nil {
+			state.dmu.Unlock()
+			mb.anomaly(req, err)
+			rejected++
+			continue
+		}
+		err = mb.store.Append(req.Round, req.Mails)
+		if err != nil {
+			state.dmu.Unlock()
+			return err
+		}
+		for _, digest := range digests {
+			state.digests[digest] = struct{}{}
+		}
+		if len(req.Mails) > 0 {
+			state.mailSize = len(req.Mails[0].Message)
+		}
 		state.mu.Lock()
 		for _, mail := range req.Mails {
 			copy(tmpKey[:], mail.UserKey)
-			box, ok := state.inboxes[tmpKey]
-			if !ok {
-				return errors.New("Userkey not registered")
-			}
-			state.inboxes[tmpKey] = append(box, mail.Message)
-			state.inboxwgs[tmpKey].Done()
+			state.deliver(tmpKey)
 		}
+		close(state.delivered)
+		state.delivered = make(chan struct{})
 		state.mu.Unlock()
+		if req.Gid != "" {
+			stats := mb.chainStats(req.Round, true)
+			stats.mu.Lock()
+			stats.deliver(req.Gid, req.Server, req.Mails)
+			stats.mu.Unlock()
+		}
+		state.batches++
+		mb.publish(req.Round, state.batches, req.Mails)
+		state.dmu.Unlock()
+	}
+
+	if rejected > 0 {
+		return fmt.Errorf("Rejected %d batches", rejected)
 	}
 	return nil
 }
 
+// challenge returns the random challenge of round, which is created
+// the first time it's asked for. Only the current and next rounds get
+// new challenges, and past rounds only while they are kept, so there
+// are no more challenges than rounds.
+func (mb *mailbox) challenge(round uint64) ([]byte, error) {
+	mb.cmu.Lock()
+	defer mb.cmu.Unlock()
+	challenge, ok := mb.challenges[round]
+	if ok {
+		return challenge, nil
+	}
+	if round > mb.current+1 {
+		return nil, errNoChallenge
+	}
+	if round < mb.current {
+		if _, err := mb.store.Users(round); err == ErrRoundNotFound {
+			return nil, errNoChallenge
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	challenge = make([]byte, CHALLENGE_SIZE)
+	rand.Read(challenge)
+	mb.challenges[round] = challenge
+	return challenge, nil
+}
+
+func (mb *mailbox) GetChallenge(ctx context.Context, in *GetChallengeRequest) (*GetChallengeResponse, error) {
+	challenge, err := mb.challenge(in.Round)
+	if err != nil {
+		return nil, err
+	}
+	return &GetChallengeResponse{
+		Challenge: challenge,
+	}, nil
+}
+
+// GetMails waits for all the mails of a round in progress (or as many
+// as asked for), up to the round deadline. Past rounds are served from
+// the store as is. Each inbox carries the number of mails expected and
+// received, so that the caller can tell a missing mail from an empty
+// inbox. Every user key needs a proof of ownership over the challenge
+// of the round.
 func (mb *mailbox) GetMails(stream Mailbox_GetMailsServer) error {
 	for {
 		in, err := stream.Recv()
@@ -161,53 +478,64 @@ func (mb *mailbox) GetMails(stream Mailbox_GetMailsServer) error {
 		}
 
 		mb.smu.RLock()
-		state, ok := mb.states[in.Round]
+		state, live := mb.states[in.Round]
 		mb.smu.RUnlock()
-		if !ok {
-			return errors.New("Round not initialized")
-		}
 
-		var tmpKey [32]byte
+		keys := make([][32]byte, len(in.UserKeys))
+		kptrs := make([]*[32]byte, len(in.UserKeys))
+		for i, key := range in.UserKeys {
+			if len(key) != 32 {
+				return errInvalidKey
+			}
+			copy(keys[i][:], key)
+			kptrs[i] = &keys[i]
+		}
+		challenge, err := mb.challenge(in.Round)
+		if err != nil {
+			return err
+		}
+		if !VerifyOwnership(kptrs, in.Round, challenge, in.Proofs) {
+			return errors.New("Invalid proof of ownership")
+		}
 
-		// get the first one so we can configure the stream
-		for _, key := range in.UserKeys {
-			copy(tmpKey[:], key)
-			state.mu.RLock()
-			wg, ok := state.inboxwgs[tmpKey]
-			state.mu.RUnlock()
-			if !ok {
-				return errors.New("Userkey not registered")
+		if len(in.Wait) != 0 && len(in.Wait) != len(keys) {
+			return errors.New("Mismatched wait counts")
+		}
+		if live {
+			err := state.wait(keys, in.Wait)
+			if err != nil {
+				return err
 			}
-			wg.Wait()
+			//log.Println("All mails present")
 		}
-		//log.Println("All mails present")
 
-		state.mu.RLock()
-		msgSize := len(state.inboxes[tmpKey]) * len(state.inboxes[tmpKey][0])
-		state.mu.RUnlock()
+		// get all inboxes first so we can configure the stream
+		inboxes := make([]*Inbox, len(in.UserKeys))
+		msgSize := 1
+		for i, key := range in.UserKeys {
+			inbox, expected, err := mb.store.Inbox(in.Round, keys[i])
+			if err != nil {
+				return err
+			}
+			inboxes[i] = &Inbox{
+				UserKey:  key,
+				Messages: inbox,
+				Expected: expected,
+				Received: uint64(len(inbox)),
+			}
+			size := 0
+			for _, msg := range inbox {
+				size += len(msg)
+			}
+			if size > msgSize {
+				msgSize = size
+			}
+		}
 		spans := span.StreamSpan(len(in.UserKeys), config.StreamSize, msgSize)
 
 		for _, span := range spans {
-			inboxes := make([]*Inbox, span.End-span.Start)
-			for i, key := range in.UserKeys[span.Start:span.End] {
-				copy(tmpKey[:], key)
-				state.mu.RLock()
-				wg, ok := state.inboxwgs[tmpKey]
-				state.mu.RUnlock()
-				if !ok {
-					return errors.New("Userkey not registered")
-				}
-				wg.Wait()
-				state.mu.RLock()
-				inbox := state.inboxes[tmpKey]
-				state.mu.RUnlock()
-				inboxes[i] = &Inbox{
-					UserKey:  key,
-					Messages: inbox,
-				}
-			}
 			if err := stream.Send(&GetMailsResponse{
-				Inboxes: inboxes,
+				Inboxes: inboxes[span.Start:span.End],
 			}); err != nil {
 				return err
 			}
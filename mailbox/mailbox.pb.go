@@ -13,6 +13,7 @@
 		EndRoundRequest
 		EndRoundResponse
 		RegisterUsersRequest
+		Chains
 		RegisterUsersResponse
 		RegisteredUsersRequest
 		RegisteredUsersResponse
@@ -20,8 +21,29 @@
 		Mail
 		DeliverMailsRequest
 		DeliverMailsResponse
+		GetChallengeRequest
+		GetChallengeResponse
 		GetMailsRequest
 		GetMailsResponse
+		PIRGetMailsRequest
+		PIRGetMailsResponse
+		SubscribeRequest
+		SubscribeResponse
+		ChainReportRequest
+		ChainDelivery
+		ChainReportResponse
+		GetAnomaliesRequest
+		Anomaly
+		GetAnomaliesResponse
+		SyncRequest
+		SyncResponse
+		IssueTokensRequest
+		TokenRequest
+		IssueTokensResponse
+		TokenSignatures
+		IssuedTokensRequest
+		TokenIssuance
+		IssuedTokensResponse
 */
 package mailbox
 
@@ -48,7 +70,9 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type NewRoundRequest struct {
-	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	Round    uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	Timeout  uint64 `protobuf:"fixed64,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	TokenKey []byte `protobuf:"bytes,3,opt,name=token_key,json=tokenKey,proto3" json:"token_key,omitempty"`
 }
 
 func (m *NewRoundRequest) Reset()                    { *m = NewRoundRequest{} }
@@ -63,6 +87,20 @@ func (m *NewRoundRequest) GetRound() uint64 {
 	return 0
 }
 
+func (m *NewRoundRequest) GetTimeout() uint64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *NewRoundRequest) GetTokenKey() []byte {
+	if m != nil {
+		return m.TokenKey
+	}
+	return nil
+}
+
 type NewRoundResponse struct {
 }
 
@@ -96,9 +134,12 @@ func (*EndRoundResponse) ProtoMessage()               {}
 func (*EndRoundResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{3} }
 
 type RegisterUsersRequest struct {
-	Round    uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
-	UserKeys [][]byte `protobuf:"bytes,2,rep,name=user_keys,json=userKeys" json:"user_keys,omitempty"`
-	Expected []uint64 `protobuf:"fixed64,3,rep,packed,name=expected" json:"expected,omitempty"`
+	Round    uint64    `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	UserKeys [][]byte  `protobuf:"bytes,2,rep,name=user_keys,json=userKeys" json:"user_keys,omitempty"`
+	Expected []uint64  `protobuf:"fixed64,3,rep,packed,name=expected" json:"expected,omitempty"`
+	Chains   []*Chains `protobuf:"bytes,4,rep,name=chains" json:"chains,omitempty"`
+	// most mails each user takes, if more than expected, optional
+	Capacity []uint64 `protobuf:"fixed64,5,rep,packed,name=capacity" json:"capacity,omitempty"`
 }
 
 func (m *RegisterUsersRequest) Reset()                    { *m = RegisterUsersRequest{} }
@@ -127,13 +168,43 @@ func (m *RegisterUsersRequest) GetExpected() []uint64 {
 	return nil
 }
 
+func (m *RegisterUsersRequest) GetChains() []*Chains {
+	if m != nil {
+		return m.Chains
+	}
+	return nil
+}
+
+func (m *RegisterUsersRequest) GetCapacity() []uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return nil
+}
+
+type Chains struct {
+	Gids []string `protobuf:"bytes,1,rep,name=gids" json:"gids,omitempty"`
+}
+
+func (m *Chains) Reset()                    { *m = Chains{} }
+func (m *Chains) String() string            { return proto.CompactTextString(m) }
+func (*Chains) ProtoMessage()               {}
+func (*Chains) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{5} }
+
+func (m *Chains) GetGids() []string {
+	if m != nil {
+		return m.Gids
+	}
+	return nil
+}
+
 type RegisterUsersResponse struct {
 }
 
 func (m *RegisterUsersResponse) Reset()                    { *m = RegisterUsersResponse{} }
 func (m *RegisterUsersResponse) String() string            { return proto.CompactTextString(m) }
 func (*RegisterUsersResponse) ProtoMessage()               {}
-func (*RegisterUsersResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{5} }
+func (*RegisterUsersResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{6} }
 
 type RegisteredUsersRequest struct {
 	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
@@ -142,7 +213,7 @@ type RegisteredUsersRequest struct {
 func (m *RegisteredUsersRequest) Reset()                    { *m = RegisteredUsersRequest{} }
 func (m *RegisteredUsersRequest) String() string            { return proto.CompactTextString(m) }
 func (*RegisteredUsersRequest) ProtoMessage()               {}
-func (*RegisteredUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{6} }
+func (*RegisteredUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{7} }
 
 func (m *RegisteredUsersRequest) GetRound() uint64 {
 	if m != nil {
@@ -158,7 +229,7 @@ type RegisteredUsersResponse struct {
 func (m *RegisteredUsersResponse) Reset()                    { *m = RegisteredUsersResponse{} }
 func (m *RegisteredUsersResponse) String() string            { return proto.CompactTextString(m) }
 func (*RegisteredUsersResponse) ProtoMessage()               {}
-func (*RegisteredUsersResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{7} }
+func (*RegisteredUsersResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{8} }
 
 func (m *RegisteredUsersResponse) GetUserKeys() [][]byte {
 	if m != nil {
@@ -170,12 +241,14 @@ func (m *RegisteredUsersResponse) GetUserKeys() [][]byte {
 type Inbox struct {
 	UserKey  []byte   `protobuf:"bytes,1,opt,name=user_key,json=userKey,proto3" json:"user_key,omitempty"`
 	Messages [][]byte `protobuf:"bytes,2,rep,name=messages" json:"messages,omitempty"`
+	Expected uint64   `protobuf:"fixed64,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Received uint64   `protobuf:"fixed64,4,opt,name=received,proto3" json:"received,omitempty"`
 }
 
 func (m *Inbox) Reset()                    { *m = Inbox{} }
 func (m *Inbox) String() string            { return proto.CompactTextString(m) }
 func (*Inbox) ProtoMessage()               {}
-func (*Inbox) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{8} }
+func (*Inbox) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{9} }
 
 func (m *Inbox) GetUserKey() []byte {
 	if m != nil {
@@ -191,6 +264,20 @@ func (m *Inbox) GetMessages() [][]byte {
 	return nil
 }
 
+func (m *Inbox) GetExpected() uint64 {
+	if m != nil {
+		return m.Expected
+	}
+	return 0
+}
+
+func (m *Inbox) GetReceived() uint64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
 type Mail struct {
 	UserKey []byte `protobuf:"bytes,1,opt,name=user_key,json=userKey,proto3" json:"user_key,omitempty"`
 	Message []byte `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -199,7 +286,7 @@ type Mail struct {
 func (m *Mail) Reset()                    { *m = Mail{} }
 func (m *Mail) String() string            { return proto.CompactTextString(m) }
 func (*Mail) ProtoMessage()               {}
-func (*Mail) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{9} }
+func (*Mail) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{10} }
 
 func (m *Mail) GetUserKey() []byte {
 	if m != nil {
@@ -216,14 +303,16 @@ func (m *Mail) GetMessage() []byte {
 }
 
 type DeliverMailsRequest struct {
-	Round uint64  `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
-	Mails []*Mail `protobuf:"bytes,2,rep,name=mails" json:"mails,omitempty"`
+	Round  uint64  `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	Mails  []*Mail `protobuf:"bytes,2,rep,name=mails" json:"mails,omitempty"`
+	Gid    string  `protobuf:"bytes,3,opt,name=gid,proto3" json:"gid,omitempty"`
+	Server string  `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
 }
 
 func (m *DeliverMailsRequest) Reset()                    { *m = DeliverMailsRequest{} }
 func (m *DeliverMailsRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeliverMailsRequest) ProtoMessage()               {}
-func (*DeliverMailsRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{10} }
+func (*DeliverMailsRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{11} }
 
 func (m *DeliverMailsRequest) GetRound() uint64 {
 	if m != nil {
@@ -239,23 +328,74 @@ func (m *DeliverMailsRequest) GetMails() []*Mail {
 	return nil
 }
 
+func (m *DeliverMailsRequest) GetGid() string {
+	if m != nil {
+		return m.Gid
+	}
+	return ""
+}
+
+func (m *DeliverMailsRequest) GetServer() string {
+	if m != nil {
+		return m.Server
+	}
+	return ""
+}
+
 type DeliverMailsResponse struct {
 }
 
 func (m *DeliverMailsResponse) Reset()                    { *m = DeliverMailsResponse{} }
 func (m *DeliverMailsResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeliverMailsResponse) ProtoMessage()               {}
-func (*DeliverMailsResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{11} }
+func (*DeliverMailsResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{12} }
+
+type GetChallengeRequest struct {
+	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (m *GetChallengeRequest) Reset()                    { *m = GetChallengeRequest{} }
+func (m *GetChallengeRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetChallengeRequest) ProtoMessage()               {}
+func (*GetChallengeRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{13} }
+
+func (m *GetChallengeRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+type GetChallengeResponse struct {
+	Challenge []byte `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+}
+
+func (m *GetChallengeResponse) Reset()                    { *m = GetChallengeResponse{} }
+func (m *GetChallengeResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetChallengeResponse) ProtoMessage()               {}
+func (*GetChallengeResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{14} }
+
+func (m *GetChallengeResponse) GetChallenge() []byte {
+	if m != nil {
+		return m.Challenge
+	}
+	return nil
+}
 
 type GetMailsRequest struct {
 	Round    uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 	UserKeys [][]byte `protobuf:"bytes,2,rep,name=user_keys,json=userKeys" json:"user_keys,omitempty"`
+	// proof of ownership of each user key
+	Proofs [][]byte `protobuf:"bytes,3,rep,name=proofs" json:"proofs,omitempty"`
+	// number of mails to wait for in each inbox, if fewer than expected
+	// (all the expected ones if empty)
+	Wait []uint64 `protobuf:"fixed64,4,rep,packed,name=wait" json:"wait,omitempty"`
 }
 
 func (m *GetMailsRequest) Reset()                    { *m = GetMailsRequest{} }
 func (m *GetMailsRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetMailsRequest) ProtoMessage()               {}
-func (*GetMailsRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{12} }
+func (*GetMailsRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{15} }
 
 func (m *GetMailsRequest) GetRound() uint64 {
 	if m != nil {
@@ -271,6 +411,20 @@ func (m *GetMailsRequest) GetUserKeys() [][]byte {
 	return nil
 }
 
+func (m *GetMailsRequest) GetProofs() [][]byte {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
+}
+
+func (m *GetMailsRequest) GetWait() []uint64 {
+	if m != nil {
+		return m.Wait
+	}
+	return nil
+}
+
 type GetMailsResponse struct {
 	Inboxes []*Inbox `protobuf:"bytes,1,rep,name=inboxes" json:"inboxes,omitempty"`
 }
@@ -278,7 +432,7 @@ type GetMailsResponse struct {
 func (m *GetMailsResponse) Reset()                    { *m = GetMailsResponse{} }
 func (m *GetMailsResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetMailsResponse) ProtoMessage()               {}
-func (*GetMailsResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{13} }
+func (*GetMailsResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{16} }
 
 func (m *GetMailsResponse) GetInboxes() []*Inbox {
 	if m != nil {
@@ -287,935 +441,5004 @@ func (m *GetMailsResponse) GetInboxes() []*Inbox {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*NewRoundRequest)(nil), "mailbox.NewRoundRequest")
-	proto.RegisterType((*NewRoundResponse)(nil), "mailbox.NewRoundResponse")
-	proto.RegisterType((*EndRoundRequest)(nil), "mailbox.EndRoundRequest")
-	proto.RegisterType((*EndRoundResponse)(nil), "mailbox.EndRoundResponse")
-	proto.RegisterType((*RegisterUsersRequest)(nil), "mailbox.RegisterUsersRequest")
-	proto.RegisterType((*RegisterUsersResponse)(nil), "mailbox.RegisterUsersResponse")
-	proto.RegisterType((*RegisteredUsersRequest)(nil), "mailbox.RegisteredUsersRequest")
-	proto.RegisterType((*RegisteredUsersResponse)(nil), "mailbox.RegisteredUsersResponse")
-	proto.RegisterType((*Inbox)(nil), "mailbox.Inbox")
-	proto.RegisterType((*Mail)(nil), "mailbox.Mail")
-	proto.RegisterType((*DeliverMailsRequest)(nil), "mailbox.DeliverMailsRequest")
-	proto.RegisterType((*DeliverMailsResponse)(nil), "mailbox.DeliverMailsResponse")
-	proto.RegisterType((*GetMailsRequest)(nil), "mailbox.GetMailsRequest")
-	proto.RegisterType((*GetMailsResponse)(nil), "mailbox.GetMailsResponse")
+type PIRGetMailsRequest struct {
+	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	// one bit per registered user, in RegisteredUsers order
+	Queries [][]byte `protobuf:"bytes,2,rep,name=queries" json:"queries,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Mailbox service
+func (m *PIRGetMailsRequest) Reset()                    { *m = PIRGetMailsRequest{} }
+func (m *PIRGetMailsRequest) String() string            { return proto.CompactTextString(m) }
+func (*PIRGetMailsRequest) ProtoMessage()               {}
+func (*PIRGetMailsRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{17} }
 
-type MailboxClient interface {
-	NewRound(ctx context.Context, in *NewRoundRequest, opts ...grpc.CallOption) (*NewRoundResponse, error)
-	EndRound(ctx context.Context, in *EndRoundRequest, opts ...grpc.CallOption) (*EndRoundResponse, error)
-	RegisterUsers(ctx context.Context, opts ...grpc.CallOption) (Mailbox_RegisterUsersClient, error)
-	RegisteredUsers(ctx context.Context, in *RegisteredUsersRequest, opts ...grpc.CallOption) (Mailbox_RegisteredUsersClient, error)
-	DeliverMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_DeliverMailsClient, error)
-	GetMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_GetMailsClient, error)
+func (m *PIRGetMailsRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
 }
 
-type mailboxClient struct {
-	cc *grpc.ClientConn
+func (m *PIRGetMailsRequest) GetQueries() [][]byte {
+	if m != nil {
+		return m.Queries
+	}
+	return nil
 }
 
-func NewMailboxClient(cc *grpc.ClientConn) MailboxClient {
-	return &mailboxClient{cc}
+type PIRGetMailsResponse struct {
+	// xor of the selected inboxes, one per query
+	Answers [][]byte `protobuf:"bytes,1,rep,name=answers" json:"answers,omitempty"`
 }
 
-func (c *mailboxClient) NewRound(ctx context.Context, in *NewRoundRequest, opts ...grpc.CallOption) (*NewRoundResponse, error) {
-	out := new(NewRoundResponse)
-	err := grpc.Invoke(ctx, "/mailbox.Mailbox/NewRound", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PIRGetMailsResponse) Reset()                    { *m = PIRGetMailsResponse{} }
+func (m *PIRGetMailsResponse) String() string            { return proto.CompactTextString(m) }
+func (*PIRGetMailsResponse) ProtoMessage()               {}
+func (*PIRGetMailsResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{18} }
+
+func (m *PIRGetMailsResponse) GetAnswers() [][]byte {
+	if m != nil {
+		return m.Answers
 	}
-	return out, nil
+	return nil
 }
 
-func (c *mailboxClient) EndRound(ctx context.Context, in *EndRoundRequest, opts ...grpc.CallOption) (*EndRoundResponse, error) {
-	out := new(EndRoundResponse)
-	err := grpc.Invoke(ctx, "/mailbox.Mailbox/EndRound", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type SubscribeRequest struct {
+	// first round to get mails of, to resume after a reconnect
+	Round    uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	UserKeys [][]byte `protobuf:"bytes,2,rep,name=user_keys,json=userKeys" json:"user_keys,omitempty"`
+	// proof of ownership of each user key, over the challenge of round
+	Proofs [][]byte `protobuf:"bytes,3,rep,name=proofs" json:"proofs,omitempty"`
 }
 
-func (c *mailboxClient) RegisterUsers(ctx context.Context, opts ...grpc.CallOption) (Mailbox_RegisterUsersClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[0], c.cc, "/mailbox.Mailbox/RegisterUsers", opts...)
-	if err != nil {
-		return nil, err
+func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()               {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{19} }
+
+func (m *SubscribeRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
 	}
-	x := &mailboxRegisterUsersClient{stream}
-	return x, nil
+	return 0
 }
 
-type Mailbox_RegisterUsersClient interface {
-	Send(*RegisterUsersRequest) error
-	CloseAndRecv() (*RegisterUsersResponse, error)
-	grpc.ClientStream
+func (m *SubscribeRequest) GetUserKeys() [][]byte {
+	if m != nil {
+		return m.UserKeys
+	}
+	return nil
 }
 
-type mailboxRegisterUsersClient struct {
-	grpc.ClientStream
+func (m *SubscribeRequest) GetProofs() [][]byte {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
 }
 
-func (x *mailboxRegisterUsersClient) Send(m *RegisterUsersRequest) error {
-	return x.ClientStream.SendMsg(m)
+type SubscribeResponse struct {
+	Round uint64  `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	Mails []*Mail `protobuf:"bytes,2,rep,name=mails" json:"mails,omitempty"`
 }
 
-func (x *mailboxRegisterUsersClient) CloseAndRecv() (*RegisterUsersResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(RegisterUsersResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SubscribeResponse) Reset()                    { *m = SubscribeResponse{} }
+func (m *SubscribeResponse) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeResponse) ProtoMessage()               {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{20} }
+
+func (m *SubscribeResponse) GetRound() uint64 {
+	if m != nil {
+		return m.Round
 	}
-	return m, nil
+	return 0
 }
 
-func (c *mailboxClient) RegisteredUsers(ctx context.Context, in *RegisteredUsersRequest, opts ...grpc.CallOption) (Mailbox_RegisteredUsersClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[1], c.cc, "/mailbox.Mailbox/RegisteredUsers", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &mailboxRegisteredUsersClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *SubscribeResponse) GetMails() []*Mail {
+	if m != nil {
+		return m.Mails
 	}
-	return x, nil
+	return nil
 }
 
-type Mailbox_RegisteredUsersClient interface {
-	Recv() (*RegisteredUsersResponse, error)
-	grpc.ClientStream
+type ChainReportRequest struct {
+	Round          uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	UnderDelivered bool   `protobuf:"varint,2,opt,name=under_delivered,json=underDelivered,proto3" json:"under_delivered,omitempty"`
 }
 
-type mailboxRegisteredUsersClient struct {
-	grpc.ClientStream
-}
+func (m *ChainReportRequest) Reset()                    { *m = ChainReportRequest{} }
+func (m *ChainReportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChainReportRequest) ProtoMessage()               {}
+func (*ChainReportRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{21} }
 
-func (x *mailboxRegisteredUsersClient) Recv() (*RegisteredUsersResponse, error) {
-	m := new(RegisteredUsersResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ChainReportRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
 	}
-	return m, nil
+	return 0
 }
 
-func (c *mailboxClient) DeliverMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_DeliverMailsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[2], c.cc, "/mailbox.Mailbox/DeliverMails", opts...)
-	if err != nil {
-		return nil, err
+func (m *ChainReportRequest) GetUnderDelivered() bool {
+	if m != nil {
+		return m.UnderDelivered
 	}
-	x := &mailboxDeliverMailsClient{stream}
-	return x, nil
+	return false
 }
 
-type Mailbox_DeliverMailsClient interface {
-	Send(*DeliverMailsRequest) error
-	CloseAndRecv() (*DeliverMailsResponse, error)
-	grpc.ClientStream
+type ChainDelivery struct {
+	Gid      string   `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Servers  []string `protobuf:"bytes,2,rep,name=servers" json:"servers,omitempty"`
+	Expected uint64   `protobuf:"fixed64,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Missing  uint64   `protobuf:"fixed64,4,opt,name=missing,proto3" json:"missing,omitempty"`
+	Received uint64   `protobuf:"fixed64,5,opt,name=received,proto3" json:"received,omitempty"`
 }
 
-type mailboxDeliverMailsClient struct {
-	grpc.ClientStream
+func (m *ChainDelivery) Reset()                    { *m = ChainDelivery{} }
+func (m *ChainDelivery) String() string            { return proto.CompactTextString(m) }
+func (*ChainDelivery) ProtoMessage()               {}
+func (*ChainDelivery) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{22} }
+
+func (m *ChainDelivery) GetGid() string {
+	if m != nil {
+		return m.Gid
+	}
+	return ""
 }
 
-func (x *mailboxDeliverMailsClient) Send(m *DeliverMailsRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *ChainDelivery) GetServers() []string {
+	if m != nil {
+		return m.Servers
+	}
+	return nil
 }
 
-func (x *mailboxDeliverMailsClient) CloseAndRecv() (*DeliverMailsResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *ChainDelivery) GetExpected() uint64 {
+	if m != nil {
+		return m.Expected
 	}
-	m := new(DeliverMailsResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *ChainDelivery) GetMissing() uint64 {
+	if m != nil {
+		return m.Missing
 	}
-	return m, nil
+	return 0
 }
 
-func (c *mailboxClient) GetMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_GetMailsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[3], c.cc, "/mailbox.Mailbox/GetMails", opts...)
-	if err != nil {
-		return nil, err
+func (m *ChainDelivery) GetReceived() uint64 {
+	if m != nil {
+		return m.Received
 	}
-	x := &mailboxGetMailsClient{stream}
-	return x, nil
+	return 0
 }
 
-type Mailbox_GetMailsClient interface {
-	Send(*GetMailsRequest) error
-	Recv() (*GetMailsResponse, error)
-	grpc.ClientStream
+type ChainReportResponse struct {
+	Chains []*ChainDelivery `protobuf:"bytes,1,rep,name=chains" json:"chains,omitempty"`
 }
 
-type mailboxGetMailsClient struct {
-	grpc.ClientStream
-}
+func (m *ChainReportResponse) Reset()                    { *m = ChainReportResponse{} }
+func (m *ChainReportResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChainReportResponse) ProtoMessage()               {}
+func (*ChainReportResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{23} }
 
-func (x *mailboxGetMailsClient) Send(m *GetMailsRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *ChainReportResponse) GetChains() []*ChainDelivery {
+	if m != nil {
+		return m.Chains
+	}
+	return nil
 }
 
-func (x *mailboxGetMailsClient) Recv() (*GetMailsResponse, error) {
-	m := new(GetMailsResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+type GetAnomaliesRequest struct {
+	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 }
 
-// Server API for Mailbox service
+func (m *GetAnomaliesRequest) Reset()                    { *m = GetAnomaliesRequest{} }
+func (m *GetAnomaliesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetAnomaliesRequest) ProtoMessage()               {}
+func (*GetAnomaliesRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{24} }
 
-type MailboxServer interface {
-	NewRound(context.Context, *NewRoundRequest) (*NewRoundResponse, error)
-	EndRound(context.Context, *EndRoundRequest) (*EndRoundResponse, error)
-	RegisterUsers(Mailbox_RegisterUsersServer) error
-	RegisteredUsers(*RegisteredUsersRequest, Mailbox_RegisteredUsersServer) error
-	DeliverMails(Mailbox_DeliverMailsServer) error
-	GetMails(Mailbox_GetMailsServer) error
+func (m *GetAnomaliesRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
 }
 
-func RegisterMailboxServer(s *grpc.Server, srv MailboxServer) {
-	s.RegisterService(&_Mailbox_serviceDesc, srv)
+type Anomaly struct {
+	Gid    string `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Server string `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Mails  uint64 `protobuf:"fixed64,4,opt,name=mails,proto3" json:"mails,omitempty"`
+	Time   uint64 `protobuf:"fixed64,5,opt,name=time,proto3" json:"time,omitempty"`
 }
 
-func _Mailbox_NewRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewRoundRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MailboxServer).NewRound(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mailbox.Mailbox/NewRound",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MailboxServer).NewRound(ctx, req.(*NewRoundRequest))
+func (m *Anomaly) Reset()                    { *m = Anomaly{} }
+func (m *Anomaly) String() string            { return proto.CompactTextString(m) }
+func (*Anomaly) ProtoMessage()               {}
+func (*Anomaly) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{25} }
+
+func (m *Anomaly) GetGid() string {
+	if m != nil {
+		return m.Gid
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Mailbox_EndRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EndRoundRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MailboxServer).EndRound(ctx, in)
+func (m *Anomaly) GetServer() string {
+	if m != nil {
+		return m.Server
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mailbox.Mailbox/EndRound",
+	return ""
+}
+
+func (m *Anomaly) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MailboxServer).EndRound(ctx, req.(*EndRoundRequest))
+	return ""
+}
+
+func (m *Anomaly) GetMails() uint64 {
+	if m != nil {
+		return m.Mails
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Mailbox_RegisterUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(MailboxServer).RegisterUsers(&mailboxRegisterUsersServer{stream})
+func (m *Anomaly) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
 }
 
-type Mailbox_RegisterUsersServer interface {
-	SendAndClose(*RegisterUsersResponse) error
-	Recv() (*RegisterUsersRequest, error)
-	grpc.ServerStream
+type GetAnomaliesResponse struct {
+	Anomalies []*Anomaly `protobuf:"bytes,1,rep,name=anomalies" json:"anomalies,omitempty"`
 }
 
-type mailboxRegisterUsersServer struct {
-	grpc.ServerStream
+func (m *GetAnomaliesResponse) Reset()                    { *m = GetAnomaliesResponse{} }
+func (m *GetAnomaliesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetAnomaliesResponse) ProtoMessage()               {}
+func (*GetAnomaliesResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{26} }
+
+func (m *GetAnomaliesResponse) GetAnomalies() []*Anomaly {
+	if m != nil {
+		return m.Anomalies
+	}
+	return nil
 }
 
-func (x *mailboxRegisterUsersServer) SendAndClose(m *RegisterUsersResponse) error {
-	return x.ServerStream.SendMsg(m)
+type SyncRequest struct {
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Since  uint64 `protobuf:"fixed64,2,opt,name=since,proto3" json:"since,omitempty"`
+	Before uint64 `protobuf:"fixed64,3,opt,name=before,proto3" json:"before,omitempty"`
 }
 
-func (x *mailboxRegisterUsersServer) Recv() (*RegisterUsersRequest, error) {
-	m := new(RegisterUsersRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SyncRequest) Reset()                    { *m = SyncRequest{} }
+func (m *SyncRequest) String() string            { return proto.CompactTextString(m) }
+func (*SyncRequest) ProtoMessage()               {}
+func (*SyncRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{27} }
+
+func (m *SyncRequest) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return m, nil
+	return ""
 }
 
-func _Mailbox_RegisteredUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RegisteredUsersRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *SyncRequest) GetSince() uint64 {
+	if m != nil {
+		return m.Since
 	}
-	return srv.(MailboxServer).RegisteredUsers(m, &mailboxRegisteredUsersServer{stream})
+	return 0
 }
 
-type Mailbox_RegisteredUsersServer interface {
-	Send(*RegisteredUsersResponse) error
-	grpc.ServerStream
+func (m *SyncRequest) GetBefore() uint64 {
+	if m != nil {
+		return m.Before
+	}
+	return 0
 }
 
-type mailboxRegisteredUsersServer struct {
-	grpc.ServerStream
+type SyncResponse struct {
+	Round   uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	Created uint64   `protobuf:"fixed64,2,opt,name=created,proto3" json:"created,omitempty"`
+	Inboxes []*Inbox `protobuf:"bytes,3,rep,name=inboxes" json:"inboxes,omitempty"`
 }
 
-func (x *mailboxRegisteredUsersServer) Send(m *RegisteredUsersResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
+func (m *SyncResponse) Reset()                    { *m = SyncResponse{} }
+func (m *SyncResponse) String() string            { return proto.CompactTextString(m) }
+func (*SyncResponse) ProtoMessage()               {}
+func (*SyncResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{28} }
 
-func _Mailbox_DeliverMails_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(MailboxServer).DeliverMails(&mailboxDeliverMailsServer{stream})
+func (m *SyncResponse) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
 }
 
-type Mailbox_DeliverMailsServer interface {
-	SendAndClose(*DeliverMailsResponse) error
-	Recv() (*DeliverMailsRequest, error)
-	grpc.ServerStream
+func (m *SyncResponse) GetCreated() uint64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
 }
 
-type mailboxDeliverMailsServer struct {
-	grpc.ServerStream
+func (m *SyncResponse) GetInboxes() []*Inbox {
+	if m != nil {
+		return m.Inboxes
+	}
+	return nil
 }
 
-func (x *mailboxDeliverMailsServer) SendAndClose(m *DeliverMailsResponse) error {
-	return x.ServerStream.SendMsg(m)
+type IssueTokensRequest struct {
+	Round uint64          `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	Users []*TokenRequest `protobuf:"bytes,2,rep,name=users" json:"users,omitempty"`
 }
 
-func (x *mailboxDeliverMailsServer) Recv() (*DeliverMailsRequest, error) {
-	m := new(DeliverMailsRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *IssueTokensRequest) Reset()                    { *m = IssueTokensRequest{} }
+func (m *IssueTokensRequest) String() string            { return proto.CompactTextString(m) }
+func (*IssueTokensRequest) ProtoMessage()               {}
+func (*IssueTokensRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{29} }
+
+func (m *IssueTokensRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
 	}
-	return m, nil
+	return 0
 }
 
-func _Mailbox_GetMails_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(MailboxServer).GetMails(&mailboxGetMailsServer{stream})
+func (m *IssueTokensRequest) GetUsers() []*TokenRequest {
+	if m != nil {
+		return m.Users
+	}
+	return nil
 }
 
-type Mailbox_GetMailsServer interface {
-	Send(*GetMailsResponse) error
-	Recv() (*GetMailsRequest, error)
-	grpc.ServerStream
+type TokenRequest struct {
+	UserKey []byte `protobuf:"bytes,1,opt,name=user_key,json=userKey,proto3" json:"user_key,omitempty"`
+	// proof of ownership of the user key, over the challenge of round
+	Proof   []byte   `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	Gids    []string `protobuf:"bytes,3,rep,name=gids" json:"gids,omitempty"`
+	Blinded [][]byte `protobuf:"bytes,4,rep,name=blinded" json:"blinded,omitempty"`
 }
 
-type mailboxGetMailsServer struct {
-	grpc.ServerStream
-}
+func (m *TokenRequest) Reset()                    { *m = TokenRequest{} }
+func (m *TokenRequest) String() string            { return proto.CompactTextString(m) }
+func (*TokenRequest) ProtoMessage()               {}
+func (*TokenRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{30} }
 
-func (x *mailboxGetMailsServer) Send(m *GetMailsResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *TokenRequest) GetUserKey() []byte {
+	if m != nil {
+		return m.UserKey
+	}
+	return nil
 }
 
-func (x *mailboxGetMailsServer) Recv() (*GetMailsRequest, error) {
-	m := new(GetMailsRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *TokenRequest) GetProof() []byte {
+	if m != nil {
+		return m.Proof
 	}
-	return m, nil
+	return nil
 }
 
-var _Mailbox_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "mailbox.Mailbox",
-	HandlerType: (*MailboxServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "NewRound",
-			Handler:    _Mailbox_NewRound_Handler,
-		},
-		{
-			MethodName: "EndRound",
-			Handler:    _Mailbox_EndRound_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "RegisterUsers",
-			Handler:       _Mailbox_RegisterUsers_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "RegisteredUsers",
-			Handler:       _Mailbox_RegisteredUsers_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "DeliverMails",
-			Handler:       _Mailbox_DeliverMails_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "GetMails",
-			Handler:       _Mailbox_GetMails_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "mailbox.proto",
+func (m *TokenRequest) GetGids() []string {
+	if m != nil {
+		return m.Gids
+	}
+	return nil
 }
 
-func (m *NewRoundRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TokenRequest) GetBlinded() [][]byte {
+	if m != nil {
+		return m.Blinded
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *NewRoundRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
-	}
-	return i, nil
+type IssueTokensResponse struct {
+	Users []*TokenSignatures `protobuf:"bytes,1,rep,name=users" json:"users,omitempty"`
 }
 
-func (m *NewRoundResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *IssueTokensResponse) Reset()                    { *m = IssueTokensResponse{} }
+func (m *IssueTokensResponse) String() string            { return proto.CompactTextString(m) }
+func (*IssueTokensResponse) ProtoMessage()               {}
+func (*IssueTokensResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{31} }
+
+func (m *IssueTokensResponse) GetUsers() []*TokenSignatures {
+	if m != nil {
+		return m.Users
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *NewRoundResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type TokenSignatures struct {
+	Signatures [][]byte `protobuf:"bytes,1,rep,name=signatures" json:"signatures,omitempty"`
 }
 
-func (m *EndRoundRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TokenSignatures) Reset()                    { *m = TokenSignatures{} }
+func (m *TokenSignatures) String() string            { return proto.CompactTextString(m) }
+func (*TokenSignatures) ProtoMessage()               {}
+func (*TokenSignatures) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{32} }
+
+func (m *TokenSignatures) GetSignatures() [][]byte {
+	if m != nil {
+		return m.Signatures
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *EndRoundRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
-	}
-	return i, nil
+type IssuedTokensRequest struct {
+	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 }
 
-func (m *EndRoundResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *IssuedTokensRequest) Reset()                    { *m = IssuedTokensRequest{} }
+func (m *IssuedTokensRequest) String() string            { return proto.CompactTextString(m) }
+func (*IssuedTokensRequest) ProtoMessage()               {}
+func (*IssuedTokensRequest) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{33} }
+
+func (m *IssuedTokensRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *EndRoundResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type TokenIssuance struct {
+	Gid    string `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Issued uint64 `protobuf:"fixed64,2,opt,name=issued,proto3" json:"issued,omitempty"`
 }
 
-func (m *RegisterUsersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TokenIssuance) Reset()                    { *m = TokenIssuance{} }
+func (m *TokenIssuance) String() string            { return proto.CompactTextString(m) }
+func (*TokenIssuance) ProtoMessage()               {}
+func (*TokenIssuance) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{34} }
+
+func (m *TokenIssuance) GetGid() string {
+	if m != nil {
+		return m.Gid
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *RegisterUsersRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
-	}
-	if len(m.UserKeys) > 0 {
-		for _, b := range m.UserKeys {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
-	}
-	if len(m.Expected) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Expected)*8))
-		for _, num := range m.Expected {
-			binary.LittleEndian.PutUint64(dAtA[i:], uint64(num))
-			i += 8
-		}
+func (m *TokenIssuance) GetIssued() uint64 {
+	if m != nil {
+		return m.Issued
 	}
-	return i, nil
+	return 0
 }
 
-func (m *RegisterUsersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+type IssuedTokensResponse struct {
+	Chains []*TokenIssuance `protobuf:"bytes,1,rep,name=chains" json:"chains,omitempty"`
+}
+
+func (m *IssuedTokensResponse) Reset()                    { *m = IssuedTokensResponse{} }
+func (m *IssuedTokensResponse) String() string            { return proto.CompactTextString(m) }
+func (*IssuedTokensResponse) ProtoMessage()               {}
+func (*IssuedTokensResponse) Descriptor() ([]byte, []int) { return fileDescriptorMailbox, []int{35} }
+
+func (m *IssuedTokensResponse) GetChains() []*TokenIssuance {
+	if m != nil {
+		return m.Chains
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *RegisterUsersResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+func init() {
+	proto.RegisterType((*NewRoundRequest)(nil), "mailbox.NewRoundRequest")
+	proto.RegisterType((*NewRoundResponse)(nil), "mailbox.NewRoundResponse")
+	proto.RegisterType((*EndRoundRequest)(nil), "mailbox.EndRoundRequest")
+	proto.RegisterType((*EndRoundResponse)(nil), "mailbox.EndRoundResponse")
+	proto.RegisterType((*RegisterUsersRequest)(nil), "mailbox.RegisterUsersRequest")
+	proto.RegisterType((*Chains)(nil), "mailbox.Chains")
+	proto.RegisterType((*RegisterUsersResponse)(nil), "mailbox.RegisterUsersResponse")
+	proto.RegisterType((*RegisteredUsersRequest)(nil), "mailbox.RegisteredUsersRequest")
+	proto.RegisterType((*RegisteredUsersResponse)(nil), "mailbox.RegisteredUsersResponse")
+	proto.RegisterType((*Inbox)(nil), "mailbox.Inbox")
+	proto.RegisterType((*Mail)(nil), "mailbox.Mail")
+	proto.RegisterType((*DeliverMailsRequest)(nil), "mailbox.DeliverMailsRequest")
+	proto.RegisterType((*DeliverMailsResponse)(nil), "mailbox.DeliverMailsResponse")
+	proto.RegisterType((*GetChallengeRequest)(nil), "mailbox.GetChallengeRequest")
+	proto.RegisterType((*GetChallengeResponse)(nil), "mailbox.GetChallengeResponse")
+	proto.RegisterType((*GetMailsRequest)(nil), "mailbox.GetMailsRequest")
+	proto.RegisterType((*GetMailsResponse)(nil), "mailbox.GetMailsResponse")
+	proto.RegisterType((*PIRGetMailsRequest)(nil), "mailbox.PIRGetMailsRequest")
+	proto.RegisterType((*PIRGetMailsResponse)(nil), "mailbox.PIRGetMailsResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "mailbox.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "mailbox.SubscribeResponse")
+	proto.RegisterType((*ChainReportRequest)(nil), "mailbox.ChainReportRequest")
+	proto.RegisterType((*ChainDelivery)(nil), "mailbox.ChainDelivery")
+	proto.RegisterType((*ChainReportResponse)(nil), "mailbox.ChainReportResponse")
+	proto.RegisterType((*GetAnomaliesRequest)(nil), "mailbox.GetAnomaliesRequest")
+	proto.RegisterType((*Anomaly)(nil), "mailbox.Anomaly")
+	proto.RegisterType((*GetAnomaliesResponse)(nil), "mailbox.GetAnomaliesResponse")
+	proto.RegisterType((*SyncRequest)(nil), "mailbox.SyncRequest")
+	proto.RegisterType((*SyncResponse)(nil), "mailbox.SyncResponse")
+	proto.RegisterType((*IssueTokensRequest)(nil), "mailbox.IssueTokensRequest")
+	proto.RegisterType((*TokenRequest)(nil), "mailbox.TokenRequest")
+	proto.RegisterType((*IssueTokensResponse)(nil), "mailbox.IssueTokensResponse")
+	proto.RegisterType((*TokenSignatures)(nil), "mailbox.TokenSignatures")
+	proto.RegisterType((*IssuedTokensRequest)(nil), "mailbox.IssuedTokensRequest")
+	proto.RegisterType((*TokenIssuance)(nil), "mailbox.TokenIssuance")
+	proto.RegisterType((*IssuedTokensResponse)(nil), "mailbox.IssuedTokensResponse")
 }
 
-func (m *RegisteredUsersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Mailbox service
+
+type MailboxClient interface {
+	NewRound(ctx context.Context, in *NewRoundRequest, opts ...grpc.CallOption) (*NewRoundResponse, error)
+	EndRound(ctx context.Context, in *EndRoundRequest, opts ...grpc.CallOption) (*EndRoundResponse, error)
+	RegisterUsers(ctx context.Context, opts ...grpc.CallOption) (Mailbox_RegisterUsersClient, error)
+	RegisteredUsers(ctx context.Context, in *RegisteredUsersRequest, opts ...grpc.CallOption) (Mailbox_RegisteredUsersClient, error)
+	DeliverMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_DeliverMailsClient, error)
+	// challenge to sign for GetMails, see ProveOwnership
+	GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*GetChallengeResponse, error)
+	GetMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_GetMailsClient, error)
+	// private information retrieval version of GetMails
+	PIRGetMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_PIRGetMailsClient, error)
+	// mails of some users as they are delivered, see subscribe.go
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Mailbox_SubscribeClient, error)
+	// per chain delivery counts, to find the chains that dropped mails
+	ChainReport(ctx context.Context, in *ChainReportRequest, opts ...grpc.CallOption) (*ChainReportResponse, error)
+	// deliveries rejected by the integrity checks of DeliverMails
+	GetAnomalies(ctx context.Context, in *GetAnomaliesRequest, opts ...grpc.CallOption) (*GetAnomaliesResponse, error)
+	// inboxes replicated at another mailbox, to catch up after downtime
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Mailbox_SyncClient, error)
+	// blind signatures of the submission tokens of the users, see tokens.go
+	IssueTokens(ctx context.Context, in *IssueTokensRequest, opts ...grpc.CallOption) (*IssueTokensResponse, error)
+	IssuedTokens(ctx context.Context, in *IssuedTokensRequest, opts ...grpc.CallOption) (*IssuedTokensResponse, error)
 }
 
-func (m *RegisteredUsersRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
-	}
-	return i, nil
+type mailboxClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *RegisteredUsersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func NewMailboxClient(cc *grpc.ClientConn) MailboxClient {
+	return &mailboxClient{cc}
+}
+
+func (c *mailboxClient) NewRound(ctx context.Context, in *NewRoundRequest, opts ...grpc.CallOption) (*NewRoundResponse, error) {
+	out := new(NewRoundResponse)
+	err := grpc.Invoke(ctx, "/mailbox.Mailbox/NewRound", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *RegisteredUsersResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.UserKeys) > 0 {
-		for _, b := range m.UserKeys {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+func (c *mailboxClient) EndRound(ctx context.Context, in *EndRoundRequest, opts ...grpc.CallOption) (*EndRoundResponse, error) {
+	out := new(EndRoundResponse)
+	err := grpc.Invoke(ctx, "/mailbox.Mailbox/EndRound", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *Inbox) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *mailboxClient) RegisterUsers(ctx context.Context, opts ...grpc.CallOption) (Mailbox_RegisterUsersClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[0], c.cc, "/mailbox.Mailbox/RegisterUsers", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &mailboxRegisterUsersClient{stream}
+	return x, nil
 }
 
-func (m *Inbox) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.UserKey) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMailbox(dAtA, i, uint64(len(m.UserKey)))
-		i += copy(dAtA[i:], m.UserKey)
-	}
-	if len(m.Messages) > 0 {
-		for _, b := range m.Messages {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
-	}
-	return i, nil
+type Mailbox_RegisterUsersClient interface {
+	Send(*RegisterUsersRequest) error
+	CloseAndRecv() (*RegisterUsersResponse, error)
+	grpc.ClientStream
 }
 
-func (m *Mail) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type mailboxRegisterUsersClient struct {
+	grpc.ClientStream
 }
 
-func (m *Mail) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.UserKey) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMailbox(dAtA, i, uint64(len(m.UserKey)))
-		i += copy(dAtA[i:], m.UserKey)
+func (x *mailboxRegisterUsersClient) Send(m *RegisterUsersRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mailboxRegisterUsersClient) CloseAndRecv() (*RegisterUsersResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if len(m.Message) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
+	m := new(RegisterUsersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *DeliverMailsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *mailboxClient) RegisteredUsers(ctx context.Context, in *RegisteredUsersRequest, opts ...grpc.CallOption) (Mailbox_RegisteredUsersClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[1], c.cc, "/mailbox.Mailbox/RegisteredUsers", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &mailboxRegisteredUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *DeliverMailsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
-	}
-	if len(m.Mails) > 0 {
-		for _, msg := range m.Mails {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+type Mailbox_RegisteredUsersClient interface {
+	Recv() (*RegisteredUsersResponse, error)
+	grpc.ClientStream
+}
+
+type mailboxRegisteredUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *mailboxRegisteredUsersClient) Recv() (*RegisteredUsersResponse, error) {
+	m := new(RegisteredUsersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *DeliverMailsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *mailboxClient) DeliverMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_DeliverMailsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[2], c.cc, "/mailbox.Mailbox/DeliverMails", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &mailboxDeliverMailsClient{stream}
+	return x, nil
 }
 
-func (m *DeliverMailsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type Mailbox_DeliverMailsClient interface {
+	Send(*DeliverMailsRequest) error
+	CloseAndRecv() (*DeliverMailsResponse, error)
+	grpc.ClientStream
 }
 
-func (m *GetMailsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type mailboxDeliverMailsClient struct {
+	grpc.ClientStream
 }
 
-func (m *GetMailsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x9
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
-		i += 8
+func (x *mailboxDeliverMailsClient) Send(m *DeliverMailsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mailboxDeliverMailsClient) CloseAndRecv() (*DeliverMailsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if len(m.UserKeys) > 0 {
-		for _, b := range m.UserKeys {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+	m := new(DeliverMailsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *GetMailsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *mailboxClient) GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*GetChallengeResponse, error) {
+	out := new(GetChallengeResponse)
+	err := grpc.Invoke(ctx, "/mailbox.Mailbox/GetChallenge", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *GetMailsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Inboxes) > 0 {
-		for _, msg := range m.Inboxes {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+func (c *mailboxClient) GetMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_GetMailsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[3], c.cc, "/mailbox.Mailbox/GetMails", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	x := &mailboxGetMailsClient{stream}
+	return x, nil
 }
 
-func encodeVarintMailbox(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+type Mailbox_GetMailsClient interface {
+	Send(*GetMailsRequest) error
+	Recv() (*GetMailsResponse, error)
+	grpc.ClientStream
+}
+
+type mailboxGetMailsClient struct {
+	grpc.ClientStream
+}
+
+func (x *mailboxGetMailsClient) Send(m *GetMailsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mailboxGetMailsClient) Recv() (*GetMailsResponse, error) {
+	m := new(GetMailsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return m, nil
 }
-func (m *NewRoundRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
+
+func (c *mailboxClient) PIRGetMails(ctx context.Context, opts ...grpc.CallOption) (Mailbox_PIRGetMailsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[4], c.cc, "/mailbox.Mailbox/PIRGetMails", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	x := &mailboxPIRGetMailsClient{stream}
+	return x, nil
 }
 
-func (m *NewRoundResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+type Mailbox_PIRGetMailsClient interface {
+	Send(*PIRGetMailsRequest) error
+	Recv() (*PIRGetMailsResponse, error)
+	grpc.ClientStream
 }
 
-func (m *EndRoundRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
-	}
-	return n
+type mailboxPIRGetMailsClient struct {
+	grpc.ClientStream
 }
 
-func (m *EndRoundResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (x *mailboxPIRGetMailsClient) Send(m *PIRGetMailsRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func (m *RegisterUsersRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
+func (x *mailboxPIRGetMailsClient) Recv() (*PIRGetMailsResponse, error) {
+	m := new(PIRGetMailsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if len(m.UserKeys) > 0 {
-		for _, b := range m.UserKeys {
-			l = len(b)
-			n += 1 + l + sovMailbox(uint64(l))
-		}
+	return m, nil
+}
+
+func (c *mailboxClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Mailbox_SubscribeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[5], c.cc, "/mailbox.Mailbox/Subscribe", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Expected) > 0 {
-		n += 1 + sovMailbox(uint64(len(m.Expected)*8)) + len(m.Expected)*8
+	x := &mailboxSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return n
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *RegisterUsersResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+type Mailbox_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
+	grpc.ClientStream
 }
 
-func (m *RegisteredUsersRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
-	}
-	return n
+type mailboxSubscribeClient struct {
+	grpc.ClientStream
 }
 
-func (m *RegisteredUsersResponse) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.UserKeys) > 0 {
-		for _, b := range m.UserKeys {
-			l = len(b)
-			n += 1 + l + sovMailbox(uint64(l))
-		}
+func (x *mailboxSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return n
+	return m, nil
 }
 
-func (m *Inbox) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.UserKey)
-	if l > 0 {
-		n += 1 + l + sovMailbox(uint64(l))
+func (c *mailboxClient) ChainReport(ctx context.Context, in *ChainReportRequest, opts ...grpc.CallOption) (*ChainReportResponse, error) {
+	out := new(ChainReportResponse)
+	err := grpc.Invoke(ctx, "/mailbox.Mailbox/ChainReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Messages) > 0 {
-		for _, b := range m.Messages {
-			l = len(b)
-			n += 1 + l + sovMailbox(uint64(l))
-		}
+	return out, nil
+}
+
+func (c *mailboxClient) GetAnomalies(ctx context.Context, in *GetAnomaliesRequest, opts ...grpc.CallOption) (*GetAnomaliesResponse, error) {
+	out := new(GetAnomaliesResponse)
+	err := grpc.Invoke(ctx, "/mailbox.Mailbox/GetAnomalies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *Mail) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.UserKey)
-	if l > 0 {
-		n += 1 + l + sovMailbox(uint64(l))
+func (c *mailboxClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Mailbox_SyncClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Mailbox_serviceDesc.Streams[6], c.cc, "/mailbox.Mailbox/Sync", opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovMailbox(uint64(l))
+	x := &mailboxSyncClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return n
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *DeliverMailsRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
+type Mailbox_SyncClient interface {
+	Recv() (*SyncResponse, error)
+	grpc.ClientStream
+}
+
+type mailboxSyncClient struct {
+	grpc.ClientStream
+}
+
+func (x *mailboxSyncClient) Recv() (*SyncResponse, error) {
+	m := new(SyncResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if len(m.Mails) > 0 {
-		for _, e := range m.Mails {
-			l = e.Size()
-			n += 1 + l + sovMailbox(uint64(l))
-		}
+	return m, nil
+}
+
+func (c *mailboxClient) IssueTokens(ctx context.Context, in *IssueTokensRequest, opts ...grpc.CallOption) (*IssueTokensResponse, error) {
+	out := new(IssueTokensResponse)
+	err := grpc.Invoke(ctx, "/mailbox.Mailbox/IssueTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *DeliverMailsResponse) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (c *mailboxClient) IssuedTokens(ctx context.Context, in *IssuedTokensRequest, opts ...grpc.CallOption) (*IssuedTokensResponse, error) {
+	out := new(IssuedTokensResponse)
+	err := grpc.Invoke(ctx, "/mailbox.Mailbox/IssuedTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Mailbox service
+
+type MailboxServer interface {
+	NewRound(context.Context, *NewRoundRequest) (*NewRoundResponse, error)
+	EndRound(context.Context, *EndRoundRequest) (*EndRoundResponse, error)
+	RegisterUsers(Mailbox_RegisterUsersServer) error
+	RegisteredUsers(*RegisteredUsersRequest, Mailbox_RegisteredUsersServer) error
+	DeliverMails(Mailbox_DeliverMailsServer) error
+	// challenge to sign for GetMails, see ProveOwnership
+	GetChallenge(context.Context, *GetChallengeRequest) (*GetChallengeResponse, error)
+	GetMails(Mailbox_GetMailsServer) error
+	// private information retrieval version of GetMails
+	PIRGetMails(Mailbox_PIRGetMailsServer) error
+	// mails of some users as they are delivered, see subscribe.go
+	Subscribe(*SubscribeRequest, Mailbox_SubscribeServer) error
+	// per chain delivery counts, to find the chains that dropped mails
+	ChainReport(context.Context, *ChainReportRequest) (*ChainReportResponse, error)
+	// deliveries rejected by the integrity checks of DeliverMails
+	GetAnomalies(context.Context, *GetAnomaliesRequest) (*GetAnomaliesResponse, error)
+	// inboxes replicated at another mailbox, to catch up after downtime
+	Sync(*SyncRequest, Mailbox_SyncServer) error
+	// blind signatures of the submission tokens of the users, see tokens.go
+	IssueTokens(context.Context, *IssueTokensRequest) (*IssueTokensResponse, error)
+	IssuedTokens(context.Context, *IssuedTokensRequest) (*IssuedTokensResponse, error)
+}
+
+func RegisterMailboxServer(s *grpc.Server, srv MailboxServer) {
+	s.RegisterService(&_Mailbox_serviceDesc, srv)
+}
+
+func _Mailbox_NewRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailboxServer).NewRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mailbox.Mailbox/NewRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailboxServer).NewRound(ctx, req.(*NewRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mailbox_EndRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailboxServer).EndRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mailbox.Mailbox/EndRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailboxServer).EndRound(ctx, req.(*EndRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mailbox_RegisterUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MailboxServer).RegisterUsers(&mailboxRegisterUsersServer{stream})
+}
+
+type Mailbox_RegisterUsersServer interface {
+	SendAndClose(*RegisterUsersResponse) error
+	Recv() (*RegisterUsersRequest, error)
+	grpc.ServerStream
+}
+
+type mailboxRegisterUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *mailboxRegisterUsersServer) SendAndClose(m *RegisterUsersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mailboxRegisterUsersServer) Recv() (*RegisterUsersRequest, error) {
+	m := new(RegisterUsersRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Mailbox_RegisteredUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RegisteredUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MailboxServer).RegisteredUsers(m, &mailboxRegisteredUsersServer{stream})
 }
 
-func (m *GetMailsRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 9
+type Mailbox_RegisteredUsersServer interface {
+	Send(*RegisteredUsersResponse) error
+	grpc.ServerStream
+}
+
+type mailboxRegisteredUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *mailboxRegisteredUsersServer) Send(m *RegisteredUsersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mailbox_DeliverMails_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MailboxServer).DeliverMails(&mailboxDeliverMailsServer{stream})
+}
+
+type Mailbox_DeliverMailsServer interface {
+	SendAndClose(*DeliverMailsResponse) error
+	Recv() (*DeliverMailsRequest, error)
+	grpc.ServerStream
+}
+
+type mailboxDeliverMailsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mailboxDeliverMailsServer) SendAndClose(m *DeliverMailsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mailboxDeliverMailsServer) Recv() (*DeliverMailsRequest, error) {
+	m := new(DeliverMailsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Mailbox_GetChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailboxServer).GetChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mailbox.Mailbox/GetChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailboxServer).GetChallenge(ctx, req.(*GetChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mailbox_GetMails_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MailboxServer).GetMails(&mailboxGetMailsServer{stream})
+}
+
+type Mailbox_GetMailsServer interface {
+	Send(*GetMailsResponse) error
+	Recv() (*GetMailsRequest, error)
+	grpc.ServerStream
+}
+
+type mailboxGetMailsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mailboxGetMailsServer) Send(m *GetMailsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mailboxGetMailsServer) Recv() (*GetMailsRequest, error) {
+	m := new(GetMailsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Mailbox_PIRGetMails_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MailboxServer).PIRGetMails(&mailboxPIRGetMailsServer{stream})
+}
+
+type Mailbox_PIRGetMailsServer interface {
+	Send(*PIRGetMailsResponse) error
+	Recv() (*PIRGetMailsRequest, error)
+	grpc.ServerStream
+}
+
+type mailboxPIRGetMailsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mailboxPIRGetMailsServer) Send(m *PIRGetMailsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mailboxPIRGetMailsServer) Recv() (*PIRGetMailsRequest, error) {
+	m := new(PIRGetMailsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Mailbox_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MailboxServer).Subscribe(m, &mailboxSubscribeServer{stream})
+}
+
+type Mailbox_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type mailboxSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *mailboxSubscribeServer) Send(m *SubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mailbox_ChainReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailboxServer).ChainReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mailbox.Mailbox/ChainReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailboxServer).ChainReport(ctx, req.(*ChainReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mailbox_GetAnomalies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnomaliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailboxServer).GetAnomalies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mailbox.Mailbox/GetAnomalies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailboxServer).GetAnomalies(ctx, req.(*GetAnomaliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mailbox_Sync_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MailboxServer).Sync(m, &mailboxSyncServer{stream})
+}
+
+type Mailbox_SyncServer interface {
+	Send(*SyncResponse) error
+	grpc.ServerStream
+}
+
+type mailboxSyncServer struct {
+	grpc.ServerStream
+}
+
+func (x *mailboxSyncServer) Send(m *SyncResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mailbox_IssueTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailboxServer).IssueTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mailbox.Mailbox/IssueTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailboxServer).IssueTokens(ctx, req.(*IssueTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mailbox_IssuedTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuedTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailboxServer).IssuedTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mailbox.Mailbox/IssuedTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailboxServer).IssuedTokens(ctx, req.(*IssuedTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Mailbox_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "mailbox.Mailbox",
+	HandlerType: (*MailboxServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NewRound",
+			Handler:    _Mailbox_NewRound_Handler,
+		},
+		{
+			MethodName: "EndRound",
+			Handler:    _Mailbox_EndRound_Handler,
+		},
+		{
+			MethodName: "GetChallenge",
+			Handler:    _Mailbox_GetChallenge_Handler,
+		},
+		{
+			MethodName: "ChainReport",
+			Handler:    _Mailbox_ChainReport_Handler,
+		},
+		{
+			MethodName: "GetAnomalies",
+			Handler:    _Mailbox_GetAnomalies_Handler,
+		},
+		{
+			MethodName: "IssueTokens",
+			Handler:    _Mailbox_IssueTokens_Handler,
+		},
+		{
+			MethodName: "IssuedTokens",
+			Handler:    _Mailbox_IssuedTokens_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RegisterUsers",
+			Handler:       _Mailbox_RegisterUsers_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "RegisteredUsers",
+			Handler:       _Mailbox_RegisteredUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DeliverMails",
+			Handler:       _Mailbox_DeliverMails_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetMails",
+			Handler:       _Mailbox_GetMails_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PIRGetMails",
+			Handler:       _Mailbox_PIRGetMails_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Mailbox_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Sync",
+			Handler:       _Mailbox_Sync_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mailbox.proto",
+}
+
+func (m *NewRoundRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NewRoundRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if m.Timeout != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Timeout))
+		i += 8
+	}
+	if len(m.TokenKey) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.TokenKey)))
+		i += copy(dAtA[i:], m.TokenKey)
+	}
+	return i, nil
+}
+
+func (m *NewRoundResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NewRoundResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *EndRoundRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndRoundRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *EndRoundResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndRoundResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *RegisterUsersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisterUsersRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Expected) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Expected)*8))
+		for _, num := range m.Expected {
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(num))
+			i += 8
+		}
+	}
+	if len(m.Chains) > 0 {
+		for _, msg := range m.Chains {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Capacity) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Capacity)*8))
+		for _, num := range m.Capacity {
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(num))
+			i += 8
+		}
+	}
+	return i, nil
+}
+
+func (m *Chains) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Chains) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Gids) > 0 {
+		for _, s := range m.Gids {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *RegisterUsersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisterUsersResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *RegisteredUsersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisteredUsersRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *RegisteredUsersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisteredUsersResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *Inbox) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Inbox) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UserKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.UserKey)))
+		i += copy(dAtA[i:], m.UserKey)
+	}
+	if len(m.Messages) > 0 {
+		for _, b := range m.Messages {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.Expected != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Expected))
+		i += 8
+	}
+	if m.Received != 0 {
+		dAtA[i] = 0x21
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Received))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *Mail) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Mail) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UserKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.UserKey)))
+		i += copy(dAtA[i:], m.UserKey)
+	}
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	return i, nil
+}
+
+func (m *DeliverMailsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeliverMailsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.Mails) > 0 {
+		for _, msg := range m.Mails {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Gid) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Gid)))
+		i += copy(dAtA[i:], m.Gid)
+	}
+	if len(m.Server) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Server)))
+		i += copy(dAtA[i:], m.Server)
+	}
+	return i, nil
+}
+
+func (m *DeliverMailsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeliverMailsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetChallengeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetChallengeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *GetChallengeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetChallengeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Challenge) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Challenge)))
+		i += copy(dAtA[i:], m.Challenge)
+	}
+	return i, nil
+}
+
+func (m *GetMailsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetMailsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Proofs) > 0 {
+		for _, b := range m.Proofs {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Wait) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Wait)*8))
+		for _, num := range m.Wait {
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(num))
+			i += 8
+		}
+	}
+	return i, nil
+}
+
+func (m *GetMailsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetMailsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Inboxes) > 0 {
+		for _, msg := range m.Inboxes {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PIRGetMailsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PIRGetMailsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.Queries) > 0 {
+		for _, b := range m.Queries {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *PIRGetMailsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PIRGetMailsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Answers) > 0 {
+		for _, b := range m.Answers {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Proofs) > 0 {
+		for _, b := range m.Proofs {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *SubscribeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.Mails) > 0 {
+		for _, msg := range m.Mails {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ChainReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if m.UnderDelivered {
+		dAtA[i] = 0x10
+		i++
+		if m.UnderDelivered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ChainDelivery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainDelivery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Gid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Gid)))
+		i += copy(dAtA[i:], m.Gid)
+	}
+	if len(m.Servers) > 0 {
+		for _, s := range m.Servers {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Expected != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Expected))
+		i += 8
+	}
+	if m.Missing != 0 {
+		dAtA[i] = 0x21
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Missing))
+		i += 8
+	}
+	if m.Received != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Received))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *ChainReportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainReportResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for _, msg := range m.Chains {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *GetAnomaliesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAnomaliesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *Anomaly) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Anomaly) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Gid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Gid)))
+		i += copy(dAtA[i:], m.Gid)
+	}
+	if len(m.Server) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Server)))
+		i += copy(dAtA[i:], m.Server)
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.Mails != 0 {
+		dAtA[i] = 0x21
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Mails))
+		i += 8
+	}
+	if m.Time != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Time))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *GetAnomaliesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAnomaliesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Anomalies) > 0 {
+		for _, msg := range m.Anomalies {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SyncRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if m.Since != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Since))
+		i += 8
+	}
+	if m.Before != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Before))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *SyncResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if m.Created != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Created))
+		i += 8
+	}
+	if len(m.Inboxes) > 0 {
+		for _, msg := range m.Inboxes {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *IssueTokensRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IssueTokensRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.Users) > 0 {
+		for _, msg := range m.Users {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *TokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UserKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.UserKey)))
+		i += copy(dAtA[i:], m.UserKey)
+	}
+	if len(m.Proof) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Proof)))
+		i += copy(dAtA[i:], m.Proof)
+	}
+	if len(m.Gids) > 0 {
+		for _, s := range m.Gids {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Blinded) > 0 {
+		for _, b := range m.Blinded {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *IssueTokensResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IssueTokensResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Users) > 0 {
+		for _, msg := range m.Users {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *TokenSignatures) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenSignatures) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *IssuedTokensRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IssuedTokensRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *TokenIssuance) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenIssuance) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Gid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMailbox(dAtA, i, uint64(len(m.Gid)))
+		i += copy(dAtA[i:], m.Gid)
+	}
+	if m.Issued != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Issued))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *IssuedTokensResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IssuedTokensResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for _, msg := range m.Chains {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMailbox(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeVarintMailbox(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *NewRoundRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if m.Timeout != 0 {
+		n += 9
+	}
+	l = len(m.TokenKey)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	return n
+}
+
+func (m *NewRoundResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *EndRoundRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *EndRoundResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RegisterUsersRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if len(m.Expected) > 0 {
+		n += 1 + sovMailbox(uint64(len(m.Expected)*8)) + len(m.Expected)*8
+	}
+	if len(m.Chains) > 0 {
+		for _, e := range m.Chains {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if len(m.Capacity) > 0 {
+		n += 1 + sovMailbox(uint64(len(m.Capacity)*8)) + len(m.Capacity)*8
+	}
+	return n
+}
+
+func (m *Chains) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Gids) > 0 {
+		for _, s := range m.Gids {
+			l = len(s)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RegisterUsersResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RegisteredUsersRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *RegisteredUsersResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Inbox) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.UserKey)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	if len(m.Messages) > 0 {
+		for _, b := range m.Messages {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if m.Expected != 0 {
+		n += 9
+	}
+	if m.Received != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *Mail) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.UserKey)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	return n
+}
+
+func (m *DeliverMailsRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if len(m.Mails) > 0 {
+		for _, e := range m.Mails {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	l = len(m.Gid)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	l = len(m.Server)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	return n
+}
+
+func (m *DeliverMailsResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetChallengeRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *GetChallengeResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Challenge)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	return n
+}
+
+func (m *GetMailsRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if len(m.Proofs) > 0 {
+		for _, b := range m.Proofs {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if len(m.Wait) > 0 {
+		n += 1 + sovMailbox(uint64(len(m.Wait)*8)) + len(m.Wait)*8
+	}
+	return n
+}
+
+func (m *GetMailsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Inboxes) > 0 {
+		for _, e := range m.Inboxes {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PIRGetMailsRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if len(m.Queries) > 0 {
+		for _, b := range m.Queries {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PIRGetMailsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Answers) > 0 {
+		for _, b := range m.Answers {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubscribeRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if len(m.UserKeys) > 0 {
+		for _, b := range m.UserKeys {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if len(m.Proofs) > 0 {
+		for _, b := range m.Proofs {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubscribeResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if len(m.Mails) > 0 {
+		for _, e := range m.Mails {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ChainReportRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if m.UnderDelivered {
+		n += 2
+	}
+	return n
+}
+
+func (m *ChainDelivery) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Gid)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	if len(m.Servers) > 0 {
+		for _, s := range m.Servers {
+			l = len(s)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if m.Expected != 0 {
+		n += 9
+	}
+	if m.Missing != 0 {
+		n += 9
+	}
+	if m.Received != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *ChainReportResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for _, e := range m.Chains {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetAnomaliesRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *Anomaly) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Gid)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	l = len(m.Server)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	if m.Mails != 0 {
+		n += 9
+	}
+	if m.Time != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *GetAnomaliesResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Anomalies) > 0 {
+		for _, e := range m.Anomalies {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SyncRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	if m.Since != 0 {
+		n += 9
+	}
+	if m.Before != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *SyncResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if m.Created != 0 {
+		n += 9
+	}
+	if len(m.Inboxes) > 0 {
+		for _, e := range m.Inboxes {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IssueTokensRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	if len(m.Users) > 0 {
+		for _, e := range m.Users {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TokenRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.UserKey)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	l = len(m.Proof)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	if len(m.Gids) > 0 {
+		for _, s := range m.Gids {
+			l = len(s)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	if len(m.Blinded) > 0 {
+		for _, b := range m.Blinded {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IssueTokensResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Users) > 0 {
+		for _, e := range m.Users {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TokenSignatures) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			l = len(b)
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IssuedTokensRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *TokenIssuance) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Gid)
+	if l > 0 {
+		n += 1 + l + sovMailbox(uint64(l))
+	}
+	if m.Issued != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *IssuedTokensResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for _, e := range m.Chains {
+			l = e.Size()
+			n += 1 + l + sovMailbox(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovMailbox(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozMailbox(x uint64) (n int) {
+	return sovMailbox(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NewRoundRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewRoundRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewRoundRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeout = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenKey = append(m.TokenKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.TokenKey == nil {
+				m.TokenKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NewRoundResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewRoundResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewRoundResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndRoundRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndRoundRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndRoundRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndRoundResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndRoundResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndRoundResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterUsersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserKeys = append(m.UserKeys, make([]byte, postIndex-iNdEx))
+			copy(m.UserKeys[len(m.UserKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				m.Expected = append(m.Expected, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMailbox
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthMailbox
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					m.Expected = append(m.Expected, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chains", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chains = append(m.Chains, &Chains{})
+			if err := m.Chains[len(m.Chains)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				m.Capacity = append(m.Capacity, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMailbox
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthMailbox
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					m.Capacity = append(m.Capacity, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Chains) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Chains: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Chains: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gids", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gids = append(m.Gids, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterUsersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisteredUsersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisteredUsersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisteredUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisteredUsersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisteredUsersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisteredUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserKeys = append(m.UserKeys, make([]byte, postIndex-iNdEx))
+			copy(m.UserKeys[len(m.UserKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Inbox) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Inbox: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Inbox: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserKey = append(m.UserKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.UserKey == nil {
+				m.UserKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, make([]byte, postIndex-iNdEx))
+			copy(m.Messages[len(m.Messages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			m.Expected = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expected = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Received", wireType)
+			}
+			m.Received = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Received = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Mail) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Mail: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Mail: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserKey = append(m.UserKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.UserKey == nil {
+				m.UserKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = append(m.Message[:0], dAtA[iNdEx:postIndex]...)
+			if m.Message == nil {
+				m.Message = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeliverMailsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeliverMailsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeliverMailsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mails", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mails = append(m.Mails, &Mail{})
+			if err := m.Mails[len(m.Mails)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Server", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Server = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeliverMailsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeliverMailsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeliverMailsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetChallengeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetChallengeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetChallengeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetChallengeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetChallengeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetChallengeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Challenge", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Challenge = append(m.Challenge[:0], dAtA[iNdEx:postIndex]...)
+			if m.Challenge == nil {
+				m.Challenge = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetMailsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetMailsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetMailsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserKeys = append(m.UserKeys, make([]byte, postIndex-iNdEx))
+			copy(m.UserKeys[len(m.UserKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proofs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proofs = append(m.Proofs, make([]byte, postIndex-iNdEx))
+			copy(m.Proofs[len(m.Proofs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				m.Wait = append(m.Wait, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMailbox
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthMailbox
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					m.Wait = append(m.Wait, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Wait", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetMailsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetMailsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetMailsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inboxes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Inboxes = append(m.Inboxes, &Inbox{})
+			if err := m.Inboxes[len(m.Inboxes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PIRGetMailsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PIRGetMailsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PIRGetMailsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Queries", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Queries = append(m.Queries, make([]byte, postIndex-iNdEx))
+			copy(m.Queries[len(m.Queries)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PIRGetMailsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PIRGetMailsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PIRGetMailsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Answers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Answers = append(m.Answers, make([]byte, postIndex-iNdEx))
+			copy(m.Answers[len(m.Answers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserKeys = append(m.UserKeys, make([]byte, postIndex-iNdEx))
+			copy(m.UserKeys[len(m.UserKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proofs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proofs = append(m.Proofs, make([]byte, postIndex-iNdEx))
+			copy(m.Proofs[len(m.Proofs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	if len(m.UserKeys) > 0 {
-		for _, b := range m.UserKeys {
-			l = len(b)
-			n += 1 + l + sovMailbox(uint64(l))
+	return nil
+}
+func (m *SubscribeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mails", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mails = append(m.Mails, &Mail{})
+			if err := m.Mails[len(m.Mails)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func (m *GetMailsResponse) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Inboxes) > 0 {
-		for _, e := range m.Inboxes {
-			l = e.Size()
-			n += 1 + l + sovMailbox(uint64(l))
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func sovMailbox(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *ChainReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMailbox
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnderDelivered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UnderDelivered = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMailbox(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-func sozMailbox(x uint64) (n int) {
-	return sovMailbox(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *NewRoundRequest) Unmarshal(dAtA []byte) error {
+func (m *ChainDelivery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1228,31 +5451,109 @@ func (m *NewRoundRequest) Unmarshal(dAtA []byte) error {
 			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainDelivery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainDelivery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Servers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Servers = append(m.Servers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			m.Expected = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expected = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Missing", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: NewRoundRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NewRoundRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Missing = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Missing = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 5:
 			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Received", wireType)
 			}
-			m.Round = 0
+			m.Received = 0
 			if (iNdEx + 8) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			m.Received = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
 		default:
 			iNdEx = preIndex
@@ -1275,7 +5576,7 @@ func (m *NewRoundRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NewRoundResponse) Unmarshal(dAtA []byte) error {
+func (m *ChainReportResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1298,12 +5599,43 @@ func (m *NewRoundResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NewRoundResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChainReportResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NewRoundResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChainReportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chains", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chains = append(m.Chains, &ChainDelivery{})
+			if err := m.Chains[len(m.Chains)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMailbox(dAtA[iNdEx:])
@@ -1325,7 +5657,7 @@ func (m *NewRoundResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EndRoundRequest) Unmarshal(dAtA []byte) error {
+func (m *GetAnomaliesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1348,10 +5680,10 @@ func (m *EndRoundRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EndRoundRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAnomaliesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EndRoundRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAnomaliesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1385,7 +5717,7 @@ func (m *EndRoundRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EndRoundResponse) Unmarshal(dAtA []byte) error {
+func (m *Anomaly) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1408,12 +5740,119 @@ func (m *EndRoundResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EndRoundResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Anomaly: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EndRoundResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Anomaly: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Server", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Server = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mails", wireType)
+			}
+			m.Mails = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mails = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Time = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMailbox(dAtA[iNdEx:])
@@ -1435,7 +5874,7 @@ func (m *EndRoundResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
+func (m *GetAnomaliesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1458,27 +5897,17 @@ func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegisterUsersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAnomaliesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegisterUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAnomaliesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
-			}
-			m.Round = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Anomalies", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMailbox
@@ -1488,65 +5917,23 @@ func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthMailbox
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserKeys = append(m.UserKeys, make([]byte, postIndex-iNdEx))
-			copy(m.UserKeys[len(m.UserKeys)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType == 1 {
-				var v uint64
-				if (iNdEx + 8) > l {
-					return io.ErrUnexpectedEOF
-				}
-				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-				iNdEx += 8
-				m.Expected = append(m.Expected, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowMailbox
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthMailbox
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					if (iNdEx + 8) > l {
-						return io.ErrUnexpectedEOF
-					}
-					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-					iNdEx += 8
-					m.Expected = append(m.Expected, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			m.Anomalies = append(m.Anomalies, &Anomaly{})
+			if err := m.Anomalies[len(m.Anomalies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMailbox(dAtA[iNdEx:])
@@ -1568,7 +5955,7 @@ func (m *RegisterUsersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
+func (m *SyncRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1587,16 +5974,65 @@ func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RegisterUsersResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegisterUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Since = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			m.Before = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Before = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMailbox(dAtA[iNdEx:])
@@ -1618,7 +6054,7 @@ func (m *RegisterUsersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegisteredUsersRequest) Unmarshal(dAtA []byte) error {
+func (m *SyncResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1641,10 +6077,10 @@ func (m *RegisteredUsersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegisteredUsersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SyncResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegisteredUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SyncResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1657,6 +6093,47 @@ func (m *RegisteredUsersRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			m.Created = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Created = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inboxes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Inboxes = append(m.Inboxes, &Inbox{})
+			if err := m.Inboxes[len(m.Inboxes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMailbox(dAtA[iNdEx:])
@@ -1678,7 +6155,7 @@ func (m *RegisteredUsersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegisteredUsersResponse) Unmarshal(dAtA []byte) error {
+func (m *IssueTokensRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1701,17 +6178,27 @@ func (m *RegisteredUsersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegisteredUsersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: IssueTokensRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegisteredUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IssueTokensRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Users", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMailbox
@@ -1721,20 +6208,22 @@ func (m *RegisteredUsersResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthMailbox
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserKeys = append(m.UserKeys, make([]byte, postIndex-iNdEx))
-			copy(m.UserKeys[len(m.UserKeys)-1], dAtA[iNdEx:postIndex])
+			m.Users = append(m.Users, &TokenRequest{})
+			if err := m.Users[len(m.Users)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1757,7 +6246,7 @@ func (m *RegisteredUsersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Inbox) Unmarshal(dAtA []byte) error {
+func (m *TokenRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1780,10 +6269,10 @@ func (m *Inbox) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Inbox: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Inbox: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1819,7 +6308,7 @@ func (m *Inbox) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1843,8 +6332,68 @@ func (m *Inbox) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Messages = append(m.Messages, make([]byte, postIndex-iNdEx))
-			copy(m.Messages[len(m.Messages)-1], dAtA[iNdEx:postIndex])
+			m.Proof = append(m.Proof[:0], dAtA[iNdEx:postIndex]...)
+			if m.Proof == nil {
+				m.Proof = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gids", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gids = append(m.Gids, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blinded", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMailbox
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMailbox
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Blinded = append(m.Blinded, make([]byte, postIndex-iNdEx))
+			copy(m.Blinded[len(m.Blinded)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1867,7 +6416,7 @@ func (m *Inbox) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Mail) Unmarshal(dAtA []byte) error {
+func (m *IssueTokensResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1890,48 +6439,17 @@ func (m *Mail) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Mail: wiretype end group for non-group")
+			return fmt.Errorf("proto: IssueTokensResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Mail: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IssueTokensResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserKey", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMailbox
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthMailbox
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.UserKey = append(m.UserKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.UserKey == nil {
-				m.UserKey = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Users", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMailbox
@@ -1941,21 +6459,21 @@ func (m *Mail) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthMailbox
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = append(m.Message[:0], dAtA[iNdEx:postIndex]...)
-			if m.Message == nil {
-				m.Message = []byte{}
+			m.Users = append(m.Users, &TokenSignatures{})
+			if err := m.Users[len(m.Users)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -1979,7 +6497,7 @@ func (m *Mail) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeliverMailsRequest) Unmarshal(dAtA []byte) error {
+func (m *TokenSignatures) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2002,27 +6520,17 @@ func (m *DeliverMailsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeliverMailsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenSignatures: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeliverMailsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenSignatures: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
-			}
-			m.Round = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mails", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMailbox
@@ -2032,22 +6540,20 @@ func (m *DeliverMailsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthMailbox
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Mails = append(m.Mails, &Mail{})
-			if err := m.Mails[len(m.Mails)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
+			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2070,7 +6576,7 @@ func (m *DeliverMailsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeliverMailsResponse) Unmarshal(dAtA []byte) error {
+func (m *IssuedTokensRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2093,12 +6599,22 @@ func (m *DeliverMailsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeliverMailsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: IssuedTokensRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeliverMailsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IssuedTokensRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMailbox(dAtA[iNdEx:])
@@ -2120,7 +6636,7 @@ func (m *DeliverMailsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetMailsRequest) Unmarshal(dAtA []byte) error {
+func (m *TokenIssuance) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2143,27 +6659,17 @@ func (m *GetMailsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetMailsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenIssuance: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMailsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenIssuance: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
-			}
-			m.Round = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMailbox
@@ -2173,21 +6679,31 @@ func (m *GetMailsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMailbox
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserKeys = append(m.UserKeys, make([]byte, postIndex-iNdEx))
-			copy(m.UserKeys[len(m.UserKeys)-1], dAtA[iNdEx:postIndex])
+			m.Gid = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Issued", wireType)
+			}
+			m.Issued = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Issued = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMailbox(dAtA[iNdEx:])
@@ -2209,7 +6725,7 @@ func (m *GetMailsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetMailsResponse) Unmarshal(dAtA []byte) error {
+func (m *IssuedTokensResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2232,15 +6748,15 @@ func (m *GetMailsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetMailsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: IssuedTokensResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMailsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IssuedTokensResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Inboxes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chains", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2264,8 +6780,8 @@ func (m *GetMailsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Inboxes = append(m.Inboxes, &Inbox{})
-			if err := m.Inboxes[len(m.Inboxes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Chains = append(m.Chains, &TokenIssuance{})
+			if err := m.Chains[len(m.Chains)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2398,35 +6914,81 @@ var (
 func init() { proto.RegisterFile("mailbox.proto", fileDescriptorMailbox) }
 
 var fileDescriptorMailbox = []byte{
-	// 468 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x8b, 0xd3, 0x40,
-	0x18, 0xee, 0x6c, 0x6c, 0x13, 0xdf, 0x6d, 0x6d, 0x19, 0xeb, 0x6e, 0x36, 0xba, 0x31, 0x8c, 0x07,
-	0x73, 0x2a, 0x4b, 0x05, 0x2f, 0x8a, 0xa0, 0xec, 0x22, 0x22, 0xab, 0x32, 0x20, 0x78, 0x93, 0xd6,
-	0xbc, 0x2c, 0xc1, 0x36, 0xa9, 0x99, 0x54, 0xdb, 0x7f, 0xe2, 0x4f, 0xf2, 0xe8, 0xc5, 0xbb, 0xd4,
-	0x3f, 0x22, 0xd3, 0xcc, 0xa4, 0x49, 0xfa, 0x79, 0x7c, 0xf3, 0x7c, 0xcc, 0x3b, 0xcf, 0x3c, 0x04,
-	0x5a, 0xe3, 0x41, 0x38, 0x1a, 0xc6, 0xb3, 0xde, 0x24, 0x89, 0xd3, 0x98, 0x9a, 0x6a, 0x64, 0x8f,
-	0xa1, 0xfd, 0x0e, 0x7f, 0xf0, 0x78, 0x1a, 0x05, 0x1c, 0xbf, 0x4d, 0x51, 0xa4, 0xb4, 0x0b, 0xf5,
-	0x44, 0xce, 0x36, 0xf1, 0x88, 0xdf, 0xe0, 0xd9, 0xc0, 0x28, 0x74, 0x56, 0x44, 0x31, 0x89, 0x23,
-	0x81, 0x52, 0x7c, 0x15, 0x05, 0x87, 0x89, 0x57, 0x44, 0x25, 0x46, 0xe8, 0x72, 0xbc, 0x09, 0x45,
-	0x8a, 0xc9, 0x47, 0x81, 0x89, 0xd8, 0xe9, 0x40, 0xef, 0xc3, 0xed, 0xa9, 0xc0, 0xe4, 0xf3, 0x57,
-	0x9c, 0x0b, 0xfb, 0xc8, 0x33, 0xfc, 0x26, 0xb7, 0xe4, 0x87, 0xb7, 0x38, 0x17, 0xd4, 0x01, 0x0b,
-	0x67, 0x13, 0xfc, 0x92, 0x62, 0x60, 0x1b, 0x9e, 0xe1, 0x37, 0x78, 0x3e, 0xb3, 0x53, 0xb8, 0x57,
-	0x39, 0x46, 0x9d, 0xdf, 0x83, 0x13, 0x0d, 0x60, 0xb0, 0x7f, 0x03, 0xf6, 0x14, 0x4e, 0xd7, 0xf8,
-	0x99, 0x55, 0x79, 0x39, 0x52, 0x5e, 0x8e, 0xbd, 0x80, 0xfa, 0x9b, 0x68, 0x18, 0xcf, 0xe8, 0x19,
-	0x58, 0x9a, 0xb5, 0x74, 0x6e, 0x72, 0x53, 0x91, 0xe4, 0x05, 0xc6, 0x28, 0xc4, 0xe0, 0x06, 0xf3,
-	0xcb, 0xe9, 0x99, 0x3d, 0x83, 0x5b, 0xd7, 0x83, 0x70, 0xb4, 0x4b, 0x6e, 0x83, 0xa9, 0xe8, 0xf6,
-	0x51, 0x86, 0xa8, 0x91, 0x7d, 0x80, 0xbb, 0x97, 0x38, 0x0a, 0xbf, 0x63, 0x22, 0x3d, 0xf6, 0x64,
-	0xfc, 0x08, 0xea, 0xb2, 0x16, 0xd9, 0x0a, 0xc7, 0xfd, 0x56, 0x4f, 0x77, 0x46, 0x6a, 0x79, 0x86,
-	0xb1, 0x13, 0xe8, 0x96, 0x1d, 0x55, 0x9c, 0x97, 0xd0, 0x7e, 0x8d, 0xe9, 0x01, 0xa7, 0xec, 0x7a,
-	0x49, 0xf6, 0x1c, 0x3a, 0x2b, 0x17, 0x95, 0xae, 0x0f, 0x66, 0x28, 0x03, 0xc4, 0x2c, 0xdb, 0xe3,
-	0xfe, 0x9d, 0x7c, 0xb1, 0x65, 0xb0, 0x5c, 0xc3, 0xfd, 0x3f, 0x06, 0x98, 0xd7, 0x19, 0x44, 0x5f,
-	0x82, 0xa5, 0xfb, 0x4a, 0xed, 0x5c, 0x50, 0xe9, 0xba, 0x73, 0xb6, 0x01, 0x51, 0x17, 0xaa, 0x49,
-	0x0b, 0xdd, 0xda, 0x82, 0x45, 0xa5, 0xf1, 0x05, 0x8b, 0xb5, 0x8a, 0xd7, 0x28, 0x87, 0x56, 0xa9,
-	0x7d, 0xf4, 0x3c, 0x67, 0x6f, 0x2a, 0xbf, 0xe3, 0x6e, 0x83, 0xb5, 0xa3, 0x4f, 0xe8, 0x27, 0x68,
-	0x57, 0x8a, 0x48, 0x1f, 0xae, 0xc9, 0xca, 0x95, 0x76, 0xbc, 0xed, 0x04, 0xed, 0x7c, 0x41, 0xe8,
-	0x7b, 0x68, 0x16, 0xdf, 0x96, 0x3e, 0xc8, 0x55, 0x1b, 0x4a, 0xe4, 0x9c, 0x6f, 0x41, 0x0b, 0xab,
-	0x5e, 0x81, 0xa5, 0x9f, 0xb3, 0x90, 0x60, 0xa5, 0x27, 0x85, 0x04, 0xab, 0x6f, 0x2f, 0x4d, 0x2e,
-	0xc8, 0xab, 0xce, 0xaf, 0x85, 0x4b, 0x7e, 0x2f, 0x5c, 0xf2, 0x77, 0xe1, 0x92, 0x9f, 0xff, 0xdc,
-	0xda, 0xb0, 0xb1, 0xfc, 0x8d, 0x3d, 0xf9, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x46, 0xa6, 0x09, 0x11,
-	0xd7, 0x04, 0x00, 0x00,
+	// 1204 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0xde, 0x89, 0xe3, 0xbf, 0xb2, 0xb3, 0x36, 0x1d, 0x6f, 0x76, 0x32, 0x71, 0x8c, 0xd5, 0x1c,
+	0x62, 0x29, 0x52, 0x58, 0x02, 0x02, 0x21, 0xb8, 0x2c, 0x9b, 0xec, 0x2a, 0x44, 0x09, 0xa8, 0x03,
+	0x82, 0x0b, 0xac, 0xc6, 0x9e, 0x5e, 0x67, 0xb4, 0xce, 0x8c, 0x77, 0x7a, 0x9c, 0xc4, 0xbc, 0x03,
+	0x77, 0x9e, 0x01, 0x5e, 0x84, 0x23, 0x8f, 0x80, 0xc2, 0x8b, 0xa0, 0xfe, 0x9d, 0x9f, 0x8c, 0xed,
+	0x08, 0x71, 0x9b, 0xaf, 0xaa, 0xba, 0xba, 0xaa, 0xba, 0xfe, 0x06, 0x36, 0xae, 0x5c, 0x7f, 0x32,
+	0x0c, 0x6f, 0x0f, 0xa6, 0x51, 0x18, 0x87, 0xa8, 0xaa, 0x20, 0xfe, 0x19, 0x5a, 0xe7, 0xf4, 0x86,
+	0x84, 0xb3, 0xc0, 0x23, 0xf4, 0xdd, 0x8c, 0xb2, 0x18, 0x75, 0xa0, 0x1c, 0x71, 0x6c, 0x5b, 0x7d,
+	0x6b, 0x50, 0x21, 0x12, 0x20, 0x1b, 0xaa, 0xb1, 0x7f, 0x45, 0xc3, 0x59, 0x6c, 0xaf, 0x09, 0xba,
+	0x86, 0x68, 0x07, 0xea, 0x71, 0xf8, 0x96, 0x06, 0xaf, 0xdf, 0xd2, 0xb9, 0x5d, 0xea, 0x5b, 0x83,
+	0x26, 0xa9, 0x09, 0xc2, 0x29, 0x9d, 0x63, 0x04, 0xed, 0x44, 0x3f, 0x9b, 0x86, 0x01, 0xa3, 0x78,
+	0x0f, 0x5a, 0xc7, 0x81, 0xb7, 0xfa, 0x4e, 0x7e, 0x38, 0x11, 0x54, 0x87, 0x7f, 0xb7, 0xa0, 0x43,
+	0xe8, 0xd8, 0x67, 0x31, 0x8d, 0xbe, 0x67, 0x34, 0x62, 0xcb, 0xcd, 0xde, 0x81, 0xfa, 0x8c, 0xd1,
+	0x88, 0xdb, 0xc6, 0xec, 0xb5, 0x7e, 0x89, 0x1b, 0xc7, 0x09, 0xa7, 0x74, 0xce, 0x90, 0x03, 0x35,
+	0x7a, 0x3b, 0xa5, 0xa3, 0x98, 0x7a, 0x76, 0xa9, 0x5f, 0x1a, 0x54, 0x88, 0xc1, 0x68, 0x0f, 0x2a,
+	0xa3, 0x4b, 0xd7, 0x0f, 0x98, 0xbd, 0xde, 0x2f, 0x0d, 0x1a, 0x87, 0xad, 0x03, 0x1d, 0xc1, 0x17,
+	0x82, 0x4c, 0x14, 0x9b, 0x2b, 0x19, 0xb9, 0x53, 0x77, 0xe4, 0xc7, 0x73, 0xbb, 0x2c, 0x95, 0x68,
+	0x8c, 0xbb, 0x50, 0x91, 0xd2, 0x08, 0xc1, 0xfa, 0xd8, 0xf7, 0x98, 0x6d, 0xf5, 0x4b, 0x83, 0x3a,
+	0x11, 0xdf, 0xf8, 0x29, 0x3c, 0xc9, 0x79, 0xa2, 0x7c, 0x3c, 0x80, 0x2d, 0xcd, 0xa0, 0xde, 0x6a,
+	0x27, 0xf1, 0xa7, 0xf0, 0xf4, 0x9e, 0xbc, 0x54, 0x95, 0xf5, 0xdf, 0xca, 0xfa, 0x8f, 0xaf, 0xa1,
+	0x7c, 0x12, 0x0c, 0xc3, 0x5b, 0xb4, 0x0d, 0x35, 0x2d, 0x25, 0x34, 0x37, 0x49, 0x55, 0x09, 0x71,
+	0xf7, 0xae, 0x28, 0x63, 0xee, 0x98, 0x9a, 0xf8, 0x69, 0x9c, 0x8b, 0x9f, 0x95, 0x89, 0x9f, 0x03,
+	0xb5, 0x88, 0x8e, 0xa8, 0x7f, 0x4d, 0x3d, 0x7b, 0x5d, 0xf2, 0x34, 0xc6, 0x5f, 0xc0, 0xfa, 0x99,
+	0xeb, 0x4f, 0x96, 0x5d, 0x6b, 0x43, 0x55, 0x5d, 0x23, 0xd2, 0xad, 0x49, 0x34, 0xc4, 0xbf, 0xc0,
+	0xe6, 0x11, 0x9d, 0xf8, 0xd7, 0x34, 0xe2, 0x3a, 0x56, 0x3c, 0xff, 0x07, 0x50, 0xe6, 0xcf, 0x26,
+	0x4d, 0x6f, 0x1c, 0x6e, 0x98, 0x47, 0xe4, 0x67, 0x89, 0xe4, 0xa1, 0x36, 0x94, 0xc6, 0xbe, 0xf4,
+	0xa0, 0x4e, 0xf8, 0x27, 0xda, 0x82, 0x0a, 0xa3, 0xd1, 0x35, 0x8d, 0x84, 0xe9, 0x75, 0xa2, 0x10,
+	0xde, 0x82, 0x4e, 0xf6, 0x6e, 0xf5, 0x60, 0xfb, 0xb0, 0xf9, 0x8a, 0xc6, 0x2f, 0x2e, 0xdd, 0xc9,
+	0x84, 0x06, 0x63, 0xba, 0xfc, 0xb5, 0x3e, 0x81, 0x4e, 0x56, 0x58, 0x3d, 0x55, 0x17, 0xea, 0x23,
+	0x4d, 0x54, 0xe1, 0x48, 0x08, 0x78, 0x0a, 0xad, 0x57, 0x34, 0x7e, 0x80, 0xcb, 0x4b, 0x33, 0x7e,
+	0x0b, 0x2a, 0xd3, 0x28, 0x0c, 0xdf, 0x30, 0x91, 0xef, 0x4d, 0xa2, 0x10, 0x4f, 0xcf, 0x1b, 0xd7,
+	0x8f, 0x45, 0xae, 0x57, 0x88, 0xf8, 0xc6, 0x5f, 0x42, 0x3b, 0xb9, 0x51, 0xd9, 0x38, 0x80, 0xaa,
+	0xcf, 0x33, 0x86, 0xca, 0x64, 0x6a, 0x1c, 0x3e, 0x36, 0x11, 0x15, 0x99, 0x44, 0x34, 0x1b, 0x1f,
+	0x01, 0xfa, 0xf6, 0x84, 0x3c, 0xcc, 0x64, 0x1b, 0xaa, 0xef, 0x66, 0x34, 0xf2, 0x4d, 0x8a, 0x69,
+	0x88, 0x3f, 0x84, 0xcd, 0x8c, 0x16, 0x65, 0x86, 0x0d, 0x55, 0x37, 0x60, 0x37, 0x34, 0xd2, 0x39,
+	0xad, 0x21, 0xfe, 0x09, 0xda, 0x17, 0xb3, 0x21, 0x1b, 0x45, 0xfe, 0x90, 0xfe, 0xff, 0x71, 0xc2,
+	0xe7, 0xf0, 0x5e, 0x4a, 0xbd, 0xb2, 0xe6, 0xbf, 0xa7, 0x1e, 0xbe, 0x00, 0x24, 0x1a, 0x04, 0xa1,
+	0xd3, 0x30, 0x8a, 0x97, 0x1b, 0xbc, 0x07, 0xad, 0x59, 0xe0, 0xd1, 0xe8, 0xb5, 0x27, 0x53, 0x90,
+	0x7a, 0xa2, 0x34, 0x6a, 0xe4, 0xb1, 0x20, 0x1f, 0x69, 0x2a, 0xfe, 0xd5, 0x82, 0x0d, 0xa1, 0x55,
+	0x91, 0xe6, 0x3a, 0xc3, 0xad, 0x24, 0xc3, 0x6d, 0xa8, 0xca, 0x9c, 0x96, 0xf6, 0xd5, 0x89, 0x86,
+	0x4b, 0x8b, 0x9a, 0x57, 0xa5, 0xcf, 0x98, 0x1f, 0x8c, 0x55, 0x4d, 0x6b, 0x98, 0x29, 0xf7, 0x72,
+	0xae, 0xdc, 0x8f, 0x61, 0x33, 0xe3, 0xa4, 0x0a, 0xdb, 0x81, 0xe9, 0xb0, 0x32, 0x95, 0xb6, 0xb2,
+	0x1d, 0x56, 0x1b, 0xaf, 0x1b, 0xad, 0x2a, 0xb2, 0xe7, 0x41, 0x78, 0xe5, 0x4e, 0x7c, 0xba, 0xa2,
+	0x25, 0xce, 0xa0, 0x2a, 0x25, 0x8b, 0x9c, 0x4f, 0xca, 0x7b, 0x2d, 0x5d, 0xde, 0x9c, 0x1e, 0x51,
+	0x97, 0x85, 0x81, 0xea, 0x05, 0x0a, 0xf1, 0x2b, 0xe4, 0x53, 0x4a, 0xa7, 0x25, 0xe0, 0x35, 0xc3,
+	0x47, 0xa0, 0x72, 0x57, 0x7c, 0xe3, 0x97, 0xa2, 0xb6, 0x53, 0x36, 0x1a, 0x5f, 0xeb, 0xae, 0x26,
+	0x2a, 0x77, 0xdb, 0xc6, 0x5d, 0x65, 0x28, 0x49, 0x44, 0xf0, 0x29, 0x34, 0x2e, 0xe6, 0xc1, 0x48,
+	0xfb, 0xf8, 0x18, 0xd6, 0x8c, 0x07, 0x6b, 0xbe, 0xc7, 0x0d, 0x62, 0x7e, 0x30, 0xa2, 0x6a, 0x14,
+	0x4b, 0xc0, 0xcd, 0x1f, 0xd2, 0x37, 0x61, 0x44, 0xd5, 0xbb, 0x29, 0x84, 0x2f, 0xa1, 0x29, 0x95,
+	0x2d, 0xcd, 0x57, 0x1b, 0xaa, 0xa3, 0x88, 0xba, 0xb1, 0x4a, 0xab, 0x0a, 0xd1, 0x30, 0x5d, 0xf4,
+	0xa5, 0xe5, 0x45, 0xff, 0x03, 0xa0, 0x13, 0xc6, 0x66, 0xf4, 0x3b, 0x3e, 0xfe, 0x57, 0x14, 0xfd,
+	0x3e, 0x94, 0x67, 0x4c, 0xe7, 0x5f, 0xe3, 0xf0, 0x89, 0xd1, 0x29, 0x0e, 0xab, 0xb3, 0x44, 0xca,
+	0xe0, 0x2b, 0x68, 0xa6, 0xc9, 0xcb, 0x26, 0x47, 0x07, 0xca, 0xa2, 0x58, 0xd5, 0xdc, 0x90, 0xc0,
+	0xcc, 0xdf, 0x52, 0x32, 0x7f, 0xb9, 0xc7, 0xc3, 0x89, 0x1f, 0x78, 0x62, 0x42, 0x89, 0x2e, 0xa2,
+	0x20, 0xcf, 0xd8, 0x8c, 0x1f, 0xe6, 0x15, 0x95, 0xc9, 0xf2, 0x05, 0xed, 0xac, 0xc9, 0x17, 0xfe,
+	0x38, 0x70, 0xe3, 0x59, 0x44, 0x99, 0xb6, 0xfa, 0x23, 0x68, 0xe5, 0x38, 0xa8, 0x07, 0xc0, 0x0c,
+	0x52, 0xcd, 0x2b, 0x45, 0xc1, 0xfb, 0xea, 0x66, 0xef, 0x01, 0x21, 0xc4, 0x9f, 0xc3, 0x86, 0x10,
+	0xe3, 0x27, 0x5c, 0x9e, 0x01, 0x85, 0xa9, 0xee, 0x0b, 0x7d, 0xea, 0x51, 0x15, 0xe2, 0x89, 0x9a,
+	0xbd, 0x67, 0x65, 0x51, 0x66, 0x6e, 0xd2, 0x45, 0x79, 0xf8, 0x47, 0x0d, 0xaa, 0x67, 0x52, 0x02,
+	0x3d, 0x87, 0x9a, 0xde, 0xf5, 0x50, 0x12, 0x9b, 0xdc, 0x7a, 0xe9, 0x6c, 0x17, 0x70, 0xd4, 0x18,
+	0x7d, 0xc4, 0x55, 0xe8, 0x8d, 0x2f, 0xa5, 0x22, 0xb7, 0x2d, 0x3a, 0xdb, 0x05, 0x1c, 0xa3, 0x82,
+	0xc0, 0x46, 0x66, 0xab, 0x42, 0xbb, 0x46, 0xba, 0x68, 0x6f, 0x74, 0x7a, 0x8b, 0xd8, 0x5a, 0xe3,
+	0xc0, 0x42, 0x3f, 0x42, 0x2b, 0xb7, 0x60, 0xa1, 0xf7, 0xef, 0x1d, 0xcb, 0xae, 0x6a, 0x4e, 0x7f,
+	0xb1, 0x80, 0xd6, 0xfc, 0xcc, 0x42, 0xdf, 0x40, 0x33, 0xbd, 0x51, 0xa0, 0xae, 0x39, 0x55, 0xb0,
+	0xe4, 0x38, 0xbb, 0x0b, 0xb8, 0x29, 0x53, 0xcf, 0xa0, 0x99, 0xde, 0x2e, 0x52, 0x0a, 0x0b, 0x36,
+	0x14, 0x67, 0x77, 0x01, 0xd7, 0x44, 0xf3, 0x18, 0x6a, 0x7a, 0xfa, 0xa6, 0x1e, 0x24, 0x37, 0xd6,
+	0x9d, 0xed, 0x02, 0x4e, 0x62, 0xd3, 0x33, 0x0b, 0x9d, 0x43, 0x23, 0x35, 0xc7, 0xd1, 0x8e, 0x91,
+	0xbf, 0xbf, 0x23, 0x38, 0xdd, 0x62, 0x66, 0x46, 0xdf, 0x4b, 0xa8, 0x9b, 0x39, 0x8c, 0x92, 0xdb,
+	0xf3, 0xa3, 0xdf, 0x71, 0x8a, 0x58, 0xa9, 0xf0, 0x7f, 0x0d, 0x8d, 0xd4, 0x68, 0x4a, 0xd9, 0x75,
+	0x7f, 0x2a, 0x3b, 0xdd, 0x62, 0xa6, 0x09, 0x95, 0x8c, 0xbc, 0xe9, 0xfd, 0xd9, 0xc8, 0xe7, 0xc7,
+	0x96, 0xb3, 0xbb, 0x80, 0x6b, 0xd4, 0x7d, 0x06, 0xeb, 0xbc, 0x6b, 0xa3, 0x4e, 0xe2, 0x42, 0x32,
+	0x11, 0x9c, 0x27, 0x39, 0x6a, 0xd6, 0xa7, 0x54, 0xf3, 0x4a, 0xf9, 0x74, 0xbf, 0x35, 0x3b, 0xdd,
+	0x62, 0x66, 0xda, 0xa7, 0x74, 0x9b, 0x40, 0x39, 0xf9, 0x6c, 0x97, 0x72, 0x76, 0x17, 0x70, 0xb5,
+	0xba, 0xaf, 0xda, 0x7f, 0xde, 0xf5, 0xac, 0xbf, 0xee, 0x7a, 0xd6, 0xdf, 0x77, 0x3d, 0xeb, 0xb7,
+	0x7f, 0x7a, 0x8f, 0x86, 0x15, 0xf1, 0x3f, 0xfa, 0xf1, 0xbf, 0x03, 0x00, 0xdf, 0xd9, 0xdd, 0xcf,
+	0xa0, 0x0e, 0x00, 0x00,
 }
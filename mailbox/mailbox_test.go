@@ -19,8 +19,20 @@ import (
 var mailboxAddr = "localhost:8500"
 var port = ":8500"
 
+func proveOwnership(t *testing.T, mailbox MailboxClient, round uint64, pubs, privs []*[32]byte) [][]byte {
+	resp, err := mailbox.GetChallenge(context.Background(), &GetChallengeRequest{Round: round})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofs := make([][]byte, len(pubs))
+	for i := range proofs {
+		proofs[i] = ProveOwnership(pubs[i], privs[i], round, resp.Challenge)
+	}
+	return proofs
+}
+
 func TestBasicMailbox(t *testing.T) {
-	server := NewMailboxServer(ecdsa.PublicKey{})
+	server := NewMailboxServer(ecdsa.PublicKey{}, NewMemoryStore(), Retention{}, Replication{}, Tokens{})
 
 	go func() {
 		grpcServer := grpc.NewServer()
@@ -45,7 +57,7 @@ func TestBasicMailbox(t *testing.T) {
 	}
 	mailbox := NewMailboxClient(cc)
 
-	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{0})
+	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{Round: 0})
 	if err != nil {
 		t.Error(err)
 	}
@@ -125,6 +137,7 @@ func TestBasicMailbox(t *testing.T) {
 	getReq := &GetMailsRequest{
 		Round:    0,
 		UserKeys: keys,
+		Proofs:   proveOwnership(t, mailbox, 0, pubs, privs),
 	}
 	err = inStream.Send(getReq)
 	if err != nil {
@@ -162,4 +175,205 @@ func TestBasicMailbox(t *testing.T) {
 	}
 
 	inStream.CloseSend()
+
+	// the inboxes should still be there after the round ends
+	_, err = mailbox.EndRound(context.Background(), &EndRoundRequest{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{Round: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inStream, err = mailbox.GetMails(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = inStream.Send(getReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = inStream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, inbox := range resp.Inboxes {
+		if len(inbox.Messages) != msgsPerUser {
+			t.Fatal("Past round mails missing for user", i)
+		}
+	}
+	inStream.CloseSend()
+
+	// keys are 32 bytes, not padded or truncated to it
+	for _, size := range []int{31, 33} {
+		short := &GetMailsRequest{
+			Round:    0,
+			UserKeys: [][]byte{make([]byte, size)},
+			Proofs:   getReq.Proofs[:1],
+		}
+		inStream, err = mailbox.GetMails(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inStream.Send(short); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := inStream.Recv(); err == nil {
+			t.Fatal("Got the mails of a key of", size, "bytes")
+		}
+	}
+}
+
+func TestMailboxDeadline(t *testing.T) {
+	server := NewMailboxServer(ecdsa.PublicKey{}, NewMemoryStore(), Retention{}, Replication{}, Tokens{})
+
+	go func() {
+		grpcServer := grpc.NewServer()
+		RegisterMailboxServer(grpcServer, server)
+
+		lis, err := net.Listen("tcp", ":8501")
+		if err != nil {
+			log.Fatal("Could not listen:", err)
+		}
+
+		err = grpcServer.Serve(lis)
+		if err != grpc.ErrServerStopped {
+			log.Fatal("Serve err:", err)
+		}
+	}()
+
+	time.Sleep(time.Millisecond)
+
+	cc, err := grpc.Dial("localhost:8501", grpc.WithInsecure())
+	if err != nil {
+		panic("Couldn't dial mailbox")
+	}
+	mailbox := NewMailboxClient(cc)
+
+	timeout := 200 * time.Millisecond
+	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{
+		Round:   0,
+		Timeout: uint64(timeout),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([][]byte, 3)
+	pubs, privs := make([]*[32]byte, len(keys)), make([]*[32]byte, len(keys))
+	for i := range keys {
+		pubs[i], privs[i], err = box.GenerateKey(rand.Reader)
+		if err != nil {
+			panic("Could not create keys")
+		}
+		keys[i] = (*pubs[i])[:]
+	}
+	expected := []uint64{2, 2, 0}
+
+	stream, err := mailbox.RegisterUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&RegisterUsersRequest{
+		Round:    0,
+		UserKeys: keys,
+		Expected: expected,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.CloseAndRecv()
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+
+	// one of the mails for the first user is dropped
+	mails := []*Mail{
+		{UserKey: keys[0], Message: []byte("mail 1")},
+		{UserKey: keys[1], Message: []byte("mail 2")},
+		{UserKey: keys[1], Message: []byte("mail 3")},
+	}
+	outStream, err := mailbox.DeliverMails(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = outStream.Send(&DeliverMailsRequest{Round: 0, Mails: mails})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = outStream.CloseAndRecv()
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+
+	// waiting only for the mails that arrived does not time out
+	received := []uint64{1, 2, 0}
+	for _, wait := range [][]uint64{received, nil} {
+		start := time.Now()
+		inStream, err := mailbox.GetMails(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = inStream.Send(&GetMailsRequest{
+			Round:    0,
+			UserKeys: keys,
+			Proofs:   proveOwnership(t, mailbox, 0, pubs, privs),
+			Wait:     wait,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := inStream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		inStream.CloseSend()
+
+		if wait != nil && time.Since(start) >= timeout {
+			t.Fatal("GetMails waited for missing mails")
+		}
+		if time.Since(start) > 10*timeout {
+			t.Fatal("GetMails did not time out")
+		}
+		for i, inbox := range resp.Inboxes {
+			if inbox.Expected != expected[i] || inbox.Received != received[i] ||
+				len(inbox.Messages) != int(received[i]) {
+				t.Fatal("Wrong counts for user", i, inbox.Expected, inbox.Received)
+			}
+		}
+	}
+}
+
+func TestChallenges(t *testing.T) {
+	mb := NewMailboxServer(ecdsa.PublicKey{}, NewMemoryStore(), Retention{Rounds: 2}, Replication{}, Tokens{})
+	for r := uint64(0); r < 5; r++ {
+		_, err := mb.NewRound(context.Background(), &NewRoundRequest{Round: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		round uint64
+		ok    bool
+	}{
+		{4, true},        // current
+		{5, true},        // next
+		{6, false},       // too far ahead
+		{1 << 40, false}, // too far ahead
+		{3, true},        // past, but kept
+		{1, false},       // expired
+	}
+	for _, test := range tests {
+		resp, err := mb.GetChallenge(context.Background(), &GetChallengeRequest{Round: test.round})
+		if test.ok && (err != nil || len(resp.Challenge) != CHALLENGE_SIZE) {
+			t.Error("No challenge for round", test.round, err)
+		} else if !test.ok && err == nil {
+			t.Error("Challenge for round", test.round)
+		}
+	}
+	if n := len(mb.(*mailbox).challenges); n != 3 {
+		t.Error("Wrong number of challenges", n)
+	}
 }
@@ -0,0 +1,261 @@
+package mailbox
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/span"
+)
+
+// Multi-server XOR-based private information retrieval (Chor et al.)
+// for downloading inboxes. In PIR mode, every inbox is replicated at
+// every mailbox (see Ring), so all mailboxes hold the same inboxes.
+// The database of a round has one fixed size row per registered user,
+// sorted by key (same order as RegisteredUsers). To fetch row i, the
+// client sends a random bit vector to each mailbox, such that the
+// vectors xor to the unit vector for i, and xors the answers back
+// together. Any subset of all but one mailbox learns nothing about i.
+//
+// A row is [digest (32)][expected (8)][received (8)][length (4)][mail]...
+// padded with zeros to the longest row. Mails arrive in a different
+// order at each mailbox, so they are sorted within a row. The digest
+// is over the user key and the rest of the row, so a client can tell
+// if the mailboxes did not answer from the same database.
+//
+// All mailboxes must hold the same users, so PIR is a deployment-wide
+// mode: a user registered at only some mailboxes breaks the database.
+// PIR reads carry no proof of ownership, since that would reveal the
+// key being read. Anyone can fetch an (end-to-end encrypted) row.
+
+const pirHeaderSize = sha256.Size + 8 + 8
+
+type pirDatabase struct {
+	rows    [][]byte
+	rowSize int
+}
+
+type pirEntry struct {
+	once sync.Once
+	db   *pirDatabase
+	err  error
+}
+
+func pirDigest(key [32]byte, body []byte) []byte {
+	h := sha256.New()
+	h.Write(key[:])
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+func encodePIRRow(key [32]byte, inbox [][]byte, expected uint64) []byte {
+	sorted := make([][]byte, len(inbox))
+	copy(sorted, inbox)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	inbox = sorted
+
+	size := pirHeaderSize
+	for _, msg := range inbox {
+		size += 4 + len(msg)
+	}
+	row := make([]byte, size)
+	body := row[sha256.Size:]
+	binary.BigEndian.PutUint64(body[0:], expected)
+	binary.BigEndian.PutUint64(body[8:], uint64(len(inbox)))
+	off := 16
+	for _, msg := range inbox {
+		binary.BigEndian.PutUint32(body[off:], uint32(len(msg)))
+		copy(body[off+4:], msg)
+		off += 4 + len(msg)
+	}
+	copy(row, pirDigest(key, body))
+	return row
+}
+
+func newPIRDatabase(store Store, round uint64, keys [][32]byte) (*pirDatabase, error) {
+	db := &pirDatabase{
+		rows: make([][]byte, len(keys)),
+	}
+	for i, key := range keys {
+		inbox, expected, err := store.Inbox(round, key)
+		if err != nil {
+			return nil, err
+		}
+		db.rows[i] = encodePIRRow(key, inbox, expected)
+		if len(db.rows[i]) > db.rowSize {
+			db.rowSize = len(db.rows[i])
+		}
+	}
+	return db, nil
+}
+
+func (db *pirDatabase) answer(query []byte) ([]byte, error) {
+	if len(query) != (len(db.rows)+7)/8 {
+		return nil, errors.New("Invalid PIR query size")
+	}
+	ans := make([]byte, db.rowSize)
+	for i, row := range db.rows {
+		if query[i/8]&(1<<uint(i%8)) == 0 {
+			continue
+		}
+		for b := range row {
+			ans[b] ^= row[b]
+		}
+	}
+	return ans, nil
+}
+
+// pirDatabase returns the database of round. For a round in progress,
+// it waits for all the mails (up to the deadline) so that a query does
+// not reveal whose mails are being waited on. The database is built
+// once, so all queries of the round are answered from the same rows.
+func (mb *mailbox) pirDatabase(round uint64) (*pirDatabase, error) {
+	mb.pmu.Lock()
+	entry, ok := mb.pirDBs[round]
+	if !ok {
+		entry = new(pirEntry)
+		mb.pirDBs[round] = entry
+	}
+	mb.pmu.Unlock()
+
+	entry.once.Do(func() {
+		keys, err := mb.store.Users(round)
+		if err != nil {
+			entry.err = err
+			return
+		}
+
+		mb.smu.RLock()
+		state, live := mb.states[round]
+		mb.smu.RUnlock()
+		if live {
+			err = state.wait(keys, nil)
+			if err != nil {
+				entry.err = err
+				return
+			}
+		}
+
+		entry.db, entry.err = newPIRDatabase(mb.store, round, keys)
+	})
+	if entry.err != nil {
+		// try again next time, e.g., if the round was not yet created
+		mb.pmu.Lock()
+		if mb.pirDBs[round] == entry {
+			delete(mb.pirDBs, round)
+		}
+		mb.pmu.Unlock()
+	}
+	return entry.db, entry.err
+}
+
+func (mb *mailbox) PIRGetMails(stream Mailbox_PIRGetMailsServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		db, err := mb.pirDatabase(in.Round)
+		if err != nil {
+			return err
+		}
+
+		answers := make([][]byte, len(in.Queries))
+		for i, query := range in.Queries {
+			answers[i], err = db.answer(query)
+			if err != nil {
+				return err
+			}
+		}
+
+		spans := span.StreamSpan(len(answers), config.StreamSize, db.rowSize+1)
+		for _, span := range spans {
+			if err := stream.Send(&PIRGetMailsResponse{
+				Answers: answers[span.Start:span.End],
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PIRQueries returns one query per mailbox to privately fetch row
+// index out of n rows.
+func PIRQueries(n, index, servers int) [][]byte {
+	if servers < 2 {
+		panic("PIR needs at least two mailboxes")
+	}
+	queries := make([][]byte, servers)
+	last := make([]byte, (n+7)/8)
+	for s := 0; s < servers-1; s++ {
+		queries[s] = make([]byte, len(last))
+		rand.Read(queries[s])
+		// keep the unused bits zero, so all queries look the same
+		if n%8 != 0 {
+			queries[s][len(last)-1] &= byte(1<<uint(n%8)) - 1
+		}
+		for b := range last {
+			last[b] ^= queries[s][b]
+		}
+	}
+	last[index/8] ^= 1 << uint(index%8)
+	queries[servers-1] = last
+	return queries
+}
+
+// PIRDecode combines the answers of all mailboxes into the inbox of key.
+func PIRDecode(key [32]byte, answers [][]byte) (*Inbox, error) {
+	if len(answers) == 0 {
+		return nil, errors.New("No PIR answers")
+	}
+	row := make([]byte, len(answers[0]))
+	for _, ans := range answers {
+		if len(ans) != len(row) {
+			return nil, errors.New("Mismatched PIR answers")
+		}
+		for b := range ans {
+			row[b] ^= ans[b]
+		}
+	}
+	if len(row) < pirHeaderSize {
+		return nil, errors.New("Invalid PIR row")
+	}
+
+	body := row[sha256.Size:]
+	inbox := &Inbox{
+		UserKey:  key[:],
+		Expected: binary.BigEndian.Uint64(body[0:]),
+		Received: binary.BigEndian.Uint64(body[8:]),
+	}
+	if inbox.Received > uint64(len(body)/4) {
+		return nil, errors.New("Invalid PIR row")
+	}
+	off := 16
+	inbox.Messages = make([][]byte, inbox.Received)
+	for i := range inbox.Messages {
+		if off+4 > len(body) {
+			return nil, errors.New("Invalid PIR row")
+		}
+		length := int(binary.BigEndian.Uint32(body[off:]))
+		if off+4+length > len(body) {
+			return nil, errors.New("Invalid PIR row")
+		}
+		inbox.Messages[i] = body[off+4 : off+4+length]
+		off += 4 + length
+	}
+	if !bytes.Equal(row[:sha256.Size], pirDigest(key, body[:off])) {
+		return nil, errors.New("PIR row digest mismatch")
+	}
+	return inbox, nil
+}
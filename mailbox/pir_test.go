@@ -0,0 +1,97 @@
+package mailbox
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+	"time"
+)
+
+func TestPIR(t *testing.T) {
+	numMailboxes := 3
+	numUsers := 13
+
+	keys := make([][32]byte, numUsers)
+	for i := range keys {
+		rand.Read(keys[i][:])
+	}
+	sortKeys(keys)
+	expected := make([]uint64, numUsers)
+	for i := range expected {
+		expected[i] = uint64(i % 4)
+	}
+	var mails []*Mail
+	for i := range keys {
+		// the last user misses a mail
+		for j := 0; j < int(expected[i]) && !(i == numUsers-1 && j == 0); j++ {
+			msg := make([]byte, 20)
+			rand.Read(msg)
+			mails = append(mails, &Mail{UserKey: keys[i][:], Message: msg})
+		}
+	}
+
+	dbs := make([]*pirDatabase, numMailboxes)
+	for m := range dbs {
+		store := NewMemoryStore()
+		store.NewRound(0, time.Now())
+		store.Register(0, keys, expected)
+		// every mailbox gets the mails in a different order
+		for _, i := range randPerm(len(mails)) {
+			store.Append(0, mails[i:i+1])
+		}
+		var err error
+		dbs[m], err = newPIRDatabase(store, 0, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, key := range keys {
+		queries := PIRQueries(numUsers, i, numMailboxes)
+		answers := make([][]byte, numMailboxes)
+		for m := range answers {
+			var err error
+			answers[m], err = dbs[m].answer(queries[m])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		inbox, err := PIRDecode(key, answers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inbox.Expected != expected[i] || inbox.Received != uint64(len(inbox.Messages)) {
+			t.Fatal("Wrong counts")
+		}
+		for _, msg := range inbox.Messages {
+			found := false
+			for _, mail := range mails {
+				if bytes.Equal(mail.UserKey, key[:]) && bytes.Equal(mail.Message, msg) {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatal("Wrong mail")
+			}
+		}
+
+		// a mailbox answering from a different database is caught
+		answers[0][pirHeaderSize-1] ^= 1
+		if _, err := PIRDecode(key, answers); err == nil {
+			t.Fatal("Bad answer not detected")
+		}
+	}
+}
+
+func randPerm(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		var b [1]byte
+		rand.Read(b[:])
+		j := int(b[0]) % (i + 1)
+		perm[i] = perm[j]
+		perm[j] = i
+	}
+	return perm
+}
@@ -0,0 +1,109 @@
+package mailbox
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+
+	"github.com/kwonalbert/xrd/config"
+)
+
+// Placement of the inboxes on the mailboxes, computed the same way by
+// the clients (to register users), the last servers (to deliver mails)
+// and the mailboxes (to resync), so nobody needs to ask the mailboxes
+// where a user is. It is a consistent hash ring: every mailbox owns
+// ringPoints points on the ring, and a key is placed at the mailboxes
+// owning the first points after the hash of the key. Adding or
+// removing a mailbox only moves the keys next to its points, about
+// 1/n of all the keys.
+
+const ringPoints = 128
+
+type ringPoint struct {
+	hash uint64
+	mid  string
+}
+
+// Ring maps user keys to the mailboxes holding their inboxes.
+type Ring struct {
+	points []ringPoint
+	factor int
+}
+
+func ringHash(data []byte) uint64 {
+	h := sha256.Sum256(data)
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// NewRing creates the ring of the mailboxes, with factor replicas per
+// inbox (capped at the number of mailboxes).
+func NewRing(mailboxes map[string]*config.Server, factor int) *Ring {
+	if factor < 1 {
+		factor = 1
+	} else if factor > len(mailboxes) {
+		factor = len(mailboxes)
+	}
+	ring := &Ring{
+		points: make([]ringPoint, 0, len(mailboxes)*ringPoints),
+		factor: factor,
+	}
+	for mid := range mailboxes {
+		for p := 0; p < ringPoints; p++ {
+			ring.points = append(ring.points, ringPoint{
+				hash: ringHash([]byte(mid + "/" + strconv.Itoa(p))),
+				mid:  mid,
+			})
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool {
+		if ring.points[i].hash != ring.points[j].hash {
+			return ring.points[i].hash < ring.points[j].hash
+		}
+		return ring.points[i].mid < ring.points[j].mid
+	})
+	return ring
+}
+
+// Replicas returns the ids of the mailboxes holding the inbox of key,
+// primary first.
+func (ring *Ring) Replicas(key [32]byte) []string {
+	if len(ring.points) == 0 {
+		return nil
+	}
+	h := ringHash(key[:])
+	start := sort.Search(len(ring.points), func(i int) bool { return ring.points[i].hash >= h })
+
+	replicas := make([]string, 0, ring.factor)
+	for i := 0; len(replicas) < ring.factor; i++ {
+		mid := ring.points[(start+i)%len(ring.points)].mid
+		if !contains(replicas, mid) {
+			replicas = append(replicas, mid)
+		}
+	}
+	return replicas
+}
+
+// Has tells whether mailbox mid is on the ring.
+func (ring *Ring) Has(mid string) bool {
+	for _, point := range ring.points {
+		if point.mid == mid {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReplica tells whether mailbox mid holds the inbox of key.
+func (ring *Ring) IsReplica(key [32]byte, mid string) bool {
+	return contains(ring.Replicas(key), mid)
+}
+
+func contains(mids []string, mid string) bool {
+	for _, m := range mids {
+		if m == mid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package mailbox
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	ring := NewRing(testMailboxes(5), 3)
+	counts := make(map[string]int)
+	var key [32]byte
+	for i := 0; i < 5000; i++ {
+		rand.Read(key[:])
+		replicas := ring.Replicas(key)
+		if len(replicas) != 3 {
+			t.Fatal("Wrong number of replicas")
+		}
+		for j := range replicas {
+			if contains(replicas[:j], replicas[j]) {
+				t.Fatal("Duplicate replica")
+			}
+		}
+		counts[replicas[0]]++
+	}
+	for mid, count := range counts {
+		if count < 700 || count > 1300 {
+			t.Fatal("Unbalanced mailbox", mid, counts)
+		}
+	}
+
+	if len(NewRing(testMailboxes(2), 3).Replicas(key)) != 2 {
+		t.Fatal("More replicas than mailboxes")
+	}
+}
+
+func TestRingReshuffle(t *testing.T) {
+	mailboxes := testMailboxes(6)
+	grown := NewRing(mailboxes, 1)
+	delete(mailboxes, "mailbox:5")
+	ring := NewRing(mailboxes, 1)
+
+	n := 5000
+	moved := 0
+	var key [32]byte
+	for i := 0; i < n; i++ {
+		rand.Read(key[:])
+		before, after := ring.Replicas(key)[0], grown.Replicas(key)[0]
+		if before == after {
+			continue
+		}
+		// only keys taken over by the new mailbox move
+		if after != "mailbox:5" {
+			t.Fatal("Key moved between old mailboxes")
+		}
+		moved++
+	}
+	if moved > n/4 {
+		t.Fatal("Too many keys moved", moved)
+	}
+}
@@ -0,0 +1,265 @@
+package mailbox
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/span"
+)
+
+// Replication of the inboxes. Every inbox is kept at Factor mailboxes,
+// its replicas, which are placed by a Ring. Clients register users at
+// all the replicas, the servers deliver every mail to each of them,
+// and clients read from the first replica that answers.
+//
+// A mailbox that was down misses registrations and mails. On the first
+// round after it (re)starts, it asks every other mailbox for the
+// inboxes it replicates in the rounds it may have missed, and adds the
+// users and mails it does not have. Equal mails of an inbox are
+// matched by count, since a user may send the same mail through
+// several chains. Sync hands out whole inboxes without proofs of
+// ownership, so only the other mailboxes may call it, authenticated
+// by the TLS certificates of their configurations.
+
+var errUnauthenticated = errors.New("Not authenticated as a replica")
+
+// Replication configures the replicas of a mailbox. The zero value is
+// a single mailbox without replicas.
+type Replication struct {
+	Id        string                    // id of this mailbox
+	Mailboxes map[string]*config.Server // all mailboxes, including this one
+	Factor    int                       // number of replicas per inbox
+}
+
+// ServerCredentials are the TLS credentials of the mailbox at addr.
+// Users connect without a certificate, the other mailboxes with theirs
+// to Sync.
+func ServerCredentials(addr string, mailboxes map[string]*config.Server) credentials.TransportCredentials {
+	pool := x509.NewCertPool()
+	for _, cfg := range mailboxes {
+		pool.AppendCertsFromPEM(cfg.Identity)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{*config.FindCertificate(addr, mailboxes)},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	})
+}
+
+// authenticate checks that the peer of ctx is mailbox mid, another
+// replica on the ring.
+func (mb *mailbox) authenticate(ctx context.Context, mid string) error {
+	cfg, ok := mb.replication.Mailboxes[mid]
+	if !ok || mid == mb.replication.Id || !mb.ring.Has(mid) {
+		return errUnauthenticated
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return errUnauthenticated
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return errUnauthenticated
+	}
+	block, _ := pem.Decode(cfg.Identity)
+	if block == nil || !bytes.Equal(block.Bytes, info.State.PeerCertificates[0].Raw) {
+		return errUnauthenticated
+	}
+	return nil
+}
+
+// Sync streams the inboxes of the rounds in [since, before) that are
+// replicated at mailbox in.Id, at least one response per round. Only
+// mailbox in.Id itself may ask.
+func (mb *mailbox) Sync(in *SyncRequest, stream Mailbox_SyncServer) error {
+	if err := mb.authenticate(stream.Context(), in.Id); err != nil {
+		return err
+	}
+	infos, err := mb.store.Rounds()
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if info.Round < in.Since || info.Round >= in.Before {
+			continue
+		}
+		users, err := mb.store.Users(info.Round)
+		if err == ErrRoundNotFound { // deleted in the meantime
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var inboxes []*Inbox
+		msgSize := 1
+		for _, key := range users {
+			if !mb.ring.IsReplica(key, in.Id) {
+				continue
+			}
+			msgs, expected, err := mb.store.Inbox(info.Round, key)
+			if err != nil {
+				return err
+			}
+			size := 32
+			for _, msg := range msgs {
+				size += len(msg)
+			}
+			if size > msgSize {
+				msgSize = size
+			}
+			key := key
+			inboxes = append(inboxes, &Inbox{
+				UserKey:  key[:],
+				Messages: msgs,
+				Expected: expected,
+				Received: uint64(len(msgs)),
+			})
+		}
+
+		resp := &SyncResponse{
+			Round:   info.Round,
+			Created: uint64(info.Created.UnixNano()),
+		}
+		if len(inboxes) == 0 {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, span := range span.StreamSpan(len(inboxes), config.StreamSize, msgSize) {
+			resp.Inboxes = inboxes[span.Start:span.End]
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resync catches up with the other mailboxes on the rounds before
+// round. The last round this mailbox has may be incomplete, so it is
+// synced again.
+func (mb *mailbox) resync(round uint64) {
+	infos, err := mb.store.Rounds()
+	if err != nil {
+		log.Println("Could not resync:", err)
+		return
+	}
+	var since uint64
+	for _, info := range infos {
+		if info.Round < round && info.Round > since {
+			since = info.Round
+		}
+	}
+
+	peers := make(map[string]*config.Server)
+	for mid, cfg := range mb.replication.Mailboxes {
+		if mid != mb.replication.Id {
+			peers[mid] = cfg
+		}
+	}
+	self := mb.replication.Mailboxes[mb.replication.Id]
+	cert, err := tls.X509KeyPair(self.Identity, self.PrivateIdentity)
+	if err != nil {
+		log.Println("Could not load the certificate to resync:", err)
+		return
+	}
+	conns, err := config.DialServersWithCertificate(peers, &cert)
+	if err != nil {
+		log.Println("Could not dial mailboxes to resync:", err)
+		return
+	}
+	defer config.CloseConns(conns)
+
+	// a peer that is down as well is skipped, the others have the inboxes
+	for mid, cfg := range peers {
+		err := mb.syncFrom(NewMailboxClient(conns[cfg.Address]), since, round)
+		if err != nil {
+			log.Println("Could not resync from", mid, err)
+		}
+	}
+}
+
+// syncFrom adds the inboxes of rounds [since, before) from another
+// mailbox that are missing here.
+func (mb *mailbox) syncFrom(rpc MailboxClient, since, before uint64) error {
+	stream, err := rpc.Sync(context.Background(), &SyncRequest{
+		Id:     mb.replication.Id,
+		Since:  since,
+		Before: before,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		err = mb.store.NewRound(resp.Round, time.Unix(0, int64(resp.Created)))
+		if err != nil {
+			return err
+		}
+		err = mb.merge(resp.Round, resp.Inboxes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merge registers the users of the inboxes that are not registered in
+// round, and appends the mails that are not in their inbox yet.
+func (mb *mailbox) merge(round uint64, inboxes []*Inbox) error {
+	var keys [][32]byte
+	var expected []uint64
+	var mails []*Mail
+	var key [32]byte
+	for _, inbox := range inboxes {
+		copy(key[:], inbox.UserKey)
+		local, _, err := mb.store.Inbox(round, key)
+		if err == ErrNotRegistered {
+			keys = append(keys, key)
+			expected = append(expected, inbox.Expected)
+		} else if err != nil {
+			return err
+		}
+
+		have := make(map[string]int)
+		for _, msg := range local {
+			have[string(msg)]++
+		}
+		for _, msg := range inbox.Messages {
+			if have[string(msg)] > 0 {
+				have[string(msg)]--
+				continue
+			}
+			mails = append(mails, &Mail{UserKey: inbox.UserKey, Message: msg})
+		}
+	}
+
+	if len(keys) > 0 {
+		err := mb.store.Register(round, keys, expected)
+		if err != nil {
+			return err
+		}
+	}
+	if len(mails) > 0 {
+		return mb.store.Append(round, mails)
+	}
+	return nil
+}
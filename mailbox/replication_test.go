@@ -0,0 +1,151 @@
+package mailbox
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kwonalbert/xrd/config"
+	grpc "google.golang.org/grpc"
+)
+
+func testMailboxes(n int) map[string]*config.Server {
+	mailboxes := make(map[string]*config.Server)
+	for m := 0; m < n; m++ {
+		id := fmt.Sprintf("mailbox:%d", m)
+		mailboxes[id] = &config.Server{Id: id}
+	}
+	return mailboxes
+}
+
+func TestSync(t *testing.T) {
+	// with certificates, only mailbox:0 at localhost:8502 listens
+	mailboxes := make(map[string]*config.Server)
+	for m := 0; m < 3; m++ {
+		id := fmt.Sprintf("mailbox:%d", m)
+		mailboxes[id] = config.CreateServer(fmt.Sprintf("localhost:%d", 8502+10*m), id)
+	}
+	factor := 2
+	ring := NewRing(mailboxes, factor)
+
+	// the peer has everything, the restarted mailbox only part of round 1
+	peerStore, store := NewMemoryStore(), NewMemoryStore()
+	peer := NewMailboxServer(ecdsa.PublicKey{}, peerStore, Retention{}, Replication{"mailbox:0", mailboxes, factor}, Tokens{})
+	mb := NewMailboxServer(ecdsa.PublicKey{}, store, Retention{}, Replication{"mailbox:1", mailboxes, factor}, Tokens{}).(*mailbox)
+
+	keys := make([][32]byte, 30)
+	expected := make([]uint64, len(keys))
+	for i := range keys {
+		rand.Read(keys[i][:])
+		expected[i] = 2
+	}
+	var mine [][32]byte
+	for _, key := range keys {
+		if ring.IsReplica(key, "mailbox:1") {
+			mine = append(mine, key)
+		}
+	}
+
+	mails := make([][]*Mail, 3)
+	for r := range mails {
+		mails[r] = randomMails(keys, 2)
+		peerStore.NewRound(uint64(r), time.Now())
+		peerStore.Register(uint64(r), keys, expected)
+		if err := peerStore.Append(uint64(r), mails[r]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.NewRound(1, time.Now())
+	store.Register(1, mine, nil)
+	var partial []*Mail
+	for _, mail := range mails[1] {
+		if len(partial) < len(mine) && ring.IsReplica(keyOf(mail), "mailbox:1") {
+			partial = append(partial, mail)
+		}
+	}
+	store.Append(1, partial)
+
+	go func() {
+		grpcServer := grpc.NewServer(grpc.Creds(ServerCredentials("localhost:8502", mailboxes)))
+		RegisterMailboxServer(grpcServer, peer)
+
+		lis, err := net.Listen("tcp", ":8502")
+		if err != nil {
+			log.Fatal("Could not listen:", err)
+		}
+
+		err = grpcServer.Serve(lis)
+		if err != grpc.ErrServerStopped {
+			log.Fatal("Serve err:", err)
+		}
+	}()
+
+	time.Sleep(time.Millisecond)
+
+	// only the mailbox itself gets its inboxes
+	peers := map[string]*config.Server{"mailbox:0": mailboxes["mailbox:0"]}
+	dial := func(mid string) *grpc.ClientConn {
+		var cert *tls.Certificate
+		if mid != "" {
+			cert = config.FindCertificate(mailboxes[mid].Address, mailboxes)
+		}
+		conns, err := config.DialServersWithCertificate(peers, cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conns["localhost:8502"]
+	}
+	for _, mid := range []string{"", "mailbox:2"} {
+		cc := dial(mid)
+		err := mb.syncFrom(NewMailboxClient(cc), 0, 2)
+		cc.Close()
+		if err == nil {
+			t.Fatal("Synced as mailbox:1 with the certificate of", mid)
+		}
+	}
+
+	cc := dial("mailbox:1")
+	defer cc.Close()
+
+	// round 2 is in progress, so only rounds 0 and 1 are synced
+	err := mb.syncFrom(NewMailboxClient(cc), 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	infos, _ := store.Rounds()
+	if len(infos) != 2 {
+		t.Fatal("Wrong rounds synced", infos)
+	}
+	for r := uint64(0); r < 2; r++ {
+		users, _ := store.Users(r)
+		if len(users) != len(mine) {
+			t.Fatal("Wrong users synced")
+		}
+		checkInboxes(t, store, r, mine, mails[r])
+		for _, key := range mine {
+			_, e, _ := store.Inbox(r, key)
+			if r == 0 && e != 2 {
+				t.Fatal("Expected count not synced")
+			}
+		}
+	}
+
+	// syncing again does not add anything
+	err = mb.syncFrom(NewMailboxClient(cc), 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkInboxes(t, store, 1, mine, mails[1])
+}
+
+func keyOf(mail *Mail) [32]byte {
+	var key [32]byte
+	copy(key[:], mail.UserKey)
+	return key
+}
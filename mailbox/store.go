@@ -0,0 +1,194 @@
+package mailbox
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRoundNotFound = errors.New("Round not initialized")
+	ErrNotRegistered = errors.New("Userkey not registered")
+)
+
+// Store keeps the inboxes of all retained rounds.
+type Store interface {
+	// NewRound creates an empty round. Creating an existing round is
+	// a no-op, so a restarted mailbox keeps its mails.
+	NewRound(round uint64, created time.Time) error
+	// Register creates (or keeps) the inboxes of the keys, and sets
+	// the number of mails expected for each.
+	Register(round uint64, keys [][32]byte, expected []uint64) error
+	// Append adds the mails to their inboxes, in order.
+	// All user keys must be registered.
+	Append(round uint64, mails []*Mail) error
+	// Inbox returns all the mails delivered to key in round, and the
+	// number of mails expected.
+	Inbox(round uint64, key [32]byte) ([][]byte, uint64, error)
+	// Users returns the registered keys of round, sorted.
+	Users(round uint64) ([][32]byte, error)
+	// Rounds returns the stored rounds, oldest first.
+	Rounds() ([]RoundInfo, error)
+	// DeleteRound removes a round and all its inboxes.
+	DeleteRound(round uint64) error
+	Close() error
+}
+
+type RoundInfo struct {
+	Round   uint64
+	Created time.Time
+}
+
+// Retention decides how long the mailbox keeps past rounds around.
+// A zero value for either field means no limit on that axis.
+type Retention struct {
+	Rounds uint64        // number of most recent rounds to keep
+	Age    time.Duration // maximum age of a round
+}
+
+// expired returns whether info should be deleted, given the current
+// round and time. The current round is never expired.
+func (r Retention) expired(info RoundInfo, current uint64, now time.Time) bool {
+	if info.Round == current {
+		return false
+	}
+	if r.Rounds > 0 && info.Round+r.Rounds <= current {
+		return true
+	}
+	if r.Age > 0 && now.Sub(info.Created) > r.Age {
+		return true
+	}
+	return false
+}
+
+type memoryStore struct {
+	mu     sync.RWMutex
+	rounds map[uint64]*memoryRound
+}
+
+type memoryRound struct {
+	created  time.Time
+	inboxes  map[[32]byte][][]byte
+	expected map[[32]byte]uint64
+}
+
+// NewMemoryStore returns a store that keeps everything in memory,
+// which is lost on restart.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		rounds: make(map[uint64]*memoryRound),
+	}
+}
+
+func (ms *memoryStore) NewRound(round uint64, created time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, ok := ms.rounds[round]; ok {
+		return nil
+	}
+	ms.rounds[round] = &memoryRound{
+		created:  created,
+		inboxes:  make(map[[32]byte][][]byte),
+		expected: make(map[[32]byte]uint64),
+	}
+	return nil
+}
+
+func (ms *memoryStore) Register(round uint64, keys [][32]byte, expected []uint64) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	r, ok := ms.rounds[round]
+	if !ok {
+		return ErrRoundNotFound
+	}
+	for i, key := range keys {
+		if _, ok := r.inboxes[key]; !ok {
+			r.inboxes[key] = nil
+		}
+		r.expected[key] = 0
+		if i < len(expected) {
+			r.expected[key] = expected[i]
+		}
+	}
+	return nil
+}
+
+func (ms *memoryStore) Append(round uint64, mails []*Mail) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	r, ok := ms.rounds[round]
+	if !ok {
+		return ErrRoundNotFound
+	}
+
+	var key [32]byte
+	for _, mail := range mails {
+		copy(key[:], mail.UserKey)
+		if _, ok := r.inboxes[key]; !ok {
+			return ErrNotRegistered
+		}
+	}
+	for _, mail := range mails {
+		copy(key[:], mail.UserKey)
+		r.inboxes[key] = append(r.inboxes[key], mail.Message)
+	}
+	return nil
+}
+
+func (ms *memoryStore) Inbox(round uint64, key [32]byte) ([][]byte, uint64, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	r, ok := ms.rounds[round]
+	if !ok {
+		return nil, 0, ErrRoundNotFound
+	}
+	inbox, ok := r.inboxes[key]
+	if !ok {
+		return nil, 0, ErrNotRegistered
+	}
+	return inbox, r.expected[key], nil
+}
+
+func (ms *memoryStore) Users(round uint64) ([][32]byte, error) {
+	ms.mu.RLock()
+	r, ok := ms.rounds[round]
+	if !ok {
+		ms.mu.RUnlock()
+		return nil, ErrRoundNotFound
+	}
+	keys := make([][32]byte, 0, len(r.inboxes))
+	for key := range r.inboxes {
+		keys = append(keys, key)
+	}
+	ms.mu.RUnlock()
+	sortKeys(keys)
+	return keys, nil
+}
+
+func sortKeys(keys [][32]byte) {
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+}
+
+func (ms *memoryStore) Rounds() ([]RoundInfo, error) {
+	ms.mu.RLock()
+	infos := make([]RoundInfo, 0, len(ms.rounds))
+	for round, r := range ms.rounds {
+		infos = append(infos, RoundInfo{round, r.created})
+	}
+	ms.mu.RUnlock()
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Round < infos[j].Round })
+	return infos, nil
+}
+
+func (ms *memoryStore) DeleteRound(round uint64) error {
+	ms.mu.Lock()
+	delete(ms.rounds, round)
+	ms.mu.Unlock()
+	return nil
+}
+
+func (ms *memoryStore) Close() error {
+	return nil
+}
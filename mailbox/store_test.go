@@ -0,0 +1,281 @@
+package mailbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func randomMails(keys [][32]byte, perUser int) []*Mail {
+	mails := make([]*Mail, 0, len(keys)*perUser)
+	for i := range keys {
+		for j := 0; j < perUser; j++ {
+			msg := make([]byte, 50)
+			rand.Read(msg)
+			mails = append(mails, &Mail{UserKey: keys[i][:], Message: msg})
+		}
+	}
+	return mails
+}
+
+func checkInboxes(t *testing.T, store Store, round uint64, keys [][32]byte, mails []*Mail) {
+	for _, key := range keys {
+		inbox, _, err := store.Inbox(round, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected [][]byte
+		for _, mail := range mails {
+			if bytes.Equal(mail.UserKey, key[:]) {
+				expected = append(expected, mail.Message)
+			}
+		}
+		if len(inbox) != len(expected) {
+			t.Fatal("Wrong number of mails")
+		}
+		for i := range inbox {
+			if !bytes.Equal(inbox[i], expected[i]) {
+				t.Fatal("Wrong mail")
+			}
+		}
+	}
+}
+
+func testStore(t *testing.T, store Store) {
+	keys := make([][32]byte, 5)
+	for i := range keys {
+		rand.Read(keys[i][:])
+	}
+	mails := randomMails(keys, 3)
+
+	if err := store.Append(0, mails); err != ErrRoundNotFound {
+		t.Fatal("Appended to a missing round")
+	}
+	if err := store.NewRound(0, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Register(0, keys[:4], []uint64{3, 3, 3, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(0, mails); err != ErrNotRegistered {
+		t.Fatal("Appended to an unregistered user")
+	}
+	if _, _, err := store.Inbox(0, keys[4]); err != ErrNotRegistered {
+		t.Fatal("Got an unregistered inbox")
+	}
+	// nothing should have been added by the failed append
+	checkInboxes(t, store, 0, keys[:4], nil)
+
+	if err := store.Register(0, keys[4:], []uint64{3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(0, mails[:7]); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(0, mails[7:]); err != nil {
+		t.Fatal(err)
+	}
+	checkInboxes(t, store, 0, keys, mails)
+
+	if err := store.NewRound(1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	infos, err := store.Rounds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[0].Round != 0 || infos[1].Round != 1 {
+		t.Fatal("Wrong rounds")
+	}
+
+	if err := store.DeleteRound(0); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Inbox(0, keys[0]); err != ErrRoundNotFound {
+		t.Fatal("Round not deleted")
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestDiskStore(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	testStore(t, store)
+}
+
+func TestDiskStoreRestart(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDiskStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([][32]byte, 5)
+	for i := range keys {
+		rand.Read(keys[i][:])
+	}
+	mails := randomMails(keys, 2)
+	created := time.Unix(1000, 0)
+	store.NewRound(7, created)
+	store.Register(7, keys, nil)
+	if err := store.Append(7, mails); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// simulate a crash in the middle of an append
+	f, err := os.OpenFile(filepath.Join(roundDir(dir, 7), "mails"), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(keys[0][:10])
+	f.Close()
+
+	store, err = NewDiskStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	infos, _ := store.Rounds()
+	if len(infos) != 1 || infos[0].Round != 7 || !infos[0].Created.Equal(created) {
+		t.Fatal("Round not restored")
+	}
+	checkInboxes(t, store, 7, keys, mails)
+
+	// and it should still be possible to add mails after the crash
+	more := randomMails(keys, 1)
+	if err := store.Append(7, more); err != nil {
+		t.Fatal(err)
+	}
+	checkInboxes(t, store, 7, keys, append(mails, more...))
+}
+
+func TestRetention(t *testing.T) {
+	store := NewMemoryStore()
+	mb := NewMailboxServer(ecdsa.PublicKey{}, store, Retention{Rounds: 2}, Replication{}, Tokens{})
+	for r := uint64(0); r < 5; r++ {
+		_, err := mb.NewRound(context.Background(), &NewRoundRequest{Round: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	infos, _ := store.Rounds()
+	if len(infos) != 2 || infos[0].Round != 3 || infos[1].Round != 4 {
+		t.Fatal("Wrong rounds retained", infos)
+	}
+
+	store.NewRound(10, time.Now().Add(-time.Hour))
+	mb = NewMailboxServer(ecdsa.PublicKey{}, store, Retention{Age: time.Minute}, Replication{}, Tokens{})
+	_, err := mb.NewRound(context.Background(), &NewRoundRequest{Round: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	infos, _ = store.Rounds()
+	for _, info := range infos {
+		if info.Round == 10 {
+			t.Fatal("Expired round retained")
+		}
+	}
+	if len(infos) != 3 {
+		t.Fatal("Wrong rounds retained", infos)
+	}
+}
+
+func TestEncryptedStore(t *testing.T) {
+	keys, _ := NewKeyRing("")
+	store, err := NewEncryptedStore(NewMemoryStore(), keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+}
+
+func TestEncryptedStoreShredding(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(t.TempDir(), "keys")
+	open := func() (Store, Store) {
+		disk, err := NewDiskStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys, err := NewKeyRing(keyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store, err := NewEncryptedStore(disk, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store, disk
+	}
+	store, disk := open()
+
+	keys := make([][32]byte, 3)
+	for i := range keys {
+		rand.Read(keys[i][:])
+	}
+	sortKeys(keys)
+	mails := randomMails(keys, 2)
+	for round := uint64(0); round < 2; round++ {
+		store.NewRound(round, time.Now())
+		store.Register(round, keys, nil)
+		if err := store.Append(round, mails); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// neither the keys nor the mails are stored in the clear
+	stored, _ := disk.Users(0)
+	for i := range stored {
+		if _, _, err := disk.Inbox(0, keys[i]); err != ErrNotRegistered {
+			t.Fatal("User key stored in the clear")
+		}
+		inbox, _, _ := disk.Inbox(0, stored[i])
+		for _, msg := range inbox {
+			for _, mail := range mails {
+				if bytes.Contains(msg, mail.Message) {
+					t.Fatal("Mail stored in the clear")
+				}
+			}
+		}
+	}
+	users, _ := store.Users(0)
+	for i := range users {
+		if users[i] != keys[i] {
+			t.Fatal("Wrong users")
+		}
+	}
+	store.Close()
+
+	// the mails survive a restart with the keys
+	store, disk = open()
+	checkInboxes(t, store, 1, keys, mails)
+
+	// and a round is gone once its key is, even if its data is not
+	kr, _ := NewKeyRing(keyFile)
+	kr.Destroy(0)
+	kr.Close()
+	store.Close()
+	store, disk = open()
+	defer store.Close()
+	if _, _, err := store.Inbox(0, keys[0]); err != ErrRoundNotFound {
+		t.Fatal("Shredded round readable")
+	}
+	infos, _ := disk.Rounds()
+	if len(infos) != 1 || infos[0].Round != 1 {
+		t.Fatal("Shredded round not deleted", infos)
+	}
+	checkInboxes(t, store, 1, keys, mails)
+}
@@ -0,0 +1,227 @@
+package mailbox
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/span"
+)
+
+// Subscriptions push the mails of some users as DeliverMails stores
+// them, so a user does not have to wait for every chain of the round.
+// A subscription first replays the stored mails from the requested
+// round on, and then forwards new mails. The subscriber is added
+// before the replay, so a batch stored during the replay may be both
+// replayed and forwarded. Batches are numbered per round, and stored
+// and published under the delivery lock of the round, which the replay
+// also takes, so the forwarded batches that were already replayed are
+// skipped. Mails are not matched by content, since a user may get the
+// same mail through several chains.
+//
+// DeliverMails never blocks on a subscriber: a subscriber that falls
+// more than subscriberBuffer deliveries behind is dropped, and should
+// resubscribe from the round it was in.
+
+const subscriberBuffer = 256
+
+var errSlowSubscriber = errors.New("Subscriber fell behind")
+
+type published struct {
+	batch uint64 // number of the batch in its round
+	resp  *SubscribeResponse
+}
+
+type subscriber struct {
+	keys  [][32]byte
+	mails chan published
+	// closed when the subscriber is dropped for being slow
+	dropped  chan struct{}
+	dropOnce sync.Once
+}
+
+func (sub *subscriber) drop() {
+	sub.dropOnce.Do(func() { close(sub.dropped) })
+}
+
+func (mb *mailbox) subscribe(sub *subscriber) {
+	mb.submu.Lock()
+	defer mb.submu.Unlock()
+	for _, key := range sub.keys {
+		mb.subs[key] = append(mb.subs[key], sub)
+	}
+}
+
+func (mb *mailbox) unsubscribe(sub *subscriber) {
+	mb.submu.Lock()
+	defer mb.submu.Unlock()
+	for _, key := range sub.keys {
+		subs := mb.subs[key]
+		for i := range subs {
+			if subs[i] == sub {
+				subs = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		if len(subs) == 0 {
+			delete(mb.subs, key)
+		} else {
+			mb.subs[key] = subs
+		}
+	}
+}
+
+// publish forwards a newly stored batch to its subscribers. The
+// delivery lock of the round must be held, so that replay sees the
+// batch either in the store or here.
+func (mb *mailbox) publish(round, batch uint64, mails []*Mail) {
+	var key [32]byte
+	batches := make(map[*subscriber][]*Mail)
+	mb.submu.RLock()
+	if len(mb.subs) == 0 {
+		mb.submu.RUnlock()
+		return
+	}
+	for _, mail := range mails {
+		copy(key[:], mail.UserKey)
+		for _, sub := range mb.subs[key] {
+			batches[sub] = append(batches[sub], mail)
+		}
+	}
+	mb.submu.RUnlock()
+
+	for sub, ms := range batches {
+		select {
+		case sub.mails <- published{batch, &SubscribeResponse{Round: round, Mails: ms}}:
+		default:
+			sub.drop()
+		}
+	}
+}
+
+func (mb *mailbox) Subscribe(in *SubscribeRequest, stream Mailbox_SubscribeServer) error {
+	sub := &subscriber{
+		keys:    make([][32]byte, len(in.UserKeys)),
+		mails:   make(chan published, subscriberBuffer),
+		dropped: make(chan struct{}),
+	}
+	kptrs := make([]*[32]byte, len(in.UserKeys))
+	for i, key := range in.UserKeys {
+		if len(key) != 32 {
+			return errInvalidKey
+		}
+		copy(sub.keys[i][:], key)
+		kptrs[i] = &sub.keys[i]
+	}
+	challenge, err := mb.challenge(in.Round)
+	if err != nil {
+		return err
+	}
+	if !VerifyOwnership(kptrs, in.Round, challenge, in.Proofs) {
+		return errors.New("Invalid proof of ownership")
+	}
+
+	mb.subscribe(sub)
+	defer mb.unsubscribe(sub)
+
+	seen, err := mb.replay(in.Round, sub.keys, stream)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case pub := <-sub.mails:
+			if pub.batch <= seen[pub.resp.Round] {
+				continue
+			}
+			if err := stream.Send(pub.resp); err != nil {
+				return err
+			}
+		case <-sub.dropped:
+			return errSlowSubscriber
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// replay sends the stored mails of the keys from round on. It returns
+// the last batch it read of each round in progress, as later batches
+// are forwarded by publish.
+func (mb *mailbox) replay(round uint64, keys [][32]byte, stream Mailbox_SubscribeServer) (map[uint64]uint64, error) {
+	seen := make(map[uint64]uint64)
+	infos, err := mb.store.Rounds()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.Round < round {
+			continue
+		}
+		mails, batch, err := mb.readRound(info.Round, keys)
+		if err != nil {
+			return nil, err
+		}
+		seen[info.Round] = batch
+
+		msgSize := 1
+		for _, mail := range mails {
+			if len(mail.Message)+32 > msgSize {
+				msgSize = len(mail.Message) + 32
+			}
+		}
+		for _, span := range span.StreamSpan(len(mails), config.StreamSize, msgSize) {
+			if err := stream.Send(&SubscribeResponse{
+				Round: info.Round,
+				Mails: mails[span.Start:span.End],
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return seen, nil
+}
+
+// readRound reads the stored mails of the keys in round, and the
+// number of batches stored so far if the round is in progress.
+func (mb *mailbox) readRound(round uint64, keys [][32]byte) ([]*Mail, uint64, error) {
+	for {
+		mb.smu.RLock()
+		state, live := mb.states[round]
+		mb.smu.RUnlock()
+		if live {
+			state.dmu.Lock()
+		}
+		mails, err := mb.readInboxes(round, keys)
+		if live {
+			batch := state.batches
+			state.dmu.Unlock()
+			return mails, batch, err
+		}
+
+		// the round may have started while reading it
+		mb.smu.RLock()
+		_, live = mb.states[round]
+		mb.smu.RUnlock()
+		if !live {
+			return mails, 0, err
+		}
+	}
+}
+
+func (mb *mailbox) readInboxes(round uint64, keys [][32]byte) ([]*Mail, error) {
+	var mails []*Mail
+	for k := range keys {
+		inbox, _, err := mb.store.Inbox(round, keys[k])
+		if err == ErrNotRegistered || err == ErrRoundNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, msg := range inbox {
+			mails = append(mails, &Mail{UserKey: keys[k][:], Message: msg})
+		}
+	}
+	return mails, nil
+}
@@ -0,0 +1,175 @@
+package mailbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"io"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/nacl/box"
+	grpc "google.golang.org/grpc"
+)
+
+// registerAndDeliver registers the users expecting perUser mails each.
+func registerAndDeliver(t *testing.T, mailbox MailboxClient, round uint64, keys [][]byte, perUser uint64, mails []*Mail) {
+	expected := make([]uint64, len(keys))
+	for i := range expected {
+		expected[i] = perUser
+	}
+	stream, err := mailbox.RegisterUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&RegisterUsersRequest{
+		Round:    round,
+		UserKeys: keys,
+		Expected: expected,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.CloseAndRecv()
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	deliver(t, mailbox, round, mails)
+}
+
+func deliver(t *testing.T, mailbox MailboxClient, round uint64, mails []*Mail) {
+	stream, err := mailbox.DeliverMails(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&DeliverMailsRequest{Round: round, Mails: mails})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.CloseAndRecv()
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+}
+
+// receive reads n mails from a subscription, and checks their round.
+func receive(t *testing.T, stream Mailbox_SubscribeClient, n int, round uint64) []*Mail {
+	var mails []*Mail
+	for len(mails) < n {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Round != round {
+			t.Fatal("Wrong round", resp.Round)
+		}
+		mails = append(mails, resp.Mails...)
+	}
+	if len(mails) != n {
+		t.Fatal("Too many mails")
+	}
+	return mails
+}
+
+func TestSubscribe(t *testing.T) {
+	server := NewMailboxServer(ecdsa.PublicKey{}, NewMemoryStore(), Retention{}, Replication{}, Tokens{})
+
+	go func() {
+		grpcServer := grpc.NewServer()
+		RegisterMailboxServer(grpcServer, server)
+
+		lis, err := net.Listen("tcp", ":8503")
+		if err != nil {
+			log.Fatal("Could not listen:", err)
+		}
+
+		err = grpcServer.Serve(lis)
+		if err != grpc.ErrServerStopped {
+			log.Fatal("Serve err:", err)
+		}
+	}()
+
+	time.Sleep(time.Millisecond)
+
+	cc, err := grpc.Dial("localhost:8503", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	mailbox := NewMailboxClient(cc)
+
+	keys := make([][]byte, 3)
+	pubs, privs := make([]*[32]byte, len(keys)), make([]*[32]byte, len(keys))
+	tkeys := make([][32]byte, len(keys))
+	for i := range keys {
+		pubs[i], privs[i], err = box.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = (*pubs[i])[:]
+		tkeys[i] = *pubs[i]
+	}
+
+	mailbox.NewRound(context.Background(), &NewRoundRequest{Round: 0})
+	registerAndDeliver(t, mailbox, 0, keys, 3, randomMails(tkeys, 2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// subscribe to the first two users, the stored mails come first
+	sub, err := mailbox.Subscribe(ctx, &SubscribeRequest{
+		Round:    0,
+		UserKeys: keys[:2],
+		Proofs:   proveOwnership(t, mailbox, 0, pubs[:2], privs[:2]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(t, sub, 4, 0)
+
+	// new mails of the round are pushed, but not the third user's
+	mails := randomMails(tkeys, 1)
+	deliver(t, mailbox, 0, mails)
+	got := receive(t, sub, 2, 0)
+	for i := range got {
+		if !bytes.Equal(got[i].Message, mails[i].Message) {
+			t.Fatal("Wrong mail pushed")
+		}
+	}
+
+	mailbox.NewRound(context.Background(), &NewRoundRequest{Round: 1})
+	registerAndDeliver(t, mailbox, 1, keys, 1, randomMails(tkeys, 1))
+	receive(t, sub, 2, 1)
+
+	// resuming from round 1 replays only round 1
+	resumed, err := mailbox.Subscribe(ctx, &SubscribeRequest{
+		Round:    1,
+		UserKeys: keys[:1],
+		Proofs:   proveOwnership(t, mailbox, 1, pubs[:1], privs[:1]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(t, resumed, 1, 1)
+}
+
+func TestSubscribeInvalidProof(t *testing.T) {
+	mb := NewMailboxServer(ecdsa.PublicKey{}, NewMemoryStore(), Retention{}, Replication{}, Tokens{})
+	pub, priv, _ := box.GenerateKey(rand.Reader)
+	other, _, _ := box.GenerateKey(rand.Reader)
+	challenge, err := mb.(*mailbox).challenge(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = mb.Subscribe(&SubscribeRequest{
+		Round:    0,
+		UserKeys: [][]byte{other[:]},
+		Proofs:   [][]byte{ProveOwnership(pub, priv, 0, challenge)},
+	}, nil)
+	if err == nil {
+		t.Fatal("Subscribed without owning the key")
+	}
+}
@@ -0,0 +1,195 @@
+package mailbox
+
+import (
+	"errors"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mixnet"
+
+	"golang.org/x/net/context"
+)
+
+// Submission tokens (see mixnet/token.go). In the rounds the
+// coordinator gives the mailboxes a signing key, a user gets one token
+// per chain from the primary mailbox of its inbox, once per round, and
+// only for the chains it is assigned to. The tokens are blind, so the
+// mailbox only counts how many it issued for each chain, which the
+// coordinator compares with the tokens spent at the chains.
+
+var (
+	ErrNoTokens        = errors.New("No tokens issued in the round")
+	errNotPrimary      = errors.New("Not the primary mailbox of the user")
+	errNotAssigned     = errors.New("Tokens for chains not assigned to the user")
+	errTokensIssued    = errors.New("Tokens already issued to the user")
+	errMismatchedBlind = errors.New("Mismatched blinded tokens")
+)
+
+// Tokens configures the issuance of tokens.
+type Tokens struct {
+	Groups map[string]*config.Group // all the chains, nil to not issue tokens
+}
+
+type tokenRound struct {
+	signer *mixnet.TokenSigner
+
+	mu     sync.Mutex
+	users  map[[32]byte]bool
+	issued map[string]uint64
+}
+
+func (mb *mailbox) newTokenRound(round uint64, key []byte) error {
+	if mb.assigner == nil {
+		return errors.New("Tokens need the chains of the users")
+	}
+	priv, err := mixnet.UnmarshalTokenKey(key)
+	if err != nil {
+		return err
+	}
+	signer, err := mixnet.NewTokenSigner(priv)
+	if err != nil {
+		return err
+	}
+	mb.tmu.Lock()
+	mb.tokenRounds[round] = &tokenRound{
+		signer: signer,
+		users:  make(map[[32]byte]bool),
+		issued: make(map[string]uint64),
+	}
+	mb.tmu.Unlock()
+	return nil
+}
+
+func (mb *mailbox) tokenRound(round uint64) *tokenRound {
+	mb.tmu.Lock()
+	defer mb.tmu.Unlock()
+	return mb.tokenRounds[round]
+}
+
+// IssueTokens signs the blinded tokens of the users, either all of
+// them or none.
+func (mb *mailbox) IssueTokens(ctx context.Context, in *IssueTokensRequest) (*IssueTokensResponse, error) {
+	tr := mb.tokenRound(in.Round)
+	if tr == nil {
+		return nil, ErrNoTokens
+	}
+
+	keys := make([][32]byte, len(in.Users))
+	kptrs := make([]*[32]byte, len(in.Users))
+	proofs := make([][]byte, len(in.Users))
+	for i, req := range in.Users {
+		if len(req.UserKey) != 32 {
+			return nil, errInvalidKey
+		}
+		copy(keys[i][:], req.UserKey)
+		kptrs[i] = &keys[i]
+		proofs[i] = req.Proof
+
+		if mb.replication.Id != "" && mb.ring.Replicas(keys[i])[0] != mb.replication.Id {
+			return nil, errNotPrimary
+		}
+		if len(req.Blinded) != len(req.Gids) {
+			return nil, errMismatchedBlind
+		}
+		if !mb.assigner.Verify(req.UserKey, in.Round, req.Gids) {
+			return nil, errNotAssigned
+		}
+	}
+	challenge, err := mb.challenge(in.Round)
+	if err != nil {
+		return nil, err
+	}
+	if !VerifyOwnership(kptrs, in.Round, challenge, proofs) {
+		return nil, errors.New("Invalid proof of ownership")
+	}
+
+	// only issue once per round, including to concurrent requests: the
+	// users are reserved before signing, and released if it fails
+	tr.mu.Lock()
+	asked := make(map[[32]byte]bool)
+	for _, key := range keys {
+		if tr.users[key] || asked[key] {
+			tr.mu.Unlock()
+			return nil, errTokensIssued
+		}
+		asked[key] = true
+	}
+	for _, key := range keys {
+		tr.users[key] = true
+	}
+	tr.mu.Unlock()
+
+	resp := &IssueTokensResponse{
+		Users: make([]*TokenSignatures, len(in.Users)),
+	}
+	jobs := make(chan int, len(in.Users))
+	for i := range in.Users {
+		resp.Users[i] = &TokenSignatures{
+			Signatures: make([][]byte, len(in.Users[i].Blinded)),
+		}
+		jobs <- i
+	}
+	close(jobs)
+
+	workers := runtime.NumCPU()
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				req := in.Users[i]
+				for g, gid := range req.Gids {
+					signature, err := tr.signer.Sign(gid, req.Blinded[g])
+					if err != nil {
+						errs <- err
+						return
+					}
+					resp.Users[i].Signatures[g] = signature
+				}
+			}
+			errs <- nil
+		}()
+	}
+	var first error
+	for w := 0; w < workers; w++ {
+		if err := <-errs; err != nil && first == nil {
+			first = err
+		}
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if first != nil {
+		for _, key := range keys {
+			delete(tr.users, key)
+		}
+		return nil, first
+	}
+	for i := range keys {
+		for _, gid := range in.Users[i].Gids {
+			tr.issued[gid]++
+		}
+	}
+	return resp, nil
+}
+
+// IssuedTokens returns the number of tokens issued for each chain.
+func (mb *mailbox) IssuedTokens(ctx context.Context, in *IssuedTokensRequest) (*IssuedTokensResponse, error) {
+	tr := mb.tokenRound(in.Round)
+	if tr == nil {
+		return nil, ErrNoTokens
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	resp := &IssuedTokensResponse{}
+	for gid, issued := range tr.issued {
+		resp.Chains = append(resp.Chains, &TokenIssuance{
+			Gid:    gid,
+			Issued: issued,
+		})
+	}
+	sort.Slice(resp.Chains, func(i, j int) bool { return resp.Chains[i].Gid < resp.Chains[j].Gid })
+	return resp, nil
+}
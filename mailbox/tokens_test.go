@@ -0,0 +1,167 @@
+package mailbox
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/nacl/box"
+	grpc "google.golang.org/grpc"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mixnet"
+)
+
+func TestIssueTokens(t *testing.T) {
+	groups := make(map[string]*config.Group)
+	for g := 0; g < 4; g++ {
+		gid := fmt.Sprintf("group:%d", g)
+		groups[gid] = &config.Group{Gid: gid, Row: uint32(g)}
+	}
+	assigner := config.NewAssigner(groups)
+	server := NewMailboxServer(ecdsa.PublicKey{}, NewMemoryStore(), Retention{}, Replication{}, Tokens{groups})
+
+	go func() {
+		grpcServer := grpc.NewServer()
+		RegisterMailboxServer(grpcServer, server)
+
+		lis, err := net.Listen("tcp", ":8506")
+		if err != nil {
+			log.Fatal("Could not listen:", err)
+		}
+
+		err = grpcServer.Serve(lis)
+		if err != grpc.ErrServerStopped {
+			log.Fatal("Serve err:", err)
+		}
+	}()
+
+	time.Sleep(time.Millisecond)
+
+	cc, err := grpc.Dial("localhost:8506", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	mailbox := NewMailboxClient(cc)
+
+	key := mixnet.GenerateTokenKey()
+	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{
+		Round:    0,
+		TokenKey: mixnet.MarshalTokenKey(key),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubs, privs := make([]*[32]byte, 2), make([]*[32]byte, 2)
+	for i := range pubs {
+		pubs[i], privs[i], err = box.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	proofs := proveOwnership(t, mailbox, 0, pubs, privs)
+
+	chains := 0
+	blindings := make([][]*mixnet.TokenBlinding, len(pubs))
+	reqs := make([]*TokenRequest, len(pubs))
+	for i, pub := range pubs {
+		req := &TokenRequest{UserKey: pub[:], Proof: proofs[i]}
+		for _, group := range assigner.Assign(pub[:], 0) {
+			blinding, blinded := mixnet.BlindToken(&key.PublicKey, 0, group.Gid)
+			blindings[i] = append(blindings[i], blinding)
+			req.Gids = append(req.Gids, group.Gid)
+			req.Blinded = append(req.Blinded, blinded)
+			chains++
+		}
+		reqs[i] = req
+	}
+
+	// only the chains of the user, all of them
+	wrong := *reqs[0]
+	wrong.Gids, wrong.Blinded = wrong.Gids[1:], wrong.Blinded[1:]
+	_, err = mailbox.IssueTokens(context.Background(), &IssueTokensRequest{
+		Round: 0,
+		Users: []*TokenRequest{&wrong, reqs[1]},
+	})
+	if err == nil {
+		t.Fatal("Issued tokens for a part of the chains")
+	}
+
+	// and each chain once
+	dup := *reqs[0]
+	dup.Gids = append([]string(nil), dup.Gids...)
+	dup.Gids[1] = dup.Gids[0]
+	_, err = mailbox.IssueTokens(context.Background(), &IssueTokensRequest{
+		Round: 0,
+		Users: []*TokenRequest{&dup, reqs[1]},
+	})
+	if err == nil {
+		t.Fatal("Issued tokens for a duplicated chain")
+	}
+
+	// a request that fails to sign does not use up the round
+	invalid := *reqs[0]
+	invalid.Blinded = append([][]byte{make([]byte, len(wrong.Blinded[0]))}, invalid.Blinded[1:]...)
+	_, err = mailbox.IssueTokens(context.Background(), &IssueTokensRequest{
+		Round: 0,
+		Users: []*TokenRequest{&invalid, reqs[1]},
+	})
+	if err == nil {
+		t.Fatal("Signed an invalid blinded token")
+	}
+
+	resp, err := mailbox.IssueTokens(context.Background(), &IssueTokensRequest{
+		Round: 0,
+		Users: reqs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range reqs {
+		for g, signature := range resp.Users[i].Signatures {
+			if _, err := blindings[i][g].Unblind(&key.PublicKey, signature); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// only once per round
+	_, err = mailbox.IssueTokens(context.Background(), &IssueTokensRequest{
+		Round: 0,
+		Users: reqs[:1],
+	})
+	if err == nil {
+		t.Fatal("Issued tokens twice")
+	}
+
+	issued, err := mailbox.IssuedTokens(context.Background(), &IssuedTokensRequest{Round: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := uint64(0)
+	for _, chain := range issued.Chains {
+		total += chain.Issued
+	}
+	if total != uint64(chains) {
+		t.Error("Wrong number of tokens issued", total, chains)
+	}
+
+	_, err = mailbox.NewRound(context.Background(), &NewRoundRequest{Round: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = mailbox.IssueTokens(context.Background(), &IssueTokensRequest{
+		Round: 1,
+		Users: reqs[:1],
+	})
+	if err == nil {
+		t.Fatal("Issued tokens in a round without tokens")
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"errors"
 	"math/big"
 	"sync"
+
+	"github.com/kwonalbert/xrd/mixnet/verifiable_mixnet"
 )
 
 type Client interface {
 	// NewRound sets the public key for a particular round.
-	NewRound(round int, publicXs, publicYs []*big.Int)
+	// kemKeys are the trustees' ML-KEM keys, or nil if not hybrid.
+	NewRound(round int, publicXs, publicYs []*big.Int, kemKeys [][]byte)
 	// EndRound deletes the round state.
 	EndRound(round int) error
 
@@ -20,6 +23,8 @@ type Client interface {
 }
 
 type client struct {
+	suite verifiable_mixnet.Suite
+
 	smu    sync.RWMutex
 	states map[int]*clientRoundState
 }
@@ -30,15 +35,17 @@ type clientRoundState struct {
 	publicYs []*big.Int
 	X        *big.Int
 	Y        *big.Int
+	kemKeys  [][]byte
 }
 
-func NewClient() Client {
+func NewClient(suite verifiable_mixnet.Suite) Client {
 	return &client{
+		suite:  suite,
 		states: make(map[int]*clientRoundState),
 	}
 }
 
-func (clt *client) NewRound(round int, publicXs, publicYs []*big.Int) {
+func (clt *client) NewRound(round int, publicXs, publicYs []*big.Int, kemKeys [][]byte) {
 	clt.smu.Lock()
 	x, y := big.NewInt(0), big.NewInt(0)
 	for i := range publicXs {
@@ -51,6 +58,7 @@ func (clt *client) NewRound(round int, publicXs, publicYs []*big.Int) {
 		publicYs: publicYs,
 		X:        x,
 		Y:        y,
+		kemKeys:  kemKeys,
 	}
 	clt.smu.Unlock()
 }
@@ -74,7 +82,7 @@ func (clt *client) GenerateRoundInput(round int, plaintext []byte) []byte {
 	var nonce [24]byte
 	binary.PutUvarint(nonce[:], uint64(round))
 
-	rx, ry, ciphertext := Encrypt(state.X, state.Y, &nonce, plaintext)
+	rx, ry, ciphertext := HybridEncrypt(clt.suite, state.X, state.Y, state.kemKeys, &nonce, plaintext)
 	buf := new(bytes.Buffer)
 
 	// sometimes, big ints are encoded as < 32 bytes..
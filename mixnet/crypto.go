@@ -3,20 +3,30 @@ package mixnet
 import (
 	"bytes"
 	"crypto/elliptic"
+	"crypto/mlkem"
 	"crypto/rand"
 	"errors"
 	"math/big"
 
-	"golang.org/x/crypto/nacl/secretbox"
 	"golang.org/x/crypto/sha3"
+
+	"github.com/kwonalbert/xrd/config"
+	"github.com/kwonalbert/xrd/mixnet/verifiable_mixnet"
 )
 
 var curve = elliptic.P256()
 var curveParams = curve.Params()
 var order = curveParams.N
 
-// overhead of oninon enryption is (x,y) coordinates + sercretbox overhead
-const Overhead = 32 + 32 + secretbox.Overhead
+// overhead of oninon enryption is (x,y) coordinates + suite overhead
+func Overhead(suite verifiable_mixnet.Suite) int {
+	return 32 + 32 + suite.Overhead()
+}
+
+// GroupSuite returns the cipher suite configured for the group.
+func GroupSuite(group *config.Group) (verifiable_mixnet.Suite, error) {
+	return verifiable_mixnet.SuiteByName(group.Suite)
+}
 
 func GenerateInnerKey() (*big.Int, *big.Int, *big.Int) {
 	priv, err := rand.Int(rand.Reader, order)
@@ -31,40 +41,80 @@ func GenerateInnerKey() (*big.Int, *big.Int, *big.Int) {
 // the trustees public key (publicX, publicY) using the key
 // encapsulation technique. The ciphertext consists of randomness
 // used to established the shared key and the encrypted plaintext.
-func Encrypt(publicX, publicY *big.Int, nonce *[24]byte, plaintext []byte) (*big.Int, *big.Int, []byte) {
+func Encrypt(suite verifiable_mixnet.Suite, publicX, publicY *big.Int, nonce *[24]byte, plaintext []byte) (*big.Int, *big.Int, []byte) {
+	return HybridEncrypt(suite, publicX, publicY, nil, nonce, plaintext)
+}
+
+// HybridEncrypt is Encrypt with an additional ML-KEM encapsulation to
+// each trustee's kem key, so that all trustees are needed to decrypt
+// even against a quantum adversary. The kem ciphertexts are prepended
+// to the encrypted plaintext.
+func HybridEncrypt(suite verifiable_mixnet.Suite, publicX, publicY *big.Int, kemKeys [][]byte, nonce *[24]byte, plaintext []byte) (*big.Int, *big.Int, []byte) {
 	priv, px, py := GenerateInnerKey()
 	sharedX, sharedY := curve.ScalarMult(publicX, publicY, priv.Bytes())
 
 	buf := new(bytes.Buffer)
 	buf.Write(sharedX.Bytes())
 	buf.Write(sharedY.Bytes())
+
+	kemCiphertexts := make([]byte, 0, len(kemKeys)*verifiable_mixnet.KEM_CIPHERTEXT_SIZE)
+	for _, kemKey := range kemKeys {
+		ek, err := mlkem.NewEncapsulationKey768(kemKey)
+		if err != nil {
+			panic(err)
+		}
+		kemShared, kemCiphertext := ek.Encapsulate()
+		buf.Write(kemShared)
+		buf.Write(kemCiphertext)
+		kemCiphertexts = append(kemCiphertexts, kemCiphertext...)
+	}
 	key := sha3.Sum256(buf.Bytes())
 
-	ciphertext := secretbox.Seal(nil, plaintext, nonce, &key)
+	ciphertext := suite.Seal(kemCiphertexts, plaintext, nonce, &key)
 	return px, py, ciphertext
 }
 
 // OnoinEncrypt returns a ciphertext that encrypts plaintext under
 // all given keys.
-func OnionEncrypt(publicXs, publicYs []*big.Int, nonce *[24]byte, plaintext []byte) (*big.Int, *big.Int, []byte) {
+func OnionEncrypt(suite verifiable_mixnet.Suite, publicXs, publicYs []*big.Int, nonce *[24]byte, plaintext []byte) (*big.Int, *big.Int, []byte) {
 	x, y := big.NewInt(0), big.NewInt(0)
 	for i := range publicXs {
 		x, y = curve.Add(x, y, publicXs[i], publicYs[i])
 	}
-	return Encrypt(x, y, nonce, plaintext)
+	return Encrypt(suite, x, y, nonce, plaintext)
 }
 
 // Decrypt returns the plaintext message decrypted using private.
-func Decrypt(private *big.Int, nonce *[24]byte, rx, ry *big.Int, ciphertext []byte) ([]byte, error) {
+func Decrypt(suite verifiable_mixnet.Suite, private *big.Int, nonce *[24]byte, rx, ry *big.Int, ciphertext []byte) ([]byte, error) {
+	return HybridDecrypt(suite, private, nil, nonce, rx, ry, ciphertext)
+}
+
+// HybridDecrypt returns the plaintext message of HybridEncrypt
+// decrypted using private and all the trustees' kem keys.
+func HybridDecrypt(suite verifiable_mixnet.Suite, private *big.Int, kemPrivates []*mlkem.DecapsulationKey768, nonce *[24]byte, rx, ry *big.Int, ciphertext []byte) ([]byte, error) {
 	sharedX, sharedY := curve.ScalarMult(rx, ry, private.Bytes())
 
 	// derive a shared key
 	buf := new(bytes.Buffer)
 	buf.Write(sharedX.Bytes())
 	buf.Write(sharedY.Bytes())
+
+	if len(ciphertext) < len(kemPrivates)*verifiable_mixnet.KEM_CIPHERTEXT_SIZE {
+		return nil, errors.New("Ciphertext too short")
+	}
+	for _, kemPrivate := range kemPrivates {
+		kemCiphertext := ciphertext[:verifiable_mixnet.KEM_CIPHERTEXT_SIZE]
+		kemShared, err := kemPrivate.Decapsulate(kemCiphertext)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kemShared)
+		buf.Write(kemCiphertext)
+		ciphertext = ciphertext[verifiable_mixnet.KEM_CIPHERTEXT_SIZE:]
+	}
 	key := sha3.Sum256(buf.Bytes())
 
-	msg, auth := secretbox.Open(nil, ciphertext, nonce, &key)
+	msg, auth := suite.Open(nil, ciphertext, nonce, &key)
 	if !auth {
 		return nil, errors.New("Misauthenticated msg")
 	}
@@ -73,10 +123,10 @@ func Decrypt(private *big.Int, nonce *[24]byte, rx, ry *big.Int, ciphertext []by
 
 // OnoinDecrypt returns the plaintext message decyprted using all
 // given keys.
-func OnionDecrypt(privates []*big.Int, nonce *[24]byte, rx, ry *big.Int, ciphertext []byte) ([]byte, error) {
+func OnionDecrypt(suite verifiable_mixnet.Suite, privates []*big.Int, nonce *[24]byte, rx, ry *big.Int, ciphertext []byte) ([]byte, error) {
 	private := big.NewInt(0)
 	for _, p := range privates {
 		private = private.Add(private, p)
 	}
-	return Decrypt(private, nonce, rx, ry, ciphertext)
+	return Decrypt(suite, private, nonce, rx, ry, ciphertext)
 }
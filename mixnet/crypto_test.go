@@ -2,8 +2,11 @@ package mixnet
 
 import (
 	"bytes"
+	"crypto/mlkem"
 	"math/big"
 	"testing"
+
+	"github.com/kwonalbert/xrd/mixnet/verifiable_mixnet"
 )
 
 func BenchmarkDecrypt(b *testing.B) {
@@ -11,10 +14,10 @@ func BenchmarkDecrypt(b *testing.B) {
 	msg := []byte("hello world")
 
 	var nonce [24]byte
-	rx, ry, c := Encrypt(publicX, publicY, &nonce, msg)
+	rx, ry, c := Encrypt(verifiable_mixnet.DefaultSuite, publicX, publicY, &nonce, msg)
 
 	for i := 0; i < b.N; i++ {
-		Decrypt(private, &nonce, rx, ry, c)
+		Decrypt(verifiable_mixnet.DefaultSuite, private, &nonce, rx, ry, c)
 	}
 }
 
@@ -23,9 +26,9 @@ func TestEncrypt(t *testing.T) {
 	msg := []byte("hello world")
 
 	var nonce [24]byte
-	rx, ry, c := Encrypt(publicX, publicY, &nonce, msg)
+	rx, ry, c := Encrypt(verifiable_mixnet.DefaultSuite, publicX, publicY, &nonce, msg)
 
-	res, err := Decrypt(private, &nonce, rx, ry, c)
+	res, err := Decrypt(verifiable_mixnet.DefaultSuite, private, &nonce, rx, ry, c)
 	if err != nil {
 		t.Error(err)
 	} else if !bytes.Equal(msg, res) {
@@ -47,12 +50,63 @@ func TestOnionEncrypt(t *testing.T) {
 	msg := []byte("hello world")
 
 	var nonce [24]byte
-	rx, ry, c := OnionEncrypt(publicXs, publicYs, &nonce, msg)
+	rx, ry, c := OnionEncrypt(verifiable_mixnet.DefaultSuite, publicXs, publicYs, &nonce, msg)
+
+	res, err := OnionDecrypt(verifiable_mixnet.DefaultSuite, privates, &nonce, rx, ry, c)
+	if err != nil {
+		t.Error(err)
+	} else if !bytes.Equal(msg, res) {
+		t.Error("Decryption failed")
+	}
+}
+
+func hybridKeys(n int) ([][]byte, []*mlkem.DecapsulationKey768) {
+	kemKeys := make([][]byte, n)
+	kemPrivates := make([]*mlkem.DecapsulationKey768, n)
+	for i := range kemKeys {
+		var priv []byte
+		kemKeys[i], priv = verifiable_mixnet.GenerateKEMKey()
+		kemPrivates[i], _ = verifiable_mixnet.NewKEMPrivateKey(priv)
+	}
+	return kemKeys, kemPrivates
+}
+
+func BenchmarkHybridDecrypt(b *testing.B) {
+	private, publicX, publicY := GenerateInnerKey()
+	kemKeys, kemPrivates := hybridKeys(3)
+	msg := []byte("hello world")
+
+	var nonce [24]byte
+	rx, ry, c := HybridEncrypt(verifiable_mixnet.DefaultSuite, publicX, publicY, kemKeys, &nonce, msg)
+
+	for i := 0; i < b.N; i++ {
+		HybridDecrypt(verifiable_mixnet.DefaultSuite, private, kemPrivates, &nonce, rx, ry, c)
+	}
+}
+
+func TestHybridEncrypt(t *testing.T) {
+	private, publicX, publicY := GenerateInnerKey()
+	kemKeys, kemPrivates := hybridKeys(3)
+	msg := []byte("hello world")
+
+	var nonce [24]byte
+	rx, ry, c := HybridEncrypt(verifiable_mixnet.DefaultSuite, publicX, publicY, kemKeys, &nonce, msg)
+	// the overhead of a hybrid layer, with a kem ciphertext per trustee
+	overhead := verifiable_mixnet.HybridOverhead(verifiable_mixnet.DefaultSuite) +
+		(len(kemKeys)-1)*verifiable_mixnet.KEM_CIPHERTEXT_SIZE
+	if len(c) != len(msg)+overhead {
+		t.Error("Wrong hybrid ciphertext size")
+	}
 
-	res, err := OnionDecrypt(privates, &nonce, rx, ry, c)
+	res, err := HybridDecrypt(verifiable_mixnet.DefaultSuite, private, kemPrivates, &nonce, rx, ry, c)
 	if err != nil {
 		t.Error(err)
 	} else if !bytes.Equal(msg, res) {
 		t.Error("Decryption failed")
 	}
+
+	_, err = HybridDecrypt(verifiable_mixnet.DefaultSuite, private, kemPrivates[1:], &nonce, rx, ry, c)
+	if err == nil {
+		t.Error("Decryption succeeded without all kem keys")
+	}
 }
@@ -3,11 +3,13 @@ package mixnet
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
 	"errors"
 	"io"
 	"log"
 	"math/big"
+	"sort"
 	"strconv"
 	"sync"
 
@@ -51,6 +53,40 @@ type roundState struct {
 	msgs      [][]byte
 	msgwg     *sync.WaitGroup
 	shuffleWg *sync.WaitGroup
+
+	verifyOnce sync.Once
+	verifyErr  error
+	// indices of the servers whose proofs were received, to confirm
+	// once they are verified
+	proved []int
+
+	// tokens spent with the ciphertexts, only at the first server of
+	// the chain, and only if they are checked (see token.go)
+	tokens *tokenLedger
+}
+
+// verifyProofs batch verifies all the shuffle proofs received this
+// round. Per hop, the servers only aggregate the keys, and the proofs
+// are checked together before the inner key is released; only then
+// are the downstream servers told whether their inputs verified.
+func (srv *server) verifyProofs(state *roundState, id string, round int) error {
+	state.verifyOnce.Do(func() {
+		state.verifyErr = srv.mixes[id].VerifyProofs(round)
+
+		state.Lock()
+		proved := state.proved
+		state.Unlock()
+		for _, index := range proved {
+			if index >= len(srv.groupRpcs[id])-1 {
+				continue
+			}
+			err := srv.submitVerified(round, id, index+1, state.verifyErr == nil)
+			if err != nil {
+				log.Println("Confirmation failed:", err)
+			}
+		}
+	})
+	return state.verifyErr
 }
 
 func NewMixServer(addr string, coordinator ecdsa.PublicKey, servers map[string]*config.Server, groups map[string]*config.Group) MixServer {
@@ -67,8 +103,12 @@ func NewMixServer(addr string, coordinator ecdsa.PublicKey, servers map[string]*
 	partOf := make(map[string]*config.Group)
 	configs := make(map[string]verifiable_mixnet.RoundConfiguration)
 	for _, group := range groups {
+		suite, err := GroupSuite(group)
+		if err != nil {
+			panic("Invalid cipher suite of " + group.Gid)
+		}
 		for s, sid := range group.Servers {
-			verifiers[sid] = NewVerifier(s, len(group.Servers))
+			verifiers[sid] = NewVerifier(s, len(group.Servers), suite, group.Hybrid)
 
 			if servers[sid].Address != addr {
 				continue
@@ -84,6 +124,8 @@ func NewMixServer(addr string, coordinator ecdsa.PublicKey, servers map[string]*
 				Last:             s == len(group.Servers)-1,
 				AuxSize:          0,
 				GroupSize:        len(group.Servers),
+				Suite:            suite,
+				Hybrid:           group.Hybrid,
 			}
 			configs[sid] = cfg
 			partOf[sid] = group
@@ -155,6 +197,24 @@ func (srv *server) NewRound(ctx context.Context, in *NewRoundRequest) (*NewRound
 	}
 
 	round := int(in.Round)
+	var tokenKey *rsa.PublicKey
+	if len(in.TokenKey) > 0 {
+		var err error
+		tokenKey, err = UnmarshalTokenPublicKey(in.TokenKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// all the servers of a chain should use the suite of the round
+	for sid, cfg := range srv.configs {
+		suite, err := verifiable_mixnet.SuiteByName(in.Suites[srv.partOf[sid].Gid])
+		if err != nil {
+			return nil, err
+		}
+		if suite != cfg.Suite {
+			return nil, errors.New("Mismatched cipher suite of " + srv.partOf[sid].Gid)
+		}
+	}
 	srv.slock.Lock()
 
 	blindkey := make([]byte, 64)
@@ -181,6 +241,12 @@ func (srv *server) NewRound(ctx context.Context, in *NewRoundRequest) (*NewRound
 		if err != nil {
 			return nil, err
 		}
+		if cfg.Hybrid {
+			err = mix.SetKEMKey(round, server.KemPrivateKey)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		publicBlindKeys := make([][]byte, len(srv.partOf[sid].Servers))
 		for p := range publicBlindKeys {
@@ -213,6 +279,9 @@ func (srv *server) NewRound(ctx context.Context, in *NewRoundRequest) (*NewRound
 			msgwg:     wg,
 			shuffleWg: swg,
 		}
+		if tokenKey != nil && cfg.First {
+			state.tokens = newTokenLedger(tokenKey, in.Round, srv.partOf[sid].Gid)
+		}
 
 		srv.states[sid][round] = state
 	}
@@ -560,6 +629,10 @@ func (srv *server) SubmitCiphertexts(stream Mix_SubmitCiphertextsServer) error {
 		return err
 	}
 
+	srv.slock.Lock()
+	state := srv.states[id][round]
+	srv.slock.Unlock()
+
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
@@ -572,6 +645,10 @@ func (srv *server) SubmitCiphertexts(stream Mix_SubmitCiphertextsServer) error {
 		if err != nil {
 			return err
 		}
+		// only spend the tokens of the ciphertexts kept
+		if state != nil && state.tokens != nil {
+			state.tokens.redeem(len(req.Ciphertexts), req.Tokens)
+		}
 	}
 
 	err = stream.SendAndClose(&SubmitCiphertextsResponse{})
@@ -622,20 +699,19 @@ func (srv *server) VerifyProof(stream Mix_VerifyProofServer) error {
 		}
 	}
 
-	err = mix.VerifyProof(round, index, keys, prf)
+	err = mix.AddProof(round, index, keys, prf)
 	if err != nil {
 		return err
 	}
 
+	// confirmed in verifyProofs, after the batch verification
 	state := srv.states[id][round]
+	state.Lock()
+	state.proved = append(state.proved, index)
+	state.Unlock()
 	state.shuffleWg.Done()
 
-	if index < len(srv.groupRpcs[id])-1 {
-		err = srv.submitVerified(round, id, index+1, true)
-		return err
-	} else {
-		return nil
-	}
+	return nil
 }
 
 func (srv *server) ConfirmVerification(ctx context.Context, in *ConfirmVerificationRequest) (*ConfirmVerificationResponse, error) {
@@ -663,10 +739,15 @@ func (srv *server) GetInnerKey(ctx context.Context, in *GetInnerKeyRequest) (*Ge
 	if err != nil {
 		return nil, err
 	}
+	kemKey, err := srv.verifiers[id].KEMPublicKey(int(in.Round))
+	if err != nil {
+		return nil, err
+	}
 
 	return &GetInnerKeyResponse{
-		X: x.Bytes(),
-		Y: y.Bytes(),
+		X:      x.Bytes(),
+		Y:      y.Bytes(),
+		KemKey: kemKey,
 	}, nil
 }
 
@@ -701,13 +782,22 @@ func (srv *server) GetPrivateInnerKey(ctx context.Context, in *GetPrivateInnerKe
 	state := srv.states[id][round]
 	state.shuffleWg.Wait()
 
-	// wait for all servers to shuffle, and return
+	// wait for all servers to shuffle, and check their proofs
+	err := srv.verifyProofs(state, id, round)
+	if err != nil {
+		return nil, err
+	}
 	priv, err := verifier.PrivateKey(round)
 	if err != nil {
 		return nil, err
 	}
+	kemPriv, err := verifier.KEMPrivateKey(round)
+	if err != nil {
+		return nil, err
+	}
 	return &GetPrivateInnerKeyResponse{
-		PrivateKey: priv.Bytes(),
+		PrivateKey:    priv.Bytes(),
+		KemPrivateKey: kemPriv,
 	}, nil
 }
 
@@ -730,6 +820,7 @@ func (srv *server) Finalize(ctx context.Context, in *FinalizeRequest) (*Finalize
 
 	group := srv.partOf[id]
 	privateKeys := make([][]byte, len(group.Servers))
+	kemPrivateKeys := make([][]byte, len(group.Servers))
 	errs := make(chan error, len(group.Servers))
 	for i, sid := range group.Servers {
 		go func(i int, sid string) {
@@ -740,6 +831,7 @@ func (srv *server) Finalize(ctx context.Context, in *FinalizeRequest) (*Finalize
 			resp, err := srv.groupRpcs[id][i].GetPrivateInnerKey(ctx, req)
 			if err == nil {
 				privateKeys[i] = resp.PrivateKey
+				kemPrivateKeys[i] = resp.KemPrivateKey
 			}
 			errs <- err
 		}(i, sid)
@@ -751,9 +843,25 @@ func (srv *server) Finalize(ctx context.Context, in *FinalizeRequest) (*Finalize
 		}
 	}
 
-	plaintexts, err := verifier.Finalize(round, privateKeys)
+	plaintexts, err := verifier.Finalize(round, privateKeys, kemPrivateKeys)
 
 	return &FinalizeResponse{
 		Plaintexts: plaintexts,
 	}, err
 }
+
+// TokenReport returns the tokens spent at the chains this server is the
+// first of.
+func (srv *server) TokenReport(ctx context.Context, in *TokenReportRequest) (*TokenReportResponse, error) {
+	resp := &TokenReportResponse{}
+	srv.slock.Lock()
+	for _, states := range srv.states {
+		state, ok := states[int(in.Round)]
+		if ok && state.tokens != nil {
+			resp.Chains = append(resp.Chains, state.tokens.report())
+		}
+	}
+	srv.slock.Unlock()
+	sort.Slice(resp.Chains, func(i, j int) bool { return resp.Chains[i].Gid < resp.Chains[j].Gid })
+	return resp, nil
+}
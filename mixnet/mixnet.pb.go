@@ -35,6 +35,9 @@
 		GetPrivateInnerKeyResponse
 		FinalizeRequest
 		FinalizeResponse
+		TokenReportRequest
+		TokenRedemption
+		TokenReportResponse
 */
 package mixnet
 
@@ -82,7 +85,9 @@ func (x Source) String() string {
 func (Source) EnumDescriptor() ([]byte, []int) { return fileDescriptorMixnet, []int{0} }
 
 type NewRoundRequest struct {
-	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	Round    uint64            `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	TokenKey []byte            `protobuf:"bytes,2,opt,name=token_key,json=tokenKey,proto3" json:"token_key,omitempty"`
+	Suites   map[string]string `protobuf:"bytes,3,rep,name=suites" json:"suites,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *NewRoundRequest) Reset()                    { *m = NewRoundRequest{} }
@@ -97,6 +102,20 @@ func (m *NewRoundRequest) GetRound() uint64 {
 	return 0
 }
 
+func (m *NewRoundRequest) GetTokenKey() []byte {
+	if m != nil {
+		return m.TokenKey
+	}
+	return nil
+}
+
+func (m *NewRoundRequest) GetSuites() map[string]string {
+	if m != nil {
+		return m.Suites
+	}
+	return nil
+}
+
 type NewRoundResponse struct {
 }
 
@@ -221,6 +240,7 @@ type SubmitCiphertextsRequest struct {
 	Round       uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 	Ciphertexts [][]byte `protobuf:"bytes,2,rep,name=ciphertexts" json:"ciphertexts,omitempty"`
 	Proofs      [][]byte `protobuf:"bytes,3,rep,name=proofs" json:"proofs,omitempty"`
+	Tokens      [][]byte `protobuf:"bytes,4,rep,name=tokens" json:"tokens,omitempty"`
 }
 
 func (m *SubmitCiphertextsRequest) Reset()                    { *m = SubmitCiphertextsRequest{} }
@@ -249,6 +269,13 @@ func (m *SubmitCiphertextsRequest) GetProofs() [][]byte {
 	return nil
 }
 
+func (m *SubmitCiphertextsRequest) GetTokens() [][]byte {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
 type SubmitCiphertextsResponse struct {
 }
 
@@ -432,6 +459,8 @@ func (m *GetInnerKeyRequest) GetRound() uint64 {
 type GetInnerKeyResponse struct {
 	X []byte `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
 	Y []byte `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+	// ml-kem key, only set in hybrid mode
+	KemKey []byte `protobuf:"bytes,3,opt,name=kem_key,json=kemKey,proto3" json:"kem_key,omitempty"`
 }
 
 func (m *GetInnerKeyResponse) Reset()                    { *m = GetInnerKeyResponse{} }
@@ -453,6 +482,13 @@ func (m *GetInnerKeyResponse) GetY() []byte {
 	return nil
 }
 
+func (m *GetInnerKeyResponse) GetKemKey() []byte {
+	if m != nil {
+		return m.KemKey
+	}
+	return nil
+}
+
 type AddInnerCiphertextsRequest struct {
 	Round    uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 	Messages [][]byte `protobuf:"bytes,2,rep,name=messages" json:"messages,omitempty"`
@@ -507,6 +543,8 @@ func (m *GetPrivateInnerKeyRequest) GetRound() uint64 {
 
 type GetPrivateInnerKeyResponse struct {
 	PrivateKey []byte `protobuf:"bytes,1,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// ml-kem key, only set in hybrid mode
+	KemPrivateKey []byte `protobuf:"bytes,2,opt,name=kem_private_key,json=kemPrivateKey,proto3" json:"kem_private_key,omitempty"`
 }
 
 func (m *GetPrivateInnerKeyResponse) Reset()         { *m = GetPrivateInnerKeyResponse{} }
@@ -523,6 +561,13 @@ func (m *GetPrivateInnerKeyResponse) GetPrivateKey() []byte {
 	return nil
 }
 
+func (m *GetPrivateInnerKeyResponse) GetKemPrivateKey() []byte {
+	if m != nil {
+		return m.KemPrivateKey
+	}
+	return nil
+}
+
 type FinalizeRequest struct {
 	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 }
@@ -555,6 +600,70 @@ func (m *FinalizeResponse) GetPlaintexts() [][]byte {
 	return nil
 }
 
+type TokenReportRequest struct {
+	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (m *TokenReportRequest) Reset()                    { *m = TokenReportRequest{} }
+func (m *TokenReportRequest) String() string            { return proto.CompactTextString(m) }
+func (*TokenReportRequest) ProtoMessage()               {}
+func (*TokenReportRequest) Descriptor() ([]byte, []int) { return fileDescriptorMixnet, []int{27} }
+
+func (m *TokenReportRequest) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+type TokenRedemption struct {
+	Gid      string `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Redeemed uint64 `protobuf:"fixed64,2,opt,name=redeemed,proto3" json:"redeemed,omitempty"`
+	Invalid  uint64 `protobuf:"fixed64,3,opt,name=invalid,proto3" json:"invalid,omitempty"`
+}
+
+func (m *TokenRedemption) Reset()                    { *m = TokenRedemption{} }
+func (m *TokenRedemption) String() string            { return proto.CompactTextString(m) }
+func (*TokenRedemption) ProtoMessage()               {}
+func (*TokenRedemption) Descriptor() ([]byte, []int) { return fileDescriptorMixnet, []int{28} }
+
+func (m *TokenRedemption) GetGid() string {
+	if m != nil {
+		return m.Gid
+	}
+	return ""
+}
+
+func (m *TokenRedemption) GetRedeemed() uint64 {
+	if m != nil {
+		return m.Redeemed
+	}
+	return 0
+}
+
+func (m *TokenRedemption) GetInvalid() uint64 {
+	if m != nil {
+		return m.Invalid
+	}
+	return 0
+}
+
+type TokenReportResponse struct {
+	Chains []*TokenRedemption `protobuf:"bytes,1,rep,name=chains" json:"chains,omitempty"`
+}
+
+func (m *TokenReportResponse) Reset()                    { *m = TokenReportResponse{} }
+func (m *TokenReportResponse) String() string            { return proto.CompactTextString(m) }
+func (*TokenReportResponse) ProtoMessage()               {}
+func (*TokenReportResponse) Descriptor() ([]byte, []int) { return fileDescriptorMixnet, []int{29} }
+
+func (m *TokenReportResponse) GetChains() []*TokenRedemption {
+	if m != nil {
+		return m.Chains
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NewRoundRequest)(nil), "mixnet.NewRoundRequest")
 	proto.RegisterType((*NewRoundResponse)(nil), "mixnet.NewRoundResponse")
@@ -583,6 +692,9 @@ func init() {
 	proto.RegisterType((*GetPrivateInnerKeyResponse)(nil), "mixnet.GetPrivateInnerKeyResponse")
 	proto.RegisterType((*FinalizeRequest)(nil), "mixnet.FinalizeRequest")
 	proto.RegisterType((*FinalizeResponse)(nil), "mixnet.FinalizeResponse")
+	proto.RegisterType((*TokenReportRequest)(nil), "mixnet.TokenReportRequest")
+	proto.RegisterType((*TokenRedemption)(nil), "mixnet.TokenRedemption")
+	proto.RegisterType((*TokenReportResponse)(nil), "mixnet.TokenReportResponse")
 	proto.RegisterEnum("mixnet.Source", Source_name, Source_value)
 }
 
@@ -610,6 +722,8 @@ type MixClient interface {
 	AddInnerCiphertexts(ctx context.Context, in *AddInnerCiphertextsRequest, opts ...grpc.CallOption) (*AddInnerCiphertextsResponse, error)
 	GetPrivateInnerKey(ctx context.Context, in *GetPrivateInnerKeyRequest, opts ...grpc.CallOption) (*GetPrivateInnerKeyResponse, error)
 	Finalize(ctx context.Context, in *FinalizeRequest, opts ...grpc.CallOption) (*FinalizeResponse, error)
+	// tokens spent at the first servers of the chains, see token.go
+	TokenReport(ctx context.Context, in *TokenReportRequest, opts ...grpc.CallOption) (*TokenReportResponse, error)
 }
 
 type mixClient struct {
@@ -803,6 +917,15 @@ func (c *mixClient) Finalize(ctx context.Context, in *FinalizeRequest, opts ...g
 	return out, nil
 }
 
+func (c *mixClient) TokenReport(ctx context.Context, in *TokenReportRequest, opts ...grpc.CallOption) (*TokenReportResponse, error) {
+	out := new(TokenReportResponse)
+	err := grpc.Invoke(ctx, "/mixnet.Mix/TokenReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Mix service
 
 type MixServer interface {
@@ -819,6 +942,8 @@ type MixServer interface {
 	AddInnerCiphertexts(context.Context, *AddInnerCiphertextsRequest) (*AddInnerCiphertextsResponse, error)
 	GetPrivateInnerKey(context.Context, *GetPrivateInnerKeyRequest) (*GetPrivateInnerKeyResponse, error)
 	Finalize(context.Context, *FinalizeRequest) (*FinalizeResponse, error)
+	// tokens spent at the first servers of the chains, see token.go
+	TokenReport(context.Context, *TokenReportRequest) (*TokenReportResponse, error)
 }
 
 func RegisterMixServer(s *grpc.Server, srv MixServer) {
@@ -1065,6 +1190,24 @@ func _Mix_Finalize_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mix_TokenReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MixServer).TokenReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mixnet.Mix/TokenReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MixServer).TokenReport(ctx, req.(*TokenReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Mix_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mixnet.Mix",
 	HandlerType: (*MixServer)(nil),
@@ -1105,6 +1248,10 @@ var _Mix_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Finalize",
 			Handler:    _Mix_Finalize_Handler,
 		},
+		{
+			MethodName: "TokenReport",
+			Handler:    _Mix_TokenReport_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1147,6 +1294,29 @@ func (m *NewRoundRequest) MarshalTo(dAtA []byte) (int, error) {
 		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
 		i += 8
 	}
+	if len(m.TokenKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMixnet(dAtA, i, uint64(len(m.TokenKey)))
+		i += copy(dAtA[i:], m.TokenKey)
+	}
+	if len(m.Suites) > 0 {
+		for k, _ := range m.Suites {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Suites[k]
+			mapSize := 1 + len(k) + sovMixnet(uint64(len(k))) + 1 + len(v) + sovMixnet(uint64(len(v)))
+			i = encodeVarintMixnet(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMixnet(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMixnet(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
 	return i, nil
 }
 
@@ -1389,6 +1559,14 @@ func (m *SubmitCiphertextsRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], b)
 		}
 	}
+	if len(m.Tokens) > 0 {
+		for _, b := range m.Tokens {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintMixnet(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
 	return i, nil
 }
 
@@ -1665,6 +1843,12 @@ func (m *GetInnerKeyResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintMixnet(dAtA, i, uint64(len(m.Y)))
 		i += copy(dAtA[i:], m.Y)
 	}
+	if len(m.KemKey) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMixnet(dAtA, i, uint64(len(m.KemKey)))
+		i += copy(dAtA[i:], m.KemKey)
+	}
 	return i, nil
 }
 
@@ -1763,6 +1947,12 @@ func (m *GetPrivateInnerKeyResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintMixnet(dAtA, i, uint64(len(m.PrivateKey)))
 		i += copy(dAtA[i:], m.PrivateKey)
 	}
+	if len(m.KemPrivateKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMixnet(dAtA, i, uint64(len(m.KemPrivateKey)))
+		i += copy(dAtA[i:], m.KemPrivateKey)
+	}
 	return i, nil
 }
 
@@ -1816,6 +2006,96 @@ func (m *FinalizeResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *TokenReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *TokenRedemption) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenRedemption) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Gid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMixnet(dAtA, i, uint64(len(m.Gid)))
+		i += copy(dAtA[i:], m.Gid)
+	}
+	if m.Redeemed != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Redeemed))
+		i += 8
+	}
+	if m.Invalid != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Invalid))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *TokenReportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenReportResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for _, msg := range m.Chains {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintMixnet(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
 func encodeVarintMixnet(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1831,6 +2111,18 @@ func (m *NewRoundRequest) Size() (n int) {
 	if m.Round != 0 {
 		n += 9
 	}
+	l = len(m.TokenKey)
+	if l > 0 {
+		n += 1 + l + sovMixnet(uint64(l))
+	}
+	if len(m.Suites) > 0 {
+		for k, v := range m.Suites {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovMixnet(uint64(len(k))) + 1 + len(v) + sovMixnet(uint64(len(v)))
+			n += mapEntrySize + 1 + sovMixnet(uint64(mapEntrySize))
+		}
+	}
 	return n
 }
 
@@ -1930,6 +2222,12 @@ func (m *SubmitCiphertextsRequest) Size() (n int) {
 			n += 1 + l + sovMixnet(uint64(l))
 		}
 	}
+	if len(m.Tokens) > 0 {
+		for _, b := range m.Tokens {
+			l = len(b)
+			n += 1 + l + sovMixnet(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2047,6 +2345,10 @@ func (m *GetInnerKeyResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMixnet(uint64(l))
 	}
+	l = len(m.KemKey)
+	if l > 0 {
+		n += 1 + l + sovMixnet(uint64(l))
+	}
 	return n
 }
 
@@ -2087,6 +2389,10 @@ func (m *GetPrivateInnerKeyResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMixnet(uint64(l))
 	}
+	l = len(m.KemPrivateKey)
+	if l > 0 {
+		n += 1 + l + sovMixnet(uint64(l))
+	}
 	return n
 }
 
@@ -2111,6 +2417,43 @@ func (m *FinalizeResponse) Size() (n int) {
 	return n
 }
 
+func (m *TokenReportRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *TokenRedemption) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Gid)
+	if l > 0 {
+		n += 1 + l + sovMixnet(uint64(l))
+	}
+	if m.Redeemed != 0 {
+		n += 9
+	}
+	if m.Invalid != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *TokenReportResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Chains) > 0 {
+		for _, e := range m.Chains {
+			l = e.Size()
+			n += 1 + l + sovMixnet(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovMixnet(x uint64) (n int) {
 	for {
 		n++
@@ -2163,31 +2506,180 @@ func (m *NewRoundRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
-		default:
-			iNdEx = preIndex
-			skippy, err := skipMixnet(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenKey", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMixnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthMixnet
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *NewRoundResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
+			m.TokenKey = append(m.TokenKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.TokenKey == nil {
+				m.TokenKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suites", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMixnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Suites == nil {
+				m.Suites = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMixnet
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMixnet
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthMixnet
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMixnet
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthMixnet
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipMixnet(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthMixnet
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Suites[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMixnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NewRoundResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
 		preIndex := iNdEx
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -2829,6 +3321,35 @@ func (m *SubmitCiphertextsRequest) Unmarshal(dAtA []byte) error {
 			m.Proofs = append(m.Proofs, make([]byte, postIndex-iNdEx))
 			copy(m.Proofs[len(m.Proofs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMixnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tokens = append(m.Tokens, make([]byte, postIndex-iNdEx))
+			copy(m.Tokens[len(m.Tokens)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMixnet(dAtA[iNdEx:])
@@ -3697,6 +4218,37 @@ func (m *GetInnerKeyResponse) Unmarshal(dAtA []byte) error {
 				m.Y = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KemKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMixnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KemKey = append(m.KemKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.KemKey == nil {
+				m.KemKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMixnet(dAtA[iNdEx:])
@@ -3977,6 +4529,37 @@ func (m *GetPrivateInnerKeyResponse) Unmarshal(dAtA []byte) error {
 				m.PrivateKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KemPrivateKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMixnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KemPrivateKey = append(m.KemPrivateKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.KemPrivateKey == nil {
+				m.KemPrivateKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMixnet(dAtA[iNdEx:])
@@ -4137,6 +4720,246 @@ func (m *FinalizeResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *TokenReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMixnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMixnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenRedemption) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMixnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenRedemption: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenRedemption: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMixnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Redeemed", wireType)
+			}
+			m.Redeemed = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Redeemed = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Invalid", wireType)
+			}
+			m.Invalid = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Invalid = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMixnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenReportResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMixnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenReportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenReportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chains", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMixnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chains = append(m.Chains, &TokenRedemption{})
+			if err := m.Chains[len(m.Chains)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMixnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMixnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMixnet(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4245,52 +5068,65 @@ var (
 func init() { proto.RegisterFile("mixnet.proto", fileDescriptorMixnet) }
 
 var fileDescriptorMixnet = []byte{
-	// 741 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdf, 0x52, 0xd3, 0x4e,
-	0x14, 0x4e, 0xe8, 0x8f, 0xc2, 0xef, 0xb4, 0x33, 0x96, 0x03, 0x6a, 0xd8, 0x0e, 0x35, 0xac, 0x17,
-	0x54, 0x2e, 0x98, 0x01, 0xaf, 0x1d, 0x07, 0x98, 0x82, 0x80, 0x74, 0x98, 0xd4, 0xe1, 0x4a, 0x47,
-	0x4b, 0xb3, 0xe8, 0x02, 0x4d, 0x62, 0x92, 0x62, 0xe3, 0x93, 0xf8, 0x30, 0x3e, 0x80, 0x97, 0x3e,
-	0x82, 0x83, 0x2f, 0xe2, 0x24, 0xd9, 0x74, 0xd3, 0x24, 0x84, 0xde, 0xf5, 0x64, 0xbf, 0xf3, 0x9d,
-	0x3f, 0xbb, 0xdf, 0x37, 0x85, 0xfa, 0x90, 0x8f, 0x2d, 0xe6, 0x6f, 0x39, 0xae, 0xed, 0xdb, 0x58,
-	0x8d, 0x23, 0xba, 0x01, 0x8f, 0xba, 0xec, 0x9b, 0x61, 0x8f, 0x2c, 0xd3, 0x60, 0x5f, 0x47, 0xcc,
-	0xf3, 0x71, 0x05, 0xe6, 0xdd, 0x30, 0xd6, 0x54, 0x5d, 0x6d, 0x57, 0x8d, 0x38, 0xa0, 0x08, 0x0d,
-	0x09, 0xf4, 0x1c, 0xdb, 0xf2, 0x58, 0x98, 0xdc, 0xb1, 0xcc, 0xd9, 0x92, 0x25, 0x50, 0x24, 0x1f,
-	0x00, 0xee, 0x9a, 0xe6, 0x29, 0xf3, 0xbc, 0xfe, 0x67, 0xe6, 0x95, 0xe6, 0x23, 0x81, 0xc5, 0xa1,
-	0x00, 0x6a, 0x73, 0x7a, 0xa5, 0x5d, 0x37, 0x26, 0x31, 0x7d, 0x0c, 0xcb, 0x53, 0x3c, 0x82, 0xfe,
-	0x05, 0x2c, 0xf5, 0xfc, 0xbe, 0xeb, 0xcf, 0xd0, 0xdd, 0x0a, 0x60, 0x1a, 0x2a, 0x08, 0x36, 0x01,
-	0x0f, 0x99, 0x3f, 0x53, 0x7f, 0x74, 0x1b, 0x96, 0xa7, 0xb0, 0x31, 0xc5, 0x54, 0xdb, 0x6a, 0xa6,
-	0xed, 0x2b, 0xd0, 0x7a, 0xa3, 0x8b, 0x21, 0xf7, 0xf7, 0xb9, 0xf3, 0x85, 0xb9, 0x3e, 0x1b, 0xfb,
-	0x0f, 0x2c, 0x41, 0x87, 0xda, 0x40, 0x62, 0xc5, 0x1e, 0xd2, 0x9f, 0xf0, 0x09, 0x54, 0x1d, 0xd7,
-	0xb6, 0x2f, 0x3d, 0xad, 0x12, 0x1d, 0x8a, 0x88, 0x36, 0x61, 0xb5, 0xa0, 0x96, 0x98, 0xf3, 0x0a,
-	0xf0, 0x9c, 0xb9, 0xfc, 0x32, 0x38, 0x0b, 0xc1, 0xe5, 0x2d, 0xac, 0xc0, 0x3c, 0xb7, 0x4c, 0x36,
-	0xd6, 0xe6, 0x74, 0xb5, 0xbd, 0x60, 0xc4, 0x01, 0x22, 0xfc, 0x77, 0xcd, 0x82, 0xa4, 0x68, 0xf4,
-	0x3b, 0x44, 0x46, 0xc5, 0xb5, 0x79, 0x5d, 0x6d, 0xd7, 0x8d, 0x38, 0x08, 0xef, 0x6a, 0xaa, 0x96,
-	0x68, 0xa1, 0x0b, 0x64, 0xdf, 0xb6, 0x2e, 0xb9, 0x3b, 0x8c, 0x4e, 0xf9, 0xa0, 0xef, 0x73, 0xdb,
-	0x7a, 0xf0, 0x49, 0xdc, 0x46, 0x60, 0x66, 0x46, 0xdd, 0x2c, 0x1a, 0x93, 0x98, 0xae, 0x41, 0xb3,
-	0x90, 0x4f, 0x94, 0x23, 0x00, 0x67, 0x2e, 0xbf, 0xed, 0xfb, 0xec, 0x84, 0x05, 0x58, 0x07, 0x75,
-	0x1c, 0x51, 0xd7, 0x0d, 0x75, 0x4c, 0x37, 0xe0, 0xff, 0xb3, 0xd1, 0xc5, 0x0d, 0x1f, 0xe4, 0x8e,
-	0xc2, 0x28, 0x88, 0x4a, 0xd5, 0x0d, 0x35, 0xa0, 0x7b, 0x00, 0x72, 0x9b, 0x65, 0x48, 0xd4, 0x60,
-	0x41, 0xdc, 0xba, 0x56, 0x89, 0xbe, 0x25, 0xa1, 0x78, 0x62, 0x47, 0x96, 0xc5, 0xdc, 0x13, 0x16,
-	0xcc, 0xf2, 0xc4, 0x24, 0x56, 0x3c, 0xb1, 0xb2, 0x16, 0xbb, 0x40, 0x76, 0x4d, 0x33, 0x4a, 0x99,
-	0xf9, 0x91, 0x95, 0x29, 0x6d, 0x0d, 0x9a, 0x85, 0x7c, 0x62, 0xad, 0xdb, 0xb0, 0x7a, 0xc8, 0x7c,
-	0xb1, 0xd9, 0xd9, 0x86, 0x7a, 0x05, 0xa4, 0x28, 0x45, 0xcc, 0xf6, 0x0c, 0x6a, 0x4e, 0x7c, 0xf4,
-	0xf1, 0x9a, 0x05, 0x62, 0x4a, 0x70, 0x26, 0x57, 0x17, 0xfa, 0xcf, 0x01, 0xb7, 0xfa, 0x37, 0xfc,
-	0x3b, 0x2b, 0xaf, 0xb3, 0x03, 0x0d, 0x09, 0x14, 0xec, 0x2d, 0x00, 0xe7, 0xa6, 0xcf, 0xad, 0x58,
-	0x4d, 0xb1, 0x3c, 0x53, 0x5f, 0x36, 0x75, 0xa8, 0xf6, 0xec, 0x91, 0x3b, 0x60, 0x08, 0x50, 0xdd,
-	0x7f, 0x7b, 0xd4, 0xe9, 0xbe, 0x6b, 0x28, 0xe1, 0xef, 0x5e, 0xc7, 0x38, 0xef, 0x18, 0x0d, 0x75,
-	0xe7, 0xe7, 0x02, 0x54, 0x4e, 0xf9, 0x18, 0x5f, 0xc3, 0x62, 0x62, 0x8d, 0xf8, 0x74, 0x4b, 0xd8,
-	0x6c, 0xc6, 0x55, 0x89, 0x96, 0x3f, 0x10, 0x7b, 0x53, 0x42, 0x82, 0xc4, 0x1e, 0x25, 0x41, 0xc6,
-	0x59, 0x25, 0x41, 0xce, 0x49, 0x15, 0x3c, 0x86, 0x5a, 0xca, 0x03, 0x91, 0x24, 0xd0, 0xbc, 0xc1,
-	0x92, 0x66, 0xe1, 0x59, 0xc2, 0xd4, 0x56, 0xf1, 0x0d, 0xd4, 0x52, 0x5e, 0x26, 0xb9, 0xf2, 0x66,
-	0x28, 0xb9, 0x0a, 0xcc, 0x8f, 0x2a, 0xd8, 0x01, 0x90, 0xbe, 0x8a, 0xab, 0x09, 0x38, 0x67, 0xcb,
-	0x84, 0x14, 0x1d, 0x4d, 0x68, 0xde, 0xc3, 0x52, 0xce, 0xbd, 0x50, 0x9f, 0xa4, 0xdc, 0x63, 0xa2,
-	0x64, 0xbd, 0x04, 0x91, 0x1a, 0xf7, 0x18, 0x6a, 0x29, 0x4b, 0x92, 0xe3, 0xe6, 0x3d, 0x51, 0x8e,
-	0x5b, 0xe4, 0x61, 0x21, 0xd7, 0x27, 0x58, 0x2e, 0xf0, 0x1d, 0xa4, 0x49, 0xde, 0xfd, 0x26, 0x47,
-	0x9e, 0x97, 0x62, 0x26, 0xbb, 0x88, 0x2f, 0x27, 0x51, 0xca, 0xd4, 0xe5, 0x64, 0x14, 0x37, 0x75,
-	0x39, 0x59, 0x69, 0x51, 0x25, 0xec, 0xb5, 0x40, 0xcc, 0xb2, 0xd7, 0xfb, 0x9d, 0x43, 0xf6, 0x5a,
-	0xe6, 0x06, 0x0a, 0x7e, 0x88, 0xdc, 0x2d, 0x23, 0x6e, 0x5c, 0x4f, 0xb5, 0x55, 0xec, 0x15, 0x84,
-	0x96, 0x41, 0xd2, 0xa2, 0x49, 0x34, 0x2d, 0x45, 0x93, 0xb1, 0x03, 0x29, 0x9a, 0xac, 0xfc, 0xa9,
-	0xb2, 0xd7, 0xf8, 0x75, 0xd7, 0x52, 0x7f, 0xdf, 0xb5, 0xd4, 0x3f, 0x77, 0x2d, 0xf5, 0xc7, 0xdf,
-	0x96, 0x72, 0x51, 0x8d, 0xfe, 0x1b, 0xbd, 0xfc, 0x17, 0x00, 0x00, 0xff, 0xff, 0xd1, 0x7c, 0x0c,
-	0xfb, 0x2b, 0x09, 0x00, 0x00,
+	// 956 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x36, 0xad, 0x98, 0x96, 0x47, 0x2a, 0xac, 0xac, 0xdc, 0x9a, 0x5e, 0x22, 0xaa, 0x42, 0x03,
+	0x8d, 0x9b, 0x83, 0x8b, 0xb8, 0x97, 0xfe, 0x1c, 0x8a, 0xc4, 0x90, 0xd3, 0xd8, 0x8d, 0x61, 0xac,
+	0x82, 0x00, 0x05, 0x5a, 0x24, 0xb4, 0x38, 0xb6, 0xb7, 0x12, 0x7f, 0x4a, 0x52, 0xae, 0xd4, 0x63,
+	0x0f, 0x7d, 0x86, 0x3e, 0x4c, 0x1f, 0xa0, 0xc7, 0x3e, 0x42, 0xe1, 0xbe, 0x48, 0xb0, 0xcb, 0xe5,
+	0x8f, 0x44, 0x9a, 0xf6, 0x8d, 0xb3, 0x33, 0xfb, 0xcd, 0xb7, 0xb3, 0xb3, 0xdf, 0x10, 0xda, 0x2e,
+	0x9f, 0x79, 0x18, 0xef, 0x07, 0xa1, 0x1f, 0xfb, 0x44, 0x4f, 0x2c, 0xeb, 0x6f, 0x0d, 0x36, 0x4f,
+	0xf1, 0x37, 0xe6, 0x4f, 0x3d, 0x87, 0xe1, 0xaf, 0x53, 0x8c, 0x62, 0xb2, 0x05, 0x6b, 0xa1, 0xb0,
+	0x0d, 0xad, 0xaf, 0xed, 0xe9, 0x2c, 0x31, 0x88, 0x09, 0x1b, 0xb1, 0x3f, 0x46, 0xef, 0xdd, 0x18,
+	0xe7, 0xc6, 0x6a, 0x5f, 0xdb, 0x6b, 0xb3, 0xa6, 0x5c, 0x38, 0xc1, 0x39, 0xf9, 0x16, 0xf4, 0x68,
+	0xca, 0x63, 0x8c, 0x8c, 0x46, 0xbf, 0xb1, 0xd7, 0x3a, 0xd8, 0xdd, 0x57, 0xd9, 0x96, 0xb0, 0xf7,
+	0x87, 0x32, 0x6a, 0xe0, 0xc5, 0xe1, 0x9c, 0xa9, 0x2d, 0xf4, 0x6b, 0x68, 0x15, 0x96, 0x49, 0x07,
+	0x1a, 0x22, 0x85, 0x48, 0xbe, 0xc1, 0xc4, 0xa7, 0x20, 0x74, 0x6d, 0x4f, 0xa6, 0x28, 0xd3, 0x6e,
+	0xb0, 0xc4, 0xf8, 0x66, 0xf5, 0x2b, 0xcd, 0x22, 0xd0, 0xc9, 0x33, 0x44, 0x81, 0xef, 0x45, 0x68,
+	0x3d, 0x81, 0xcd, 0x81, 0xe7, 0xdc, 0x7d, 0x22, 0xb1, 0x39, 0x0f, 0x54, 0x9b, 0x8f, 0x80, 0x3c,
+	0x77, 0x9c, 0xd7, 0x18, 0x45, 0xf6, 0x25, 0x46, 0xf5, 0x15, 0xa1, 0xd0, 0x74, 0x55, 0xa0, 0xb1,
+	0xda, 0x6f, 0x88, 0x82, 0xa4, 0xb6, 0xf5, 0x31, 0x74, 0x17, 0x70, 0x14, 0xfc, 0xe7, 0xf0, 0x70,
+	0x18, 0xdb, 0x61, 0x7c, 0x0f, 0x76, 0x5b, 0x40, 0x8a, 0xa1, 0x0a, 0xe0, 0x29, 0x90, 0x97, 0x18,
+	0xdf, 0x8b, 0x9f, 0xf5, 0x0c, 0xba, 0x0b, 0xb1, 0x09, 0xc4, 0x02, 0x6d, 0x6d, 0x89, 0xf6, 0x1f,
+	0x1a, 0x18, 0xc3, 0xe9, 0xb9, 0xcb, 0xe3, 0x43, 0x1e, 0x5c, 0x61, 0x18, 0xe3, 0x2c, 0xbe, 0xa3,
+	0x0a, 0x7d, 0x68, 0x8d, 0xf2, 0x58, 0x55, 0x88, 0xe2, 0x12, 0xf9, 0x04, 0xf4, 0x20, 0xf4, 0xfd,
+	0x8b, 0xa4, 0x39, 0xda, 0x4c, 0x59, 0x62, 0x5d, 0x36, 0x50, 0x64, 0x3c, 0x48, 0xd6, 0x13, 0xcb,
+	0x32, 0x61, 0xa7, 0x82, 0x83, 0x2a, 0xc0, 0x2f, 0x40, 0xde, 0x62, 0xc8, 0x2f, 0xe6, 0x67, 0x02,
+	0xa4, 0x9e, 0xda, 0x16, 0xac, 0x71, 0xcf, 0xc1, 0x99, 0xec, 0x9b, 0x75, 0x96, 0x18, 0x84, 0xc0,
+	0x83, 0x31, 0xce, 0x53, 0x32, 0xf2, 0x5b, 0x44, 0x4a, 0x52, 0xc6, 0x9a, 0x6c, 0xec, 0xc4, 0x10,
+	0x97, 0xb8, 0x90, 0x4b, 0x51, 0x38, 0x05, 0x7a, 0xe8, 0x7b, 0x17, 0x3c, 0x74, 0xa5, 0x97, 0x8f,
+	0xec, 0x98, 0xfb, 0xde, 0x9d, 0xbd, 0x72, 0x2d, 0x83, 0xd1, 0x91, 0x6c, 0x9a, 0x2c, 0xb3, 0xad,
+	0x47, 0x60, 0x56, 0xe2, 0xa9, 0x74, 0x14, 0xe0, 0x2c, 0xe4, 0xd7, 0x76, 0x8c, 0xe2, 0xa5, 0xb5,
+	0x41, 0x9b, 0x49, 0xe8, 0x36, 0xd3, 0x66, 0xd6, 0x13, 0xd8, 0x38, 0x9b, 0x9e, 0x4f, 0xf8, 0xa8,
+	0xe4, 0x12, 0x56, 0xfa, 0x4e, 0xb5, 0xb9, 0xf5, 0x02, 0x20, 0xaf, 0x66, 0x5d, 0x24, 0x31, 0x60,
+	0x5d, 0xb5, 0x83, 0xd1, 0x90, 0x6b, 0xa9, 0xa9, 0x7a, 0xef, 0x95, 0xe7, 0x61, 0x78, 0x82, 0xf3,
+	0xfa, 0xde, 0x3b, 0x86, 0xee, 0x42, 0xac, 0xea, 0xbd, 0xba, 0xc4, 0xdb, 0xb0, 0x3e, 0x46, 0x57,
+	0xca, 0x4b, 0x92, 0x58, 0x1f, 0xa3, 0x7b, 0x82, 0x73, 0x51, 0xef, 0xe7, 0x8e, 0x23, 0xb1, 0xee,
+	0xdd, 0x95, 0x75, 0x6f, 0xf3, 0x11, 0x98, 0x95, 0x78, 0xaa, 0xde, 0xcf, 0x60, 0xe7, 0x25, 0xc6,
+	0xaa, 0xe4, 0xf7, 0x3b, 0x2d, 0x02, 0xad, 0xda, 0xa2, 0x0e, 0xfd, 0x29, 0xb4, 0x82, 0xc4, 0xf5,
+	0x2e, 0x15, 0xb6, 0x36, 0x83, 0x20, 0xbf, 0xd3, 0xcf, 0x60, 0x53, 0x9c, 0xbc, 0x18, 0x94, 0x54,
+	0xe5, 0xa3, 0x31, 0xba, 0xf9, 0xdd, 0x0b, 0x65, 0x3b, 0xe2, 0x9e, 0x3d, 0xe1, 0xbf, 0x63, 0x3d,
+	0x9f, 0x03, 0xe8, 0xe4, 0x81, 0x8a, 0x45, 0x0f, 0x20, 0x98, 0xd8, 0xdc, 0x4b, 0x9e, 0x69, 0xf2,
+	0xf0, 0x0b, 0x2b, 0xe2, 0x76, 0xdf, 0x88, 0xf7, 0xc7, 0x30, 0xf0, 0xc3, 0xb8, 0x1e, 0xff, 0x47,
+	0xd8, 0x54, 0xb1, 0x0e, 0xba, 0x81, 0xe8, 0x56, 0xa1, 0xda, 0x97, 0xdc, 0x49, 0x55, 0xfb, 0x92,
+	0xcb, 0x2b, 0x08, 0xd1, 0x41, 0x74, 0x55, 0xcb, 0xeb, 0x2c, 0xb3, 0x45, 0x93, 0x71, 0xef, 0xda,
+	0x9e, 0x70, 0x47, 0xde, 0xb5, 0xce, 0x52, 0xd3, 0x3a, 0x82, 0xee, 0x02, 0x0d, 0xc5, 0xfe, 0x0b,
+	0xd0, 0x47, 0x57, 0x36, 0xf7, 0x12, 0xe6, 0xad, 0x83, 0xed, 0x74, 0xc0, 0x2c, 0xf1, 0x60, 0x2a,
+	0xec, 0x69, 0x1f, 0xf4, 0xa1, 0x3f, 0x0d, 0x47, 0x48, 0x00, 0xf4, 0xc3, 0x1f, 0x5e, 0x0d, 0x4e,
+	0xdf, 0x74, 0x56, 0xc4, 0xf7, 0x70, 0xc0, 0xde, 0x0e, 0x58, 0x47, 0x3b, 0xf8, 0xb3, 0x09, 0x8d,
+	0xd7, 0x7c, 0x46, 0xbe, 0x83, 0x66, 0x3a, 0x43, 0xc8, 0xf6, 0x2d, 0x73, 0x8b, 0x1a, 0x65, 0x87,
+	0x6a, 0x97, 0x15, 0x01, 0x90, 0xce, 0x91, 0x1c, 0x60, 0x69, 0x04, 0x51, 0xa3, 0xec, 0xc8, 0x00,
+	0x8e, 0xa1, 0x55, 0x18, 0x16, 0x84, 0xa6, 0xa1, 0xe5, 0x49, 0x44, 0xcd, 0x4a, 0x5f, 0x8a, 0xb4,
+	0xa7, 0x91, 0xef, 0xa1, 0x55, 0x10, 0xfd, 0x1c, 0xab, 0x3c, 0x35, 0xa8, 0x59, 0xe9, 0xcb, 0x58,
+	0x0d, 0x00, 0xf2, 0x01, 0x44, 0x76, 0xd2, 0xe0, 0xd2, 0xfc, 0xa2, 0xb4, 0xca, 0x95, 0xc1, 0xfc,
+	0x04, 0x0f, 0x4b, 0x6a, 0x4e, 0xfa, 0xd9, 0x96, 0x5b, 0x86, 0x0d, 0x7d, 0x5c, 0x13, 0x51, 0x38,
+	0xee, 0x31, 0xb4, 0x0a, 0x12, 0x9d, 0x1f, 0xb7, 0x3c, 0x23, 0xa8, 0x59, 0xe9, 0x2b, 0x60, 0xbd,
+	0x87, 0x6e, 0x85, 0x0e, 0x13, 0x2b, 0xdd, 0x77, 0xbb, 0xe8, 0xd3, 0xdd, 0xda, 0x98, 0xac, 0x16,
+	0xc9, 0xe5, 0xa4, 0x02, 0xb1, 0x70, 0x39, 0x4b, 0x42, 0x43, 0xcd, 0x4a, 0x5f, 0x86, 0xf4, 0x5e,
+	0xfe, 0x5f, 0x2c, 0x6b, 0x58, 0xce, 0xf5, 0x76, 0xc1, 0xa4, 0xbb, 0xb5, 0x31, 0x59, 0x86, 0x9f,
+	0xa5, 0xda, 0x2f, 0x69, 0x1a, 0x79, 0x5c, 0xa0, 0x55, 0x2d, 0x91, 0xd4, 0xaa, 0x0b, 0x29, 0x3e,
+	0x9a, 0x54, 0xa2, 0xf2, 0x47, 0xb3, 0xa4, 0x6e, 0xd4, 0x28, 0x3b, 0x8a, 0xb5, 0x2c, 0x08, 0x45,
+	0x5e, 0xcb, 0xb2, 0x88, 0x51, 0xb3, 0xd2, 0x97, 0x22, 0xbd, 0xe8, 0xfc, 0x73, 0xd3, 0xd3, 0xfe,
+	0xbd, 0xe9, 0x69, 0xff, 0xdd, 0xf4, 0xb4, 0xbf, 0xfe, 0xef, 0xad, 0x9c, 0xeb, 0xf2, 0x27, 0xf9,
+	0xcb, 0x0f, 0x03, 0x00, 0x4b, 0x1c, 0xa9, 0xea, 0x34, 0x0b, 0x00, 0x00,
 }
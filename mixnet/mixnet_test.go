@@ -109,11 +109,15 @@ func createTestCiphertexts(num int, servers map[string]*config.Server, group *co
 		publicKeys[s] = servers[sid].PublicKey
 	}
 
+	suite, err := GroupSuite(group)
+	if err != nil {
+		panic(err)
+	}
 	for i := range msgs {
 		msgs[i] = make([]byte, 10)
 		rand.Read(msgs[i])
 
-		ciphertexts[i], prfs[i] = verifiable_mixnet.P256OnionEncrypt(msgs[i], auxs, nonces, publicKeys, true)
+		ciphertexts[i], prfs[i] = verifiable_mixnet.P256OnionEncrypt(suite, msgs[i], auxs, nonces, publicKeys, true)
 	}
 
 	return msgs, ciphertexts, prfs
@@ -150,6 +154,15 @@ func TestMixnet(t *testing.T) {
 			t.Error(err)
 		}
 		mixClients[m] = NewMixClient(conn)
+
+		// the round should use the suite of the chain
+		_, err = mixClients[m].NewRound(context.Background(), &NewRoundRequest{
+			Round:  0,
+			Suites: map[string]string{group.Gid: verifiable_mixnet.ChaCha20Poly1305.Name()},
+		})
+		if err == nil {
+			t.Error("Started a round with another cipher suite")
+		}
 		mixClients[m].NewRound(context.Background(), &NewRoundRequest{Round: 0})
 	}
 
@@ -0,0 +1,305 @@
+package mixnet
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// Submission tokens, to check that every user sends through all of
+// its chains. Every round, a user gets one token per chain from its
+// mailbox, and spends each with its ciphertext at the first server of
+// the chain. Comparing the tokens issued for a chain with the ones
+// spent at it finds the chains some users skipped.
+//
+// Tokens are RSA blind signatures over full domain hashes of a random
+// serial, so neither the mailbox nor the servers can link a spent
+// token to the user it was issued to. All the mailboxes share the
+// signing key, and every chain has its own public exponent, a prime
+// derived from its gid: a token signed for one chain cannot be spent
+// at another, even though the mailbox never sees what it signs.
+
+const TOKEN_SERIAL_SIZE = 32
+
+const tokenLabel = "xrd submission token"
+
+var (
+	errInvalidBlinded = errors.New("Invalid blinded token")
+	errInvalidToken   = errors.New("Invalid token signature")
+)
+
+func GenerateTokenKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("Could not generate a token key")
+	}
+	return key
+}
+
+func MarshalTokenKey(key *rsa.PrivateKey) []byte {
+	return x509.MarshalPKCS1PrivateKey(key)
+}
+
+func UnmarshalTokenKey(b []byte) (*rsa.PrivateKey, error) {
+	return x509.ParsePKCS1PrivateKey(b)
+}
+
+// MarshalTokenPublicKey only keeps the modulus, the exponents are
+// those of the chains.
+func MarshalTokenPublicKey(key *rsa.PublicKey) []byte {
+	return key.N.Bytes()
+}
+
+func UnmarshalTokenPublicKey(b []byte) (*rsa.PublicKey, error) {
+	n := new(big.Int).SetBytes(b)
+	if n.BitLen() < 2048 {
+		return nil, errors.New("Token key too short")
+	}
+	return &rsa.PublicKey{N: n}, nil
+}
+
+// exponents of the chains, by gid
+var tokenExponents sync.Map
+
+// tokenExponent returns the public exponent of the tokens of gid, the
+// first prime after a 64 bit hash of it.
+func tokenExponent(gid string) *big.Int {
+	if e, ok := tokenExponents.Load(gid); ok {
+		return e.(*big.Int)
+	}
+	h := sha256.Sum256([]byte(tokenLabel + " exponent " + gid))
+	e := new(big.Int).SetUint64(binary.BigEndian.Uint64(h[:]) | 1<<63 | 1)
+	two := big.NewInt(2)
+	for !e.ProbablyPrime(20) {
+		e.Add(e, two)
+	}
+	tokenExponents.Store(gid, e)
+	return e
+}
+
+// tokenHash hashes the serial of a token onto [0, n).
+func tokenHash(n *big.Int, round uint64, gid string, serial []byte) *big.Int {
+	size := (n.BitLen()+7)/8 + 16
+	out := make([]byte, 0, size+sha256.Size)
+	var counter [4]byte
+	for c := uint32(0); len(out) < size; c++ {
+		binary.BigEndian.PutUint32(counter[:], c)
+		h := sha256.New()
+		h.Write([]byte(tokenLabel))
+		h.Write(counter[:])
+		binary.Write(h, binary.BigEndian, round)
+		binary.Write(h, binary.BigEndian, uint32(len(gid)))
+		h.Write([]byte(gid))
+		h.Write(serial)
+		out = h.Sum(out)
+	}
+	m := new(big.Int).SetBytes(out[:size])
+	return m.Mod(m, n)
+}
+
+// A TokenBlinding is what a user keeps of a token until the mailbox
+// signs it.
+type TokenBlinding struct {
+	round  uint64
+	gid    string
+	serial []byte
+	// inverse of the blinding factor
+	unblind *big.Int
+}
+
+// BlindToken creates a token for the chain gid in round, and returns it
+// blinded, to be signed by the mailbox.
+func BlindToken(key *rsa.PublicKey, round uint64, gid string) (*TokenBlinding, []byte) {
+	serial := make([]byte, TOKEN_SERIAL_SIZE)
+	rand.Read(serial)
+
+	var r, unblind *big.Int
+	for unblind == nil {
+		var err error
+		r, err = rand.Int(rand.Reader, key.N)
+		if err != nil {
+			panic("Could not blind a token")
+		}
+		unblind = new(big.Int).ModInverse(r, key.N)
+	}
+	blinded := r.Exp(r, tokenExponent(gid), key.N)
+	blinded.Mul(blinded, tokenHash(key.N, round, gid, serial))
+	blinded.Mod(blinded, key.N)
+
+	return &TokenBlinding{
+		round:   round,
+		gid:     gid,
+		serial:  serial,
+		unblind: unblind,
+	}, blinded.FillBytes(make([]byte, (key.N.BitLen()+7)/8))
+}
+
+// Unblind returns the token out of the signature of the mailbox.
+func (b *TokenBlinding) Unblind(key *rsa.PublicKey, signature []byte) ([]byte, error) {
+	s := new(big.Int).SetBytes(signature)
+	s.Mul(s, b.unblind)
+	s.Mod(s, key.N)
+
+	size := (key.N.BitLen() + 7) / 8
+	token := make([]byte, TOKEN_SERIAL_SIZE+size)
+	copy(token, b.serial)
+	s.FillBytes(token[TOKEN_SERIAL_SIZE:])
+	if !VerifyToken(key, b.round, b.gid, token) {
+		return nil, errInvalidToken
+	}
+	return token, nil
+}
+
+// VerifyToken returns whether token is a token for the chain gid in
+// round.
+func VerifyToken(key *rsa.PublicKey, round uint64, gid string, token []byte) bool {
+	if len(token) != TOKEN_SERIAL_SIZE+(key.N.BitLen()+7)/8 {
+		return false
+	}
+	s := new(big.Int).SetBytes(token[TOKEN_SERIAL_SIZE:])
+	if s.Cmp(key.N) >= 0 {
+		return false
+	}
+	m := tokenHash(key.N, round, gid, token[:TOKEN_SERIAL_SIZE])
+	return s.Exp(s, tokenExponent(gid), key.N).Cmp(m) == 0
+}
+
+// TokenSerial returns the serial of a token, which is spent only once.
+func TokenSerial(token []byte) [TOKEN_SERIAL_SIZE]byte {
+	var serial [TOKEN_SERIAL_SIZE]byte
+	copy(serial[:], token)
+	return serial
+}
+
+// A TokenSigner signs blinded tokens, with the private exponents of
+// the chains.
+type TokenSigner struct {
+	key *rsa.PrivateKey
+
+	mu sync.Mutex
+	// private exponents mod p-1 and q-1, by gid
+	exponents map[string][2]*big.Int
+}
+
+func NewTokenSigner(key *rsa.PrivateKey) (*TokenSigner, error) {
+	if len(key.Primes) != 2 {
+		return nil, errors.New("Token key should have two primes")
+	}
+	key.Precompute()
+	return &TokenSigner{
+		key:       key,
+		exponents: make(map[string][2]*big.Int),
+	}, nil
+}
+
+func (signer *TokenSigner) PublicKey() *rsa.PublicKey {
+	return &signer.key.PublicKey
+}
+
+func (signer *TokenSigner) exponent(gid string) ([2]*big.Int, error) {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	if d, ok := signer.exponents[gid]; ok {
+		return d, nil
+	}
+	var d [2]*big.Int
+	one := big.NewInt(1)
+	e := tokenExponent(gid)
+	for i, prime := range signer.key.Primes[:2] {
+		pm := new(big.Int).Sub(prime, one)
+		d[i] = new(big.Int).ModInverse(e, pm)
+		if d[i] == nil {
+			return d, errors.New("Token exponent not invertible")
+		}
+	}
+	signer.exponents[gid] = d
+	return d, nil
+}
+
+// Sign signs a blinded token for the chain gid.
+func (signer *TokenSigner) Sign(gid string, blinded []byte) ([]byte, error) {
+	n := signer.key.N
+	m := new(big.Int).SetBytes(blinded)
+	if m.Sign() == 0 || m.Cmp(n) >= 0 {
+		return nil, errInvalidBlinded
+	}
+	d, err := signer.exponent(gid)
+	if err != nil {
+		return nil, err
+	}
+
+	// chinese remainder theorem, as in crypto/rsa
+	p, q := signer.key.Primes[0], signer.key.Primes[1]
+	sp := new(big.Int).Exp(new(big.Int).Mod(m, p), d[0], p)
+	sq := new(big.Int).Exp(new(big.Int).Mod(m, q), d[1], q)
+	sp.Sub(sp, sq)
+	sp.Mul(sp, signer.key.Precomputed.Qinv)
+	sp.Mod(sp, p)
+	sp.Mul(sp, q)
+	sp.Add(sp, sq)
+
+	return sp.FillBytes(make([]byte, (n.BitLen()+7)/8)), nil
+}
+
+// tokenLedger counts the tokens spent at a chain in a round.
+type tokenLedger struct {
+	key   *rsa.PublicKey
+	round uint64
+	gid   string
+
+	mu    sync.Mutex
+	spent map[[TOKEN_SERIAL_SIZE]byte]bool
+	// ciphertexts with a valid token, and the ones without
+	redeemed uint64
+	invalid  uint64
+}
+
+func newTokenLedger(key *rsa.PublicKey, round uint64, gid string) *tokenLedger {
+	return &tokenLedger{
+		key:   key,
+		round: round,
+		gid:   gid,
+		spent: make(map[[TOKEN_SERIAL_SIZE]byte]bool),
+	}
+}
+
+// redeem spends the tokens of n ciphertexts. Missing, invalid and
+// already spent tokens are counted, and the ciphertexts are kept
+// anyway, so that all the servers of the chain mix the same ones.
+func (ledger *tokenLedger) redeem(n int, tokens [][]byte) {
+	valid := make([]bool, n)
+	for i := 0; i < n && i < len(tokens); i++ {
+		valid[i] = VerifyToken(ledger.key, ledger.round, ledger.gid, tokens[i])
+	}
+
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	for i := range valid {
+		if !valid[i] {
+			ledger.invalid++
+			continue
+		}
+		serial := TokenSerial(tokens[i])
+		if ledger.spent[serial] {
+			ledger.invalid++
+			continue
+		}
+		ledger.spent[serial] = true
+		ledger.redeemed++
+	}
+}
+
+func (ledger *tokenLedger) report() *TokenRedemption {
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	return &TokenRedemption{
+		Gid:      ledger.gid,
+		Redeemed: ledger.redeemed,
+		Invalid:  ledger.invalid,
+	}
+}
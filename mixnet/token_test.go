@@ -0,0 +1,58 @@
+package mixnet
+
+import (
+	"testing"
+)
+
+func TestTokens(t *testing.T) {
+	signer, err := NewTokenSigner(GenerateTokenKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := UnmarshalTokenPublicKey(MarshalTokenPublicKey(signer.PublicKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blinding, blinded := BlindToken(key, 1, "group:0")
+	signature, err := signer.Sign("group:0", blinded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := blinding.Unblind(key, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyToken(key, 1, "group:0", token) {
+		t.Fatal("Token not verified")
+	}
+	if VerifyToken(key, 2, "group:0", token) {
+		t.Fatal("Token verified in another round")
+	}
+	if VerifyToken(key, 1, "group:1", token) {
+		t.Fatal("Token verified at another chain")
+	}
+	token[0] ^= 1
+	if VerifyToken(key, 1, "group:0", token) {
+		t.Fatal("Modified token verified")
+	}
+	token[0] ^= 1
+
+	// a token signed for another chain does not unblind
+	other, blinded := BlindToken(key, 1, "group:0")
+	signature, err = signer.Sign("group:1", blinded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Unblind(key, signature); err == nil {
+		t.Fatal("Unblinded a token signed for another chain")
+	}
+
+	// a token is only spent once, and missing ones are counted
+	ledger := newTokenLedger(key, 1, "group:0")
+	ledger.redeem(3, [][]byte{token, token})
+	report := ledger.report()
+	if report.Redeemed != 1 || report.Invalid != 2 {
+		t.Error("Wrong redemptions", report.Redeemed, report.Invalid)
+	}
+}
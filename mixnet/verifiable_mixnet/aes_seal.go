@@ -10,7 +10,7 @@ import (
 	"golang.org/x/crypto/nacl/box"
 )
 
-const Overhead = sha256.Size
+const hmacOverhead = sha256.Size
 const SymmetricKeySize = 16
 
 // sliceForAppend takes a slice and a requested number of bytes. It returns a
@@ -28,23 +28,21 @@ func sliceForAppend(in []byte, n int) (head, tail []byte) {
 	return
 }
 
-func Seal(out, message []byte, nonce *[24]byte, peersPublicKey, privateKey *[32]byte) []byte {
+func Seal(suite Suite, out, message []byte, nonce *[24]byte, peersPublicKey, privateKey *[32]byte) []byte {
 	var sharedKey [32]byte
 	box.Precompute(&sharedKey, peersPublicKey, privateKey)
-	return SecretSeal(out, message, nonce, &sharedKey)
+	return suite.Seal(out, message, nonce, &sharedKey)
 }
 
-func Open(out, abox []byte, nonce *[24]byte, peersPublicKey, privateKey *[32]byte) ([]byte, bool) {
+func Open(suite Suite, out, abox []byte, nonce *[24]byte, peersPublicKey, privateKey *[32]byte) ([]byte, bool) {
 	var sharedKey [32]byte
 	box.Precompute(&sharedKey, peersPublicKey, privateKey)
-	return SecretOpen(out, abox, nonce, &sharedKey)
+	return suite.Open(out, abox, nonce, &sharedKey)
 }
 
-// SecretSeal performs AES-HMAC authenticated encryption with a
-// symmetric key. TODO: We need to check that out does not overlap
-// the message or the nonce.
-func SecretSeal(out, message []byte, nonce *[24]byte, key *[32]byte) []byte {
-	keys := hkdf.New(sha256.New, (*key)[:], (*nonce)[:], nil)
+// aesHMACKeys derives the AES and HMAC keys for one message.
+func aesHMACKeys(nonce *[24]byte, key *[32]byte) ([]byte, []byte) {
+	keys := hkdf.New(sha256.New, (*key)[:], (*nonce)[:], []byte(AES128CTRHMAC.Name()))
 	aesKey := make([]byte, SymmetricKeySize)
 	hmacKey := make([]byte, SymmetricKeySize)
 
@@ -61,8 +59,16 @@ func SecretSeal(out, message []byte, nonce *[24]byte, key *[32]byte) []byte {
 	} else if n != len(hmacKey) {
 		panic("Could not read enough bytes for hmac key")
 	}
+	return aesKey, hmacKey
+}
+
+// SecretSeal performs AES-HMAC authenticated encryption with a
+// symmetric key. TODO: We need to check that out does not overlap
+// the message or the nonce.
+func SecretSeal(out, message []byte, nonce *[24]byte, key *[32]byte) []byte {
+	aesKey, hmacKey := aesHMACKeys(nonce, key)
 
-	ret, out := sliceForAppend(out, len(message)+Overhead)
+	ret, out := sliceForAppend(out, len(message)+hmacOverhead)
 
 	iv := (*nonce)[:aes.BlockSize]
 
@@ -72,42 +78,29 @@ func SecretSeal(out, message []byte, nonce *[24]byte, key *[32]byte) []byte {
 	}
 
 	stream := cipher.NewCTR(block, iv)
-	stream.XORKeyStream(out[:len(out)-Overhead], message)
+	stream.XORKeyStream(out[:len(out)-hmacOverhead], message)
 
-	hmac.New(sha256.New, hmacKey).Sum(out[:len(out)-Overhead])
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write(out[:len(out)-hmacOverhead])
+	mac.Sum(out[:len(out)-hmacOverhead])
 	return ret
 }
 
-
 // SecretOpen opens the ciphertext generated using SecretSeal.
-func SecretOpen(out, box []byte, nonce *[24]byte, key *[32]byte) ([]byte, bool) {	
-	keys := hkdf.New(sha256.New, (*key)[:], (*nonce)[:], nil)
-	aesKey := make([]byte, SymmetricKeySize)
-	hmacKey := make([]byte, SymmetricKeySize)
-
-	n, err := keys.Read(aesKey)
-	if err != nil {
-		panic("Could not generate aes key")
-	} else if n != len(aesKey) {
-		panic("Could not read enough bytes for aes key")
-	}
-
-	n, err = keys.Read(hmacKey)
-	if err != nil {
-		panic("Could not generate hmac key")
-	} else if n != len(hmacKey) {
-		panic("Could not read enough bytes for hmac key")
+func SecretOpen(out, box []byte, nonce *[24]byte, key *[32]byte) ([]byte, bool) {
+	if len(box) < hmacOverhead {
+		return nil, false
 	}
+	aesKey, hmacKey := aesHMACKeys(nonce, key)
 
 	mac := hmac.New(sha256.New, hmacKey)
-	var orig [Overhead]byte
-	copy(orig[:], box[len(box)-Overhead:])
-	mac.Sum(box[:len(box)-Overhead])
-	if !hmac.Equal(box[len(box)-Overhead:], orig[:]) {
+	mac.Write(box[:len(box)-hmacOverhead])
+	var expected [hmacOverhead]byte
+	if !hmac.Equal(box[len(box)-hmacOverhead:], mac.Sum(expected[:0])) {
 		return nil, false
 	}
 
-	ret, out := sliceForAppend(out, len(box)-Overhead)
+	ret, out := sliceForAppend(out, len(box)-hmacOverhead)
 
 	iv := (*nonce)[:aes.BlockSize]
 
@@ -117,7 +110,7 @@ func SecretOpen(out, box []byte, nonce *[24]byte, key *[32]byte) ([]byte, bool)
 	}
 
 	stream := cipher.NewCTR(block, iv)
-	stream.XORKeyStream(out, box[:len(box)-Overhead])
+	stream.XORKeyStream(out, box[:len(box)-hmacOverhead])
 
 	return ret, true
 }
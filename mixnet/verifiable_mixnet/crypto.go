@@ -2,12 +2,14 @@ package verifiable_mixnet
 
 import (
 	"crypto/elliptic"
+	"crypto/mlkem"
 	"crypto/rand"
 	"encoding/binary"
 	"log"
 	"math/big"
 	"sync"
 
+	"filippo.io/nistec"
 	"golang.org/x/crypto/nacl/box"
 )
 
@@ -48,7 +50,7 @@ func defaultDecryptionWorker(nonce *[NONCE_SIZE]byte, auxSize int, wg *sync.Wait
 	for job := range jobs {
 		copy(theirKey[:], job.Ciphertext[:BOX_KEY_SIZE])
 
-		res, ok := Open(nil, job.Ciphertext[BOX_KEY_SIZE+auxSize:], nonce, &theirKey, job.PrivateKey)
+		res, ok := Open(job.Suite, nil, job.Ciphertext[BOX_KEY_SIZE+auxSize:], nonce, &theirKey, job.PrivateKey)
 		if !ok {
 			job.Result[job.Idx] = nil
 			log.Println("open failed")
@@ -76,15 +78,15 @@ func defaultDecryptionWorker(nonce *[NONCE_SIZE]byte, auxSize int, wg *sync.Wait
 }
 
 // encrypt just one layer
-func Encrypt(msg []byte, aux []byte, nonce *[NONCE_SIZE]byte, key *[BOX_KEY_SIZE]byte) []byte {
+func Encrypt(suite Suite, msg []byte, aux []byte, nonce *[NONCE_SIZE]byte, key *[BOX_KEY_SIZE]byte) []byte {
 	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
 	if err != nil {
 		panic(err)
 	}
 
-	res := make([]byte, len(msg)+len(aux)+BOX_KEY_SIZE+Overhead)
+	res := make([]byte, len(msg)+len(aux)+BOX_KEY_SIZE+suite.Overhead())
 
-	Seal(res[BOX_KEY_SIZE+len(aux):BOX_KEY_SIZE+len(aux)],
+	Seal(suite, res[BOX_KEY_SIZE+len(aux):BOX_KEY_SIZE+len(aux)],
 		msg, nonce, key, privateKey)
 
 	copy(res[:], (*publicKey)[:])
@@ -98,7 +100,7 @@ func Encrypt(msg []byte, aux []byte, nonce *[NONCE_SIZE]byte, key *[BOX_KEY_SIZE
 // (i.e., message traversal order).
 // auxs and keys are reversed in place, so the caller should
 // not reuse these arrays outside
-func OnionEncrypt(msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte) []byte {
+func OnionEncrypt(suite Suite, msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte) []byte {
 	reverse(auxs)
 	reverse(nonces)
 	reverse(keys)
@@ -109,7 +111,7 @@ func OnionEncrypt(msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte) []b
 	totalSize := len(msg)
 	for i := range auxs {
 		totalSize += len(auxs[i])
-		totalSize += BOX_KEY_SIZE + Overhead
+		totalSize += BOX_KEY_SIZE + suite.Overhead()
 	}
 
 	// avoid allocation by creating arrays ahead of time
@@ -126,12 +128,12 @@ func OnionEncrypt(msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte) []b
 		copy(theirKey[:], keys[i])
 		copy(nonce[:], nonces[i])
 
-		Seal(res[BOX_KEY_SIZE+len(auxs[i]):BOX_KEY_SIZE+len(auxs[i])], in[:l], &nonce, &theirKey, privateKey)
+		Seal(suite, res[BOX_KEY_SIZE+len(auxs[i]):BOX_KEY_SIZE+len(auxs[i])], in[:l], &nonce, &theirKey, privateKey)
 
 		copy(res[:], (*publicKey)[:])
 		copy(res[BOX_KEY_SIZE:], auxs[i])
 
-		l = l + BOX_KEY_SIZE + len(auxs[i]) + Overhead
+		l = l + BOX_KEY_SIZE + len(auxs[i]) + suite.Overhead()
 		copy(in, res[:l])
 	}
 	return res
@@ -171,27 +173,87 @@ func P256KeyToBytes(x, y, priv *big.Int) ([]byte, []byte) {
 	return pub, secret
 }
 
+// P256DecryptionWorker peels one layer of P256OnionEncrypt. The scalar
+// multiplications use fixed-size constant-time types, so there is no
+// big.Int allocation per message.
 func P256DecryptionWorker(nonce *[NONCE_SIZE]byte, auxSize int, wg *sync.WaitGroup, jobs chan DecryptionJob) {
-	theirKeyX, theirKeyY := new(big.Int), new(big.Int)
+	theirKey := nistec.NewP256Point()
+	shared := nistec.NewP256Point()
+	blind := nistec.NewP256Point()
+	var blindKey [SCALAR_SIZE]byte
 	var sharedKey [SHARED_KEY_SIZE]byte
 	for job := range jobs {
-		theirKeyX.SetBytes(job.Ciphertext[:POINT_SIZE/2])
-		theirKeyY.SetBytes(job.Ciphertext[POINT_SIZE/2 : POINT_SIZE])
-		sharedX, _ := curve.ScalarMult(theirKeyX, theirKeyY, (*job.PrivateKey)[:])
-		sxb := sharedX.Bytes()
-		// explicitly zero out the rest, since we are reusing arrays
-		copy(sharedKey[SHARED_KEY_SIZE-len(sxb):], sxb)
-		for b := 0; b < SHARED_KEY_SIZE-len(sxb); b++ {
-			sharedKey[b] = 0
+		if len(job.Ciphertext) < POINT_SIZE+auxSize+job.Suite.Overhead() {
+			job.Result[job.Idx] = nil
+			log.Println("ciphertext too short")
+			wg.Done()
+			continue
 		}
 
-		blindX, blindY := curve.ScalarMult(theirKeyX, theirKeyY, job.PrivateBlindKey)
+		err := setP256Point(theirKey, job.Ciphertext[:POINT_SIZE])
+		if err == nil {
+			err = setP256Scalar(&blindKey, job.PrivateBlindKey)
+		}
+		if err != nil {
+			job.Result[job.Idx] = nil
+			log.Println("invalid dh key:", err)
+			wg.Done()
+			continue
+		}
+
+		_, err = shared.ScalarMult(theirKey, (*job.PrivateKey)[:])
+		if err != nil {
+			job.Result[job.Idx] = nil
+			log.Println("invalid private key:", err)
+			wg.Done()
+			continue
+		}
+		sx, err := shared.BytesX()
+		if err != nil {
+			job.Result[job.Idx] = nil
+			log.Println("invalid shared key:", err)
+			wg.Done()
+			continue
+		}
+		copy(sharedKey[:], sx)
+
+		// hybrid layers carry a kem ciphertext after the aux data
+		offset := POINT_SIZE + auxSize
+		if job.KEMKey != nil {
+			if len(job.Ciphertext) < offset+KEM_CIPHERTEXT_SIZE+job.Suite.Overhead() {
+				job.Result[job.Idx] = nil
+				log.Println("hybrid ciphertext too short")
+				wg.Done()
+				continue
+			}
+			kemCiphertext := job.Ciphertext[offset : offset+KEM_CIPHERTEXT_SIZE]
+			kemKey, err := job.KEMKey.Decapsulate(kemCiphertext)
+			if err != nil {
+				job.Result[job.Idx] = nil
+				log.Println("decapsulation failed")
+				wg.Done()
+				continue
+			}
+			HybridKey(&sharedKey, kemKey, kemCiphertext)
+			offset += KEM_CIPHERTEXT_SIZE
+		}
+
+		_, err = blind.ScalarMult(theirKey, blindKey[:])
+		if err != nil {
+			job.Result[job.Idx] = nil
+			log.Println("invalid blind key:", err)
+			wg.Done()
+			continue
+		}
 
-		bxb, byb := blindX.Bytes(), blindY.Bytes()
 		res := make([]byte, POINT_SIZE,
-			len(job.Ciphertext)-Overhead-auxSize)
-		copy(res[POINT_SIZE/2-len(bxb):], bxb)
-		copy(res[POINT_SIZE-len(byb):], byb)
+			POINT_SIZE+len(job.Ciphertext)-offset-job.Suite.Overhead())
+		if err := p256PointBytes(res, blind); err != nil {
+			job.Result[job.Idx] = nil
+			log.Println("invalid blinded key:", err)
+			wg.Done()
+			continue
+		}
 
 		if job.ProdJob != nil {
 			job.ProdWg.Add(1)
@@ -199,7 +261,7 @@ func P256DecryptionWorker(nonce *[NONCE_SIZE]byte, auxSize int, wg *sync.WaitGro
 		}
 
 		// append to res
-		res, ok := SecretOpen(res, job.Ciphertext[POINT_SIZE+auxSize:],
+		res, ok := job.Suite.Open(res, job.Ciphertext[offset:],
 			nonce, &sharedKey)
 		if !ok {
 			job.Result[job.Idx] = nil
@@ -213,10 +275,21 @@ func P256DecryptionWorker(nonce *[NONCE_SIZE]byte, auxSize int, wg *sync.WaitGro
 	}
 }
 
-func P256OnionEncrypt(msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte, nizk bool) ([]byte, []byte) {
+func P256OnionEncrypt(suite Suite, msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte, nizk bool) ([]byte, []byte) {
+	return p256OnionEncrypt(suite, msg, auxs, nonces, keys, nil, nizk)
+}
+
+// kemKeys is nil for non-hybrid layers
+func p256OnionEncrypt(suite Suite, msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte, kemKeys [][]byte, nizk bool) ([]byte, []byte) {
 	reverse(auxs)
 	reverse(nonces)
 	reverse(keys)
+	reverse(kemKeys)
+
+	kemSize := 0
+	if kemKeys != nil {
+		kemSize = KEM_CIPHERTEXT_SIZE
+	}
 
 	theirKeyX, theirKeyY := new(big.Int), new(big.Int)
 	var nonce [NONCE_SIZE]byte
@@ -224,7 +297,7 @@ func P256OnionEncrypt(msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte,
 	totalSize := len(msg) + POINT_SIZE // POINT_SIZE for the diffie-hellman key
 	for i := range auxs {
 		totalSize += len(auxs[i])
-		totalSize += Overhead
+		totalSize += kemSize + suite.Overhead()
 	}
 
 	// avoid allocation by creating arrays ahead of time
@@ -256,12 +329,23 @@ func P256OnionEncrypt(msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte,
 			sharedKey[b] = 0
 		}
 
+		if kemKeys != nil {
+			kemKey, err := mlkem.NewEncapsulationKey768(kemKeys[i])
+			if err != nil {
+				panic(err)
+			}
+			kemShared, kemCiphertext := kemKey.Encapsulate()
+			HybridKey(&sharedKey, kemShared, kemCiphertext)
+			copy(res[POINT_SIZE+len(auxs[i]):], kemCiphertext)
+		}
+
 		// POINT_SIZE bytes reserved for the public keys
-		SecretSeal(res[len(auxs[i])+POINT_SIZE:len(auxs[i])+POINT_SIZE], in[:l], &nonce, &sharedKey)
+		start := POINT_SIZE + len(auxs[i]) + kemSize
+		suite.Seal(res[start:start], in[:l], &nonce, &sharedKey)
 
 		copy(res[POINT_SIZE:], auxs[i])
 
-		l = l + len(auxs[i]) + Overhead
+		l = l + len(auxs[i]) + kemSize + suite.Overhead()
 		copy(in, res[POINT_SIZE:POINT_SIZE+l])
 	}
 
@@ -275,6 +359,7 @@ func P256OnionEncrypt(msg []byte, auxs [][]byte, nonces [][]byte, keys [][]byte,
 	reverse(auxs)
 	reverse(nonces)
 	reverse(keys)
+	reverse(kemKeys)
 	if nizk {
 		return res, PoKLog(privateKey, publicX, publicY)
 	} else {
@@ -0,0 +1,224 @@
+package verifiable_mixnet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+)
+
+// AES-GCM-SIV as specified in RFC 8452. Go's standard library does
+// not ship it, so this is a small, unoptimized implementation that
+// only needs to be fast enough for the onion layers.
+
+const (
+	gcmSIVNonceSize = 12
+	gcmSIVTagSize   = 16
+)
+
+type gcmSIV struct {
+	block  cipher.Block // key generating key
+	keyLen int
+}
+
+func newGCMSIV(key []byte) (cipher.AEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("AES-GCM-SIV: invalid key size")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &gcmSIV{
+		block:  block,
+		keyLen: len(key),
+	}, nil
+}
+
+func (g *gcmSIV) NonceSize() int { return gcmSIVNonceSize }
+func (g *gcmSIV) Overhead() int  { return gcmSIVTagSize }
+
+// deriveKeys returns the per-nonce authentication and encryption keys.
+func (g *gcmSIV) deriveKeys(nonce []byte) ([]byte, cipher.Block) {
+	var in, out [aes.BlockSize]byte
+	copy(in[4:], nonce)
+
+	keys := make([]byte, 16+g.keyLen)
+	for i := 0; i < len(keys)/8; i++ {
+		binary.LittleEndian.PutUint32(in[:4], uint32(i))
+		g.block.Encrypt(out[:], in[:])
+		copy(keys[8*i:], out[:8])
+	}
+
+	encBlock, err := aes.NewCipher(keys[16:])
+	if err != nil {
+		panic("Could not create AES-GCM-SIV encryption key")
+	}
+	return keys[:16], encBlock
+}
+
+func (g *gcmSIV) tag(authKey []byte, encBlock cipher.Block, nonce, plaintext, data []byte) [gcmSIVTagSize]byte {
+	p := newPolyval(authKey)
+	p.update(data)
+	p.update(plaintext)
+
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[:8], uint64(len(data))*8)
+	binary.LittleEndian.PutUint64(lengths[8:], uint64(len(plaintext))*8)
+	p.update(lengths[:])
+
+	s := p.sum()
+	for i := range nonce {
+		s[i] ^= nonce[i]
+	}
+	s[15] &= 0x7f
+
+	var tag [gcmSIVTagSize]byte
+	encBlock.Encrypt(tag[:], s[:])
+	return tag
+}
+
+// ctr is AES-CTR with a 32-bit little endian counter in the first
+// word of the block, as used by GCM-SIV.
+func ctr(encBlock cipher.Block, tag []byte, dst, src []byte) {
+	var counter, stream [aes.BlockSize]byte
+	copy(counter[:], tag)
+	counter[15] |= 0x80
+
+	for len(src) > 0 {
+		encBlock.Encrypt(stream[:], counter[:])
+		n := len(src)
+		if n > len(stream) {
+			n = len(stream)
+		}
+		for i := 0; i < n; i++ {
+			dst[i] = src[i] ^ stream[i]
+		}
+		dst, src = dst[n:], src[n:]
+		c := binary.LittleEndian.Uint32(counter[:4])
+		binary.LittleEndian.PutUint32(counter[:4], c+1)
+	}
+}
+
+func (g *gcmSIV) Seal(dst, nonce, plaintext, data []byte) []byte {
+	if len(nonce) != gcmSIVNonceSize {
+		panic("AES-GCM-SIV: incorrect nonce length")
+	}
+	authKey, encBlock := g.deriveKeys(nonce)
+	tag := g.tag(authKey, encBlock, nonce, plaintext, data)
+
+	ret, out := sliceForAppend(dst, len(plaintext)+gcmSIVTagSize)
+	ctr(encBlock, tag[:], out[:len(plaintext)], plaintext)
+	copy(out[len(plaintext):], tag[:])
+	return ret
+}
+
+func (g *gcmSIV) Open(dst, nonce, ciphertext, data []byte) ([]byte, error) {
+	if len(nonce) != gcmSIVNonceSize {
+		panic("AES-GCM-SIV: incorrect nonce length")
+	}
+	if len(ciphertext) < gcmSIVTagSize {
+		return nil, errors.New("AES-GCM-SIV: message authentication failed")
+	}
+	tag := ciphertext[len(ciphertext)-gcmSIVTagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-gcmSIVTagSize]
+
+	authKey, encBlock := g.deriveKeys(nonce)
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	ctr(encBlock, tag, out, ciphertext)
+
+	expected := g.tag(authKey, encBlock, nonce, out, data)
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errors.New("AES-GCM-SIV: message authentication failed")
+	}
+	return ret, nil
+}
+
+// polyval computes POLYVAL through its relation to GHASH (RFC 8452,
+// appendix A), so only the GHASH multiplication has to be written.
+type polyval struct {
+	h   fieldElement
+	acc fieldElement
+}
+
+// element of GF(2^128) in GHASH bit order
+type fieldElement struct {
+	hi, lo uint64
+}
+
+func newPolyval(key []byte) *polyval {
+	var rev [16]byte
+	reverseBytes(rev[:], key)
+	return &polyval{
+		h: mulX(loadElement(rev[:])),
+	}
+}
+
+func (p *polyval) update(in []byte) {
+	var block, rev [16]byte
+	for len(in) > 0 {
+		n := copy(block[:], in)
+		for i := n; i < len(block); i++ {
+			block[i] = 0
+		}
+		in = in[n:]
+
+		reverseBytes(rev[:], block[:])
+		x := loadElement(rev[:])
+		p.acc.hi ^= x.hi
+		p.acc.lo ^= x.lo
+		p.acc = gfMul(p.acc, p.h)
+	}
+}
+
+func (p *polyval) sum() [16]byte {
+	var out, rev [16]byte
+	binary.BigEndian.PutUint64(rev[:8], p.acc.hi)
+	binary.BigEndian.PutUint64(rev[8:], p.acc.lo)
+	reverseBytes(out[:], rev[:])
+	return out
+}
+
+func loadElement(b []byte) fieldElement {
+	return fieldElement{
+		hi: binary.BigEndian.Uint64(b[:8]),
+		lo: binary.BigEndian.Uint64(b[8:]),
+	}
+}
+
+func reverseBytes(dst, src []byte) {
+	for i := range src {
+		dst[len(src)-1-i] = src[i]
+	}
+}
+
+// mulX multiplies by x in the GHASH field
+func mulX(v fieldElement) fieldElement {
+	mask := -(v.lo & 1)
+	v.lo = v.lo>>1 | v.hi<<63
+	v.hi = v.hi>>1 ^ (0xe100000000000000 & mask)
+	return v
+}
+
+// gfMul is the constant time bitwise GHASH multiplication
+func gfMul(x, y fieldElement) fieldElement {
+	var z fieldElement
+	v := y
+	for i := 0; i < 128; i++ {
+		var bit uint64
+		if i < 64 {
+			bit = (x.hi >> (63 - uint(i))) & 1
+		} else {
+			bit = (x.lo >> (127 - uint(i))) & 1
+		}
+		mask := -bit
+		z.hi ^= v.hi & mask
+		z.lo ^= v.lo & mask
+		v = mulX(v)
+	}
+	return z
+}
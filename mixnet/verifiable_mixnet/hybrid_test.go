@@ -0,0 +1,101 @@
+package verifiable_mixnet
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestSingleGroupP256Hybrid(t *testing.T) {
+	K := 3
+	mixes := make([]Mix, K)
+	publicKeys, privateKeys := make([][]byte, K), make([][]byte, K)
+	kemKeys := make([][]byte, K)
+	nonces := make([][]byte, K)
+	for i := range mixes {
+		publicKeys[i], privateKeys[i] = P256KeyToBytes(GenerateP256Key())
+		var kemPriv []byte
+		kemKeys[i], kemPriv = GenerateKEMKey()
+		nonce := Nonce(0, 0, i)
+		nonces[i] = nonce[:]
+
+		mixes[i] = NewMix(P256DecryptionWorker)
+		err := mixes[i].NewRound(0, RoundConfiguration{Index: i, Hybrid: true})
+		if err != nil {
+			t.Error(err)
+		}
+		mixes[i].SetRoundKey(0, publicKeys[i], privateKeys[i])
+		mixes[i].SetBlindKey(0, nil, big.NewInt(1).Bytes())
+		err = mixes[i].SetKEMKey(0, kemPriv)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	msgs := make([][]byte, 10)
+	ciphertexts := make([][]byte, len(msgs))
+	for i := range msgs {
+		msgs[i] = make([]byte, 100)
+		rand.Read(msgs[i])
+		ciphertexts[i], _ = P256HybridOnionEncrypt(DefaultSuite, msgs[i], make([][]byte, K), nonces, publicKeys, kemKeys, false)
+		if len(ciphertexts[i]) != POINT_SIZE+len(msgs[i])+K*HybridOverhead(DefaultSuite) {
+			t.Error("Wrong hybrid ciphertext size")
+		}
+	}
+
+	res := ciphertexts
+	var err error
+	for i := range mixes {
+		err = mixes[i].AddMessages(0, res)
+		if err != nil {
+			t.Error(err)
+		}
+		res, err = mixes[i].Mix(0)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	for r := range res {
+		if res[r] == nil || !partOf(res[r][POINT_SIZE:], msgs) {
+			t.Error("Hybrid mixnet failed")
+		}
+	}
+}
+
+func TestShortP256HybridCiphertext(t *testing.T) {
+	publicKey, privateKey := P256KeyToBytes(GenerateP256Key())
+	_, kemPriv := GenerateKEMKey()
+	mix := NewMix(P256DecryptionWorker)
+	err := mix.NewRound(0, RoundConfiguration{Hybrid: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mix.SetRoundKey(0, publicKey, privateKey)
+	mix.SetBlindKey(0, nil, big.NewInt(1).Bytes())
+	err = mix.SetKEMKey(0, kemPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a valid dh key, but no room for the kem ciphertext
+	ciphertext := make([]byte, POINT_SIZE+DefaultSuite.Overhead()+10)
+	dhKey, _ := P256KeyToBytes(GenerateP256Key())
+	copy(ciphertext, dhKey)
+
+	err = mix.AddMessages(0, [][]byte{ciphertext})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mix.Mix(0); err == nil {
+		t.Error("Mixed a short hybrid ciphertext")
+	}
+}
+
+func BenchmarkP256Decryption(b *testing.B) {
+	benchmarkHop(b, P256DecryptionWorker, false)
+}
+
+func BenchmarkP256HybridDecryption(b *testing.B) {
+	benchmarkHop(b, P256DecryptionWorker, true)
+}
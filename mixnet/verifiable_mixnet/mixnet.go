@@ -1,23 +1,29 @@
 package verifiable_mixnet
 
 import (
+	"crypto/mlkem"
 	"crypto/rand"
 	"errors"
+	"log"
 	"math/big"
 	"runtime"
 	"sync"
+
+	"filippo.io/nistec"
 )
 
 type RoundConfiguration struct {
-	ClientVerifiable bool // whether client submission is verifiable submission or not
-	Verifiable       bool // whether this is a verifiable mixnet or not
-	Row              int  // group id of this mixnet group within a layer
-	Layer            int  // used if there are multiple layers
-	Index            int  // index of this server in the mixnet group
-	First            bool // whether this is the first server
-	Last             bool // whether this is the last server
-	AuxSize          int  // auxilary input length
-	GroupSize        int  // size of the mix chain
+	ClientVerifiable bool  // whether client submission is verifiable submission or not
+	Verifiable       bool  // whether this is a verifiable mixnet or not
+	Row              int   // group id of this mixnet group within a layer
+	Layer            int   // used if there are multiple layers
+	Index            int   // index of this server in the mixnet group
+	First            bool  // whether this is the first server
+	Last             bool  // whether this is the last server
+	AuxSize          int   // auxilary input length
+	GroupSize        int   // size of the mix chain
+	Suite            Suite // onion layer cipher suite, DefaultSuite if nil
+	Hybrid           bool  // whether layers also carry a kem ciphertext
 }
 
 var nWorkers = runtime.NumCPU()
@@ -40,6 +46,8 @@ type Mix interface {
 	SetRoundKey(round int, publicKey, privateKey []byte) error
 	// RoundKey returns the onion encryption key for the round.
 	RoundKey(round int) ([]byte, error)
+	// SetKEMKey sets the kem decapsulation key for hybrid rounds.
+	SetKEMKey(round int, privateKey []byte) error
 	// RoundConfiguration gets the config
 	RoundConfiguration(round int) (RoundConfiguration, error)
 	// SetAuxProcessor takes in a function that will be used to
@@ -73,7 +81,15 @@ type Mix interface {
 	// and the proof of shuffle.
 	ProveMix(round int) ([][]byte, []byte, error)
 	// VerifyProof checks that out is a shuffled version of in.
+	// It also verifies any proofs added through AddProof.
 	VerifyProof(round, index int, in [][]byte, proof []byte) error
+	// AddProof is VerifyProof without the proof verification.
+	// Proofs from all upstream servers can then be verified
+	// together with VerifyProofs, which is much cheaper than
+	// verifying them one by one.
+	AddProof(round, index int, in [][]byte, proof []byte) error
+	// VerifyProofs verifies all proofs added since the last call.
+	VerifyProofs(round int) error
 	// ConfirmVerification is used to let a server know the proof
 	// successfully verified.
 	ConfirmVerification(round int, success bool) error
@@ -86,7 +102,9 @@ type server struct {
 }
 
 type DecryptionJob struct {
+	Suite        Suite
 	PrivateKey   *[BOX_KEY_SIZE]byte
+	KEMKey       *mlkem.DecapsulationKey768 // nil if not hybrid
 	Ciphertext   []byte
 	AuxProcessor AuxProcessor
 
@@ -106,6 +124,7 @@ type roundState struct {
 	config     RoundConfiguration
 	privateKey *[BOX_KEY_SIZE]byte
 	publicKey  []byte
+	kemKey     *mlkem.DecapsulationKey768
 	nonce      *[NONCE_SIZE]byte
 
 	shuffler *Shuffler
@@ -132,6 +151,10 @@ type roundState struct {
 	prodSet         []*sync.WaitGroup
 	products        []point // maps index to the product
 	verified        *sync.WaitGroup
+
+	// upstream proofs not yet verified
+	pendingStmts []LogEquivalenceStatement
+	pendingPrfs  [][]byte
 }
 
 func NewMix(dw DecryptionWorker) Mix {
@@ -150,6 +173,10 @@ func (srv *server) NewRound(round int, config RoundConfiguration) error {
 		return errors.New("Round already exists")
 	}
 
+	if config.Suite == nil {
+		config.Suite = DefaultSuite
+	}
+
 	nonce := Nonce(round, config.Row, config.Index)
 	state := &roundState{
 		config: config,
@@ -233,6 +260,21 @@ func (srv *server) SetRoundKey(round int, publicKey, privateKey []byte) error {
 	return nil
 }
 
+func (srv *server) SetKEMKey(round int, privateKey []byte) error {
+	srv.smu.RLock()
+	state, ok := srv.states[round]
+	srv.smu.RUnlock()
+	if !ok {
+		return errors.New("Mixnet-SetKEMKey: Round not yet started")
+	}
+	kemKey, err := NewKEMPrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+	state.kemKey = kemKey
+	return nil
+}
+
 func (srv *server) RoundKey(round int) ([]byte, error) {
 	srv.smu.RLock()
 	state, ok := srv.states[round]
@@ -273,6 +315,10 @@ func (srv *server) AddMessages(round int, msgs [][]byte) error {
 		return errors.New("Mixnet-AddMessages: Round not yet started")
 	}
 
+	if state.config.Hybrid && state.kemKey == nil {
+		return errors.New("Mixnet-AddMessages: KEM key not set")
+	}
+
 	result := make([][]byte, len(msgs))
 
 	state.Lock()
@@ -289,7 +335,9 @@ func (srv *server) AddMessages(round int, msgs [][]byte) error {
 	go func(state *roundState) {
 		for i, msg := range msgs {
 			job := DecryptionJob{
+				Suite:        state.config.Suite,
 				PrivateKey:   state.privateKey,
+				KEMKey:       state.kemKey,
 				Ciphertext:   msg,
 				AuxProcessor: state.auxProcessor,
 
@@ -455,21 +503,25 @@ func (srv *server) BlindKey(round int) ([]byte, error) {
 	return state.publicBlindKeys[state.config.Index], nil
 }
 
+// productWorker sums up the dh keys in projective coordinates, so
+// there is no field inversion per key.
 func productWorker(jobs chan []byte, wg *sync.WaitGroup, result chan []byte) {
-	prodx, prody := big.NewInt(0), big.NewInt(0)
-	x, y := new(big.Int), new(big.Int)
+	prod := nistec.NewP256Point()
+	p := nistec.NewP256Point()
 	for job := range jobs {
-		x.SetBytes(job[:POINT_SIZE/2])
-		y.SetBytes(job[POINT_SIZE/2 : POINT_SIZE])
-		prodx, prody = curve.Add(prodx, prody, x, y)
+		if err := setP256Point(p, job[:POINT_SIZE]); err != nil {
+			log.Println("invalid dh key in product:", err)
+		} else {
+			prod.Add(prod, p)
+		}
 		wg.Done()
 	}
 
+	// nil if the worker got no keys
 	res := make([]byte, POINT_SIZE)
-	prodxb := prodx.Bytes()
-	prodyb := prody.Bytes()
-	copy(res[POINT_SIZE/2-len(prodxb):], prodxb)
-	copy(res[POINT_SIZE-len(prodyb):], prodyb)
+	if err := p256PointBytes(res, prod); err != nil {
+		res = nil
+	}
 
 	result <- res
 }
@@ -479,13 +531,22 @@ func (srv *server) gatherProducts(round, index int) (*big.Int, *big.Int) {
 
 	state.prodWgs[index].Wait()
 	close(state.prodJobs[index]) // indicate no more keys will be added
-	prodx, prody := big.NewInt(0), big.NewInt(0)
-	x, y := new(big.Int), new(big.Int)
+	prod := nistec.NewP256Point()
+	p := nistec.NewP256Point()
 	for i := 0; i < nWorkers; i++ {
 		res := <-state.partialProducts[index]
-		x.SetBytes(res[:POINT_SIZE/2])
-		y.SetBytes(res[POINT_SIZE/2 : POINT_SIZE])
-		prodx, prody = curve.Add(prodx, prody, x, y)
+		if res == nil {
+			continue
+		}
+		if err := setP256Point(p, res); err != nil {
+			panic("Invalid partial product")
+		}
+		prod.Add(prod, p)
+	}
+	prodx, prody, err := p256PointToBig(prod)
+	if err != nil {
+		// no keys, (0, 0) as in crypto/elliptic
+		prodx, prody = big.NewInt(0), big.NewInt(0)
 	}
 
 	state.products[index] = point{
@@ -544,16 +605,10 @@ func (srv *server) ProveMix(round int) ([][]byte, []byte, error) {
 	bx, by := srv.gatherProducts(round, index+1)
 
 	private := new(big.Int).SetBytes(state.privateBlindKey)
-	publicx := new(big.Int).SetBytes(state.publicBlindKeys[index][:POINT_SIZE/2])
-	publicy := new(big.Int).SetBytes(state.publicBlindKeys[index][POINT_SIZE/2 : POINT_SIZE])
-	basex := curve.Params().Gx
-	basey := curve.Params().Gy
-	if index > 0 {
-		basex = new(big.Int).SetBytes(state.publicBlindKeys[index-1][:POINT_SIZE/2])
-		basey = new(big.Int).SetBytes(state.publicBlindKeys[index-1][POINT_SIZE/2 : POINT_SIZE])
-	}
+	st := blindStatement(state, index, ox, oy, bx, by)
 
-	prf := LogEquivalence(private, ox, oy, bx, by, basex, basey, publicx, publicy)
+	prf := LogEquivalence(private, st.Basex1, st.Basey1, st.X1, st.Y1,
+		st.Basex2, st.Basey2, st.X2, st.Y2, st.Context)
 
 	// wait for all other servers to verify previous proof
 	// NOTE: commented out because for crossroads,
@@ -565,12 +620,34 @@ func (srv *server) ProveMix(round int) ([][]byte, []byte, error) {
 	return shuffled, prf, nil
 }
 
-func (srv *server) VerifyProof(round, index int, in [][]byte, proof []byte) error {
+// blindStatement is the statement proven by the server at index: the
+// product of its output keys (bx, by) is the product of its input keys
+// (ox, oy) raised to the same exponent as its public blind key. The
+// cipher suite of the chain is in the transcript, so the proof also
+// records which suite the layers were opened with.
+func blindStatement(state *roundState, index int, ox, oy, bx, by *big.Int) LogEquivalenceStatement {
+	basex := curve.Params().Gx
+	basey := curve.Params().Gy
+	if index > 0 {
+		basex = new(big.Int).SetBytes(state.publicBlindKeys[index-1][:POINT_SIZE/2])
+		basey = new(big.Int).SetBytes(state.publicBlindKeys[index-1][POINT_SIZE/2 : POINT_SIZE])
+	}
+	publicx := new(big.Int).SetBytes(state.publicBlindKeys[index][:POINT_SIZE/2])
+	publicy := new(big.Int).SetBytes(state.publicBlindKeys[index][POINT_SIZE/2 : POINT_SIZE])
+
+	return LogEquivalenceStatement{
+		Basex1: ox, Basey1: oy, X1: bx, Y1: by,
+		Basex2: basex, Basey2: basey, X2: publicx, Y2: publicy,
+		Context: []byte("xrd blind proof " + state.config.Suite.Name()),
+	}
+}
+
+func (srv *server) AddProof(round, index int, in [][]byte, proof []byte) error {
 	srv.smu.RLock()
 	state, ok := srv.states[round]
 	srv.smu.RUnlock()
 	if !ok {
-		return errors.New("Mixnet-VerifyProof: Round not yet started")
+		return errors.New("Mixnet-AddProof: Round not yet started")
 	}
 
 	if state.config.Index <= index {
@@ -593,24 +670,41 @@ func (srv *server) VerifyProof(round, index int, in [][]byte, proof []byte) erro
 
 	bx, by := srv.gatherProducts(round, index+1)
 
-	basex := curve.Params().Gx
-	basey := curve.Params().Gy
-	if index > 0 {
-		basex = new(big.Int).SetBytes(state.publicBlindKeys[index-1][:POINT_SIZE/2])
-		basey = new(big.Int).SetBytes(state.publicBlindKeys[index-1][POINT_SIZE/2 : POINT_SIZE])
+	state.Lock()
+	state.pendingStmts = append(state.pendingStmts, blindStatement(state, index, ox, oy, bx, by))
+	state.pendingPrfs = append(state.pendingPrfs, proof)
+	state.Unlock()
+
+	return nil
+}
+
+func (srv *server) VerifyProofs(round int) error {
+	srv.smu.RLock()
+	state, ok := srv.states[round]
+	srv.smu.RUnlock()
+	if !ok {
+		return errors.New("Mixnet-VerifyProofs: Round not yet started")
 	}
-	publicx := new(big.Int).SetBytes(state.publicBlindKeys[index][:POINT_SIZE/2])
-	publicy := new(big.Int).SetBytes(state.publicBlindKeys[index][POINT_SIZE/2 : POINT_SIZE])
 
-	eq := VerifyLogEquivalence(ox, oy, bx, by,
-		basex, basey, publicx, publicy, proof)
-	if !eq {
+	state.Lock()
+	stmts, prfs := state.pendingStmts, state.pendingPrfs
+	state.pendingStmts, state.pendingPrfs = nil, nil
+	state.Unlock()
+
+	if !BatchVerifyLogEquivalence(stmts, prfs) {
 		return errors.New("Proof verification failed")
 	}
-
 	return nil
 }
 
+func (srv *server) VerifyProof(round, index int, in [][]byte, proof []byte) error {
+	err := srv.AddProof(round, index, in, proof)
+	if err != nil {
+		return err
+	}
+	return srv.VerifyProofs(round)
+}
+
 func (srv *server) ConfirmVerification(round int, success bool) error {
 	srv.smu.RLock()
 	state, ok := srv.states[round]
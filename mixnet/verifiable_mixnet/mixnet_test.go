@@ -70,7 +70,7 @@ func TestSingleGroup(t *testing.T) {
 		ns := make([][]byte, len(nonces))
 		copy(ns, nonces)
 
-		ciphertexts[i] = OnionEncrypt(msgs[i], auxs, ns, keys)
+		ciphertexts[i] = OnionEncrypt(DefaultSuite, msgs[i], auxs, ns, keys)
 	}
 
 	res := ciphertexts
@@ -143,7 +143,7 @@ func TestSingleGroupP256(t *testing.T) {
 		ns := make([][]byte, len(nonces))
 		copy(ns, nonces)
 
-		ciphertexts[i], _ = P256OnionEncrypt(msgs[i], auxs, ns, keys, false)
+		ciphertexts[i], _ = P256OnionEncrypt(DefaultSuite, msgs[i], auxs, ns, keys, false)
 	}
 
 	res := ciphertexts
@@ -239,7 +239,7 @@ func TestVerifiableSingleGroup(t *testing.T) {
 			ns := make([][]byte, len(nonces))
 			copy(ns, nonces)
 
-			ciphertexts[i], clientprfs[i] = P256OnionEncrypt(msgs[i], auxs, ns, keys, true)
+			ciphertexts[i], clientprfs[i] = P256OnionEncrypt(DefaultSuite, msgs[i], auxs, ns, keys, true)
 		}(i)
 	}
 	wg.Wait()
@@ -393,7 +393,7 @@ func Test2X2(t *testing.T) {
 		msgs[i] = make([]byte, 10)
 		rand.Read(msgs[i])
 
-		ciphertexts[i] = OnionEncrypt(msgs[i], auxs, nonces, pubs)
+		ciphertexts[i] = OnionEncrypt(DefaultSuite, msgs[i], auxs, nonces, pubs)
 	}
 
 	neighborWgs := make([][][]*sync.WaitGroup, L)
@@ -554,7 +554,7 @@ func TestAuxProcessor(t *testing.T) {
 		ns := make([][]byte, len(nonces))
 		copy(ns, nonces)
 
-		ciphertexts[i] = OnionEncrypt(msgs[i], auxs, ns, keys)
+		ciphertexts[i] = OnionEncrypt(DefaultSuite, msgs[i], auxs, ns, keys)
 	}
 
 	res := ciphertexts
@@ -5,6 +5,8 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"math/big"
+
+	"filippo.io/nistec"
 )
 
 func PoKLog(exp, x, y *big.Int) []byte {
@@ -53,7 +55,10 @@ func VerifyPoKLog(x, y *big.Int, prf []byte) bool {
 	return resx.Cmp(rx) == 0 && resy.Cmp(ry) == 0
 }
 
-func LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.Int) []byte {
+// LogEquivalence proves that log_{base1} (x1, y1) == log_{base2} (x2, y2)
+// == exp. context is bound into the challenge, so the proof only
+// verifies with the same context.
+func LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.Int, context []byte) []byte {
 	prf := make([]byte, 32*5) // two elliptic curve points + one scalar
 	r, _ := rand.Int(rand.Reader, order)
 
@@ -66,18 +71,7 @@ func LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.Int
 	copy(prf[96-len(rx2b):], rx2b)
 	copy(prf[128-len(ry2b):], ry2b)
 
-	buf := new(bytes.Buffer)
-	buf.Write(basex1.Bytes())
-	buf.Write(basey1.Bytes())
-	buf.Write(x1.Bytes())
-	buf.Write(y1.Bytes())
-	buf.Write(basex2.Bytes())
-	buf.Write(basey2.Bytes())
-	buf.Write(x2.Bytes())
-	buf.Write(y2.Bytes())
-	buf.Write(prf[:128])
-
-	c := sha256.Sum256(buf.Bytes())
+	c := logEquivalenceChallenge(basex1, basey1, x1, y1, basex2, basey2, x2, y2, prf, context)
 
 	C := new(big.Int).SetBytes(c[:])
 
@@ -90,12 +84,7 @@ func LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.Int
 	return prf
 }
 
-func VerifyLogEquivalence(basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.Int, prf []byte) bool {
-	rx1 := new(big.Int).SetBytes(prf[:32])
-	ry1 := new(big.Int).SetBytes(prf[32:64])
-	rx2 := new(big.Int).SetBytes(prf[64:96])
-	ry2 := new(big.Int).SetBytes(prf[96:128])
-
+func logEquivalenceChallenge(basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.Int, prf, context []byte) [32]byte {
 	buf := new(bytes.Buffer)
 	buf.Write(basex1.Bytes())
 	buf.Write(basey1.Bytes())
@@ -106,8 +95,18 @@ func VerifyLogEquivalence(basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.In
 	buf.Write(x2.Bytes())
 	buf.Write(y2.Bytes())
 	buf.Write(prf[:128])
+	buf.Write(context)
 
-	c := sha256.Sum256(buf.Bytes())
+	return sha256.Sum256(buf.Bytes())
+}
+
+func VerifyLogEquivalence(basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.Int, prf, context []byte) bool {
+	rx1 := new(big.Int).SetBytes(prf[:32])
+	ry1 := new(big.Int).SetBytes(prf[32:64])
+	rx2 := new(big.Int).SetBytes(prf[64:96])
+	ry2 := new(big.Int).SetBytes(prf[96:128])
+
+	c := logEquivalenceChallenge(basex1, basey1, x1, y1, basex2, basey2, x2, y2, prf, context)
 
 	nx1, ny1 := curve.ScalarMult(basex1, basey1, prf[128:])
 	nx2, ny2 := curve.ScalarMult(basex2, basey2, prf[128:])
@@ -119,3 +118,68 @@ func VerifyLogEquivalence(basex1, basey1, x1, y1, basex2, basey2, x2, y2 *big.In
 	resx2, resy2 := curve.Add(nx2, ny2, cx2, cy2)
 	return resx1.Cmp(rx1) == 0 && resy1.Cmp(ry1) == 0 && resx2.Cmp(rx2) == 0 && resy2.Cmp(ry2) == 0
 }
+
+// LogEquivalenceStatement is the statement proven by LogEquivalence,
+// i.e., log_{base1} (x1, y1) == log_{base2} (x2, y2), in Context.
+type LogEquivalenceStatement struct {
+	Basex1, Basey1, X1, Y1 *big.Int
+	Basex2, Basey2, X2, Y2 *big.Int
+	Context                []byte
+}
+
+// BatchVerifyLogEquivalence verifies all proofs at once. Each proof
+// equation s*base + c*x - r = 0 is weighted by a random 128 bit
+// scalar, and the sum is checked with a single multi-scalar
+// multiplication, instead of four scalar multiplications per proof.
+func BatchVerifyLogEquivalence(stmts []LogEquivalenceStatement, prfs [][]byte) bool {
+	if len(stmts) != len(prfs) {
+		return false
+	}
+
+	scalars := make([][SCALAR_SIZE]byte, 0, 6*len(stmts))
+	points := make([]*nistec.P256Point, 0, 6*len(stmts))
+	addTerm := func(k *big.Int, x, y *big.Int) bool {
+		p, err := bigToP256Point(x, y)
+		if err != nil {
+			return false
+		}
+		var s [SCALAR_SIZE]byte
+		k.Mod(k, order).FillBytes(s[:])
+		scalars = append(scalars, s)
+		points = append(points, p)
+		return true
+	}
+
+	var wb [16]byte
+	for i, st := range stmts {
+		prf := prfs[i]
+		if len(prf) != 32*5 {
+			return false
+		}
+		rx1, ry1 := new(big.Int).SetBytes(prf[:32]), new(big.Int).SetBytes(prf[32:64])
+		rx2, ry2 := new(big.Int).SetBytes(prf[64:96]), new(big.Int).SetBytes(prf[96:128])
+		s := new(big.Int).SetBytes(prf[128:])
+		cb := logEquivalenceChallenge(st.Basex1, st.Basey1, st.X1, st.Y1,
+			st.Basex2, st.Basey2, st.X2, st.Y2, prf, st.Context)
+		c := new(big.Int).SetBytes(cb[:])
+
+		for _, eq := range [][6]*big.Int{
+			{st.Basex1, st.Basey1, st.X1, st.Y1, rx1, ry1},
+			{st.Basex2, st.Basey2, st.X2, st.Y2, rx2, ry2},
+		} {
+			if _, err := rand.Read(wb[:]); err != nil {
+				panic(err)
+			}
+			w := new(big.Int).SetBytes(wb[:])
+
+			ok := addTerm(new(big.Int).Mul(w, s), eq[0], eq[1]) &&
+				addTerm(new(big.Int).Mul(w, c), eq[2], eq[3]) &&
+				addTerm(new(big.Int).Neg(w), eq[4], eq[5])
+			if !ok {
+				return false
+			}
+		}
+	}
+
+	return multiScalarMult(scalars, points).IsInfinity() == 1
+}
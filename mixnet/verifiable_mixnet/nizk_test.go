@@ -43,12 +43,72 @@ func TestLogEquivalence(t *testing.T) {
 		x1, y1 := curve.ScalarMult(basex1, basey1, exp.Bytes())
 		x2, y2 := curve.ScalarMult(basex2, basey2, exp.Bytes())
 
-		prf := LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2)
+		prf := LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2, []byte("context"))
 		if !VerifyLogEquivalence(basex1, basey1, x1, y1,
-			basex2, basey2, x2, y2, prf) {
+			basex2, basey2, x2, y2, prf, []byte("context")) {
 			log.Println("trial", i)
 			t.Fatal("Log equivalence failed")
 		}
+		if VerifyLogEquivalence(basex1, basey1, x1, y1,
+			basex2, basey2, x2, y2, prf, []byte("other context")) {
+			t.Fatal("Log equivalence verified in another context")
+		}
+	}
+}
+
+func randomLogEquivalence(n int) ([]LogEquivalenceStatement, [][]byte) {
+	stmts := make([]LogEquivalenceStatement, n)
+	prfs := make([][]byte, n)
+	for i := range stmts {
+		exp, err := rand.Int(rand.Reader, curve.Params().N)
+		if err != nil {
+			panic(err)
+		}
+		bexp1, err := rand.Int(rand.Reader, curve.Params().N)
+		if err != nil {
+			panic(err)
+		}
+		bexp2, err := rand.Int(rand.Reader, curve.Params().N)
+		if err != nil {
+			panic(err)
+		}
+		basex1, basey1 := curve.ScalarBaseMult(bexp1.Bytes())
+		basex2, basey2 := curve.ScalarBaseMult(bexp2.Bytes())
+
+		x1, y1 := curve.ScalarMult(basex1, basey1, exp.Bytes())
+		x2, y2 := curve.ScalarMult(basex2, basey2, exp.Bytes())
+
+		stmts[i] = LogEquivalenceStatement{
+			basex1, basey1, x1, y1, basex2, basey2, x2, y2, nil,
+		}
+		prfs[i] = LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2, nil)
+	}
+	return stmts, prfs
+}
+
+func TestBatchVerifyLogEquivalence(t *testing.T) {
+	stmts, prfs := randomLogEquivalence(10)
+	if !BatchVerifyLogEquivalence(stmts, prfs) {
+		t.Fatal("Batch log equivalence failed")
+	}
+	if !BatchVerifyLogEquivalence(nil, nil) {
+		t.Fatal("Empty batch failed")
+	}
+
+	// a single bad proof should fail the whole batch
+	bad := make([]byte, len(prfs[3]))
+	copy(bad, prfs[3])
+	bad[len(bad)-1] ^= 1
+	prfs[3] = bad
+	if BatchVerifyLogEquivalence(stmts, prfs) {
+		t.Fatal("Batch accepted a bad proof")
+	}
+
+	// so should a proof for the wrong statement
+	stmts, prfs = randomLogEquivalence(10)
+	prfs[0], prfs[1] = prfs[1], prfs[0]
+	if BatchVerifyLogEquivalence(stmts, prfs) {
+		t.Fatal("Batch accepted a swapped proof")
 	}
 }
 
@@ -102,7 +162,7 @@ func BenchmarkProveLogEquivalence(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2)
+		LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2, nil)
 	}
 }
 
@@ -126,12 +186,33 @@ func BenchmarkVerifyLogEquivalence(b *testing.B) {
 	x1, y1 := curve.ScalarMult(basex1, basey1, exp.Bytes())
 	x2, y2 := curve.ScalarMult(basex2, basey2, exp.Bytes())
 
-	prf := LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2)
+	prf := LogEquivalence(exp, basex1, basey1, x1, y1, basex2, basey2, x2, y2, nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		VerifyLogEquivalence(basex1, basey1, x1, y1,
-			basex2, basey2, x2, y2, prf)
+			basex2, basey2, x2, y2, prf, nil)
+	}
+}
+
+func BenchmarkVerifyLogEquivalence10(b *testing.B) {
+	stmts, prfs := randomLogEquivalence(10)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, st := range stmts {
+			VerifyLogEquivalence(st.Basex1, st.Basey1, st.X1, st.Y1,
+				st.Basex2, st.Basey2, st.X2, st.Y2, prfs[j], nil)
+		}
+	}
+}
+
+func BenchmarkBatchVerifyLogEquivalence10(b *testing.B) {
+	stmts, prfs := randomLogEquivalence(10)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerifyLogEquivalence(stmts, prfs)
 	}
 }
 
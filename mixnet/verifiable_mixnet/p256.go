@@ -0,0 +1,104 @@
+package verifiable_mixnet
+
+import (
+	"errors"
+	"math/big"
+
+	"filippo.io/nistec"
+)
+
+// Fixed-size, constant-time helpers around nistec for the decryption
+// and aggregation hot paths. Points are encoded as POINT_SIZE bytes x || y, and scalars
+// as SCALAR_SIZE bytes big-endian, same as P256KeyToBytes.
+
+const SCALAR_SIZE = 32
+
+// setP256Point decodes a x || y encoded point into p.
+func setP256Point(p *nistec.P256Point, b []byte) error {
+	if len(b) != POINT_SIZE {
+		return errors.New("Invalid point size")
+	}
+	var buf [1 + POINT_SIZE]byte
+	buf[0] = 4 // uncompressed
+	copy(buf[1:], b)
+	_, err := p.SetBytes(buf[:])
+	return err
+}
+
+// p256PointBytes encodes p as x || y into out.
+func p256PointBytes(out []byte, p *nistec.P256Point) error {
+	b := p.Bytes()
+	if len(b) != 1+POINT_SIZE {
+		return errors.New("Point at infinity")
+	}
+	copy(out, b[1:])
+	return nil
+}
+
+// bigToP256Point converts a crypto/elliptic point, where (0, 0) is the
+// point at infinity.
+func bigToP256Point(x, y *big.Int) (*nistec.P256Point, error) {
+	p := nistec.NewP256Point()
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return p, nil
+	}
+	var b [POINT_SIZE]byte
+	x.FillBytes(b[:POINT_SIZE/2])
+	y.FillBytes(b[POINT_SIZE/2:])
+	return p, setP256Point(p, b[:])
+}
+
+// p256PointToBig converts to a crypto/elliptic point. The point at
+// infinity is an error, as in p256PointBytes.
+func p256PointToBig(p *nistec.P256Point) (*big.Int, *big.Int, error) {
+	var b [POINT_SIZE]byte
+	if err := p256PointBytes(b[:], p); err != nil {
+		return nil, nil, err
+	}
+	return new(big.Int).SetBytes(b[:POINT_SIZE/2]), new(big.Int).SetBytes(b[POINT_SIZE/2:]), nil
+}
+
+// setP256Scalar left pads b into a fixed-size scalar.
+func setP256Scalar(s *[SCALAR_SIZE]byte, b []byte) error {
+	if len(b) > SCALAR_SIZE {
+		return errors.New("Invalid scalar size")
+	}
+	for i := 0; i < SCALAR_SIZE-len(b); i++ {
+		s[i] = 0
+	}
+	copy(s[SCALAR_SIZE-len(b):], b)
+	return nil
+}
+
+// multiScalarMult returns sum scalars[i] * points[i] using interleaved
+// 4-bit windows (Straus), so all points share the same doublings.
+// This is *not* constant time, and should only be used on public
+// values such as proof verification.
+func multiScalarMult(scalars [][SCALAR_SIZE]byte, points []*nistec.P256Point) *nistec.P256Point {
+	tables := make([][16]*nistec.P256Point, len(points))
+	for i, p := range points {
+		tables[i][0] = nistec.NewP256Point()
+		tables[i][1] = p
+		for j := 2; j < 16; j++ {
+			tables[i][j] = nistec.NewP256Point().Add(tables[i][j-1], p)
+		}
+	}
+
+	acc := nistec.NewP256Point()
+	for b := 0; b < SCALAR_SIZE; b++ {
+		for _, shift := range []uint{4, 0} {
+			if b > 0 || shift == 0 {
+				for d := 0; d < 4; d++ {
+					acc.Double(acc)
+				}
+			}
+			for i := range points {
+				w := (scalars[i][b] >> shift) & 0xf
+				if w != 0 {
+					acc.Add(acc, tables[i][w])
+				}
+			}
+		}
+	}
+	return acc
+}
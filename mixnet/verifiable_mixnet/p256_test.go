@@ -0,0 +1,210 @@
+package verifiable_mixnet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"log"
+	"math/big"
+	"sync"
+	"testing"
+
+	"filippo.io/nistec"
+)
+
+// bigP256DecryptionWorker is the math/big decryption worker, kept as a
+// reference for P256DecryptionWorker.
+func bigP256DecryptionWorker(nonce *[NONCE_SIZE]byte, auxSize int, wg *sync.WaitGroup, jobs chan DecryptionJob) {
+	theirKeyX, theirKeyY := new(big.Int), new(big.Int)
+	var sharedKey [SHARED_KEY_SIZE]byte
+	for job := range jobs {
+		theirKeyX.SetBytes(job.Ciphertext[:POINT_SIZE/2])
+		theirKeyY.SetBytes(job.Ciphertext[POINT_SIZE/2 : POINT_SIZE])
+		sharedX, _ := curve.ScalarMult(theirKeyX, theirKeyY, (*job.PrivateKey)[:])
+		sxb := sharedX.Bytes()
+		// explicitly zero out the rest, since we are reusing arrays
+		copy(sharedKey[SHARED_KEY_SIZE-len(sxb):], sxb)
+		for b := 0; b < SHARED_KEY_SIZE-len(sxb); b++ {
+			sharedKey[b] = 0
+		}
+
+		// hybrid layers carry a kem ciphertext after the aux data
+		offset := POINT_SIZE + auxSize
+		if job.KEMKey != nil {
+			kemCiphertext := job.Ciphertext[offset : offset+KEM_CIPHERTEXT_SIZE]
+			kemKey, err := job.KEMKey.Decapsulate(kemCiphertext)
+			if err != nil {
+				job.Result[job.Idx] = nil
+				log.Println("decapsulation failed")
+				wg.Done()
+				continue
+			}
+			HybridKey(&sharedKey, kemKey, kemCiphertext)
+			offset += KEM_CIPHERTEXT_SIZE
+		}
+
+		blindX, blindY := curve.ScalarMult(theirKeyX, theirKeyY, job.PrivateBlindKey)
+
+		bxb, byb := blindX.Bytes(), blindY.Bytes()
+		res := make([]byte, POINT_SIZE,
+			POINT_SIZE+len(job.Ciphertext)-offset-job.Suite.Overhead())
+		copy(res[POINT_SIZE/2-len(bxb):], bxb)
+		copy(res[POINT_SIZE-len(byb):], byb)
+
+		if job.ProdJob != nil {
+			job.ProdWg.Add(1)
+			job.ProdJob <- res[:POINT_SIZE]
+		}
+
+		// append to res
+		res, ok := job.Suite.Open(res, job.Ciphertext[offset:],
+			nonce, &sharedKey)
+		if !ok {
+			job.Result[job.Idx] = nil
+			log.Println("open failed")
+			wg.Done()
+			continue
+		}
+
+		job.Result[job.Idx] = res
+		wg.Done()
+	}
+}
+
+func TestP256DecryptionWorker(t *testing.T) {
+	publicKey, privateKey := P256KeyToBytes(GenerateP256Key())
+	_, blindKey := P256KeyToBytes(GenerateP256Key())
+	nonce := Nonce(0, 0, 0)
+
+	var priv [BOX_KEY_SIZE]byte
+	copy(priv[:], privateKey)
+
+	n := 20
+	ciphertexts := make([][]byte, n)
+	for i := range ciphertexts {
+		msg := make([]byte, 100)
+		rand.Read(msg)
+		ciphertexts[i], _ = P256OnionEncrypt(DefaultSuite, msg, [][]byte{nil}, [][]byte{nonce[:]}, [][]byte{publicKey}, false)
+	}
+	// invalid dh key
+	ciphertexts[0] = append(make([]byte, POINT_SIZE), ciphertexts[0][POINT_SIZE:]...)
+
+	results := make([][][]byte, 2)
+	for w, dw := range []DecryptionWorker{P256DecryptionWorker, bigP256DecryptionWorker} {
+		results[w] = make([][]byte, n)
+		wg := new(sync.WaitGroup)
+		jobs := make(chan DecryptionJob, n)
+		go dw(&nonce, 0, wg, jobs)
+
+		// first ciphertext is only checked against the new worker,
+		// the reference worker panics on points not on the curve
+		start := 0
+		if w == 1 {
+			start = 1
+		}
+		wg.Add(n - start)
+		for i := start; i < n; i++ {
+			jobs <- DecryptionJob{
+				Suite:           DefaultSuite,
+				PrivateKey:      &priv,
+				PrivateBlindKey: blindKey,
+				Ciphertext:      ciphertexts[i],
+				Idx:             i,
+				Result:          results[w],
+			}
+		}
+		wg.Wait()
+		close(jobs)
+	}
+
+	if results[0][0] != nil {
+		t.Error("Invalid dh key accepted")
+	}
+	for i := 1; i < n; i++ {
+		if results[0][i] == nil || !bytes.Equal(results[0][i], results[1][i]) {
+			t.Error("Decryption workers do not match")
+		}
+	}
+}
+
+// benchmarkHop measures the cost of peeling one layer on a server
+func benchmarkHop(b *testing.B, dw DecryptionWorker, hybrid bool) {
+	publicKey, privateKey := P256KeyToBytes(GenerateP256Key())
+	kemKey, kemPriv := GenerateKEMKey()
+	nonce := Nonce(0, 0, 0)
+	msg := make([]byte, 256)
+
+	var ciphertext []byte
+	var job DecryptionJob
+	if hybrid {
+		ciphertext, _ = P256HybridOnionEncrypt(DefaultSuite, msg, [][]byte{nil}, [][]byte{nonce[:]}, [][]byte{publicKey}, [][]byte{kemKey}, false)
+		job.KEMKey, _ = NewKEMPrivateKey(kemPriv)
+	} else {
+		ciphertext, _ = P256OnionEncrypt(DefaultSuite, msg, [][]byte{nil}, [][]byte{nonce[:]}, [][]byte{publicKey}, false)
+	}
+
+	var priv [BOX_KEY_SIZE]byte
+	copy(priv[:], privateKey)
+	job.Suite = DefaultSuite
+	job.PrivateKey = &priv
+	job.PrivateBlindKey = big.NewInt(1).Bytes()
+	job.Ciphertext = ciphertext
+	job.Result = make([][]byte, 1)
+
+	wg := new(sync.WaitGroup)
+	jobs := make(chan DecryptionJob, 1)
+	go dw(&nonce, 0, wg, jobs)
+	defer close(jobs)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		jobs <- job
+		wg.Wait()
+	}
+	if job.Result[0] == nil {
+		b.Error("Decryption failed")
+	}
+	b.ReportMetric(float64(len(ciphertext)-len(msg)-POINT_SIZE), "bytes/hop")
+}
+
+func BenchmarkBigP256Decryption(b *testing.B) {
+	benchmarkHop(b, bigP256DecryptionWorker, false)
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	for n := 0; n < 5; n++ {
+		scalars := make([][SCALAR_SIZE]byte, n)
+		points := make([]*nistec.P256Point, n)
+		ex, ey := new(big.Int), new(big.Int)
+		for i := range points {
+			k, err := rand.Int(rand.Reader, curve.Params().N)
+			if err != nil {
+				t.Fatal(err)
+			}
+			px, py := curve.ScalarBaseMult(k.Bytes())
+			points[i], err = bigToP256Point(px, py)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rand.Read(scalars[i][:])
+
+			x, y := curve.ScalarMult(px, py, scalars[i][:])
+			ex, ey = curve.Add(ex, ey, x, y)
+		}
+
+		x, y, err := p256PointToBig(multiScalarMult(scalars, points))
+		if n == 0 {
+			if err == nil {
+				t.Fatal("Encoded the point at infinity")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.Cmp(ex) != 0 || y.Cmp(ey) != 0 {
+			t.Fatal("Multi scalar multiplication mismatch for", n, "points")
+		}
+	}
+}
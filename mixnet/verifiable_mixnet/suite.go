@@ -0,0 +1,108 @@
+package verifiable_mixnet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Suite is the authenticated encryption used for a single onion layer.
+// The AEAD suites derive their keys from the shared key with the suite
+// name as the context, and the name of the suite of a chain is in the
+// transcript of the blind proof of every server of the chain (see
+// blindStatement), so a server that opened its layers with another
+// suite fails verification.
+type Suite interface {
+	// Name identifies the suite in the group configuration.
+	Name() string
+	// Overhead is the number of bytes Seal adds to a message.
+	Overhead() int
+
+	Seal(out, message []byte, nonce *[NONCE_SIZE]byte, key *[SHARED_KEY_SIZE]byte) []byte
+	Open(out, box []byte, nonce *[NONCE_SIZE]byte, key *[SHARED_KEY_SIZE]byte) ([]byte, bool)
+}
+
+var (
+	AES128CTRHMAC    Suite = aesHMACSuite{}
+	AES256GCMSIV     Suite = &aeadSuite{"aes256-gcm-siv", 32, newGCMSIV}
+	ChaCha20Poly1305 Suite = &aeadSuite{"chacha20-poly1305", chacha20poly1305.KeySize, chacha20poly1305.New}
+
+	// DefaultSuite is used when a group does not specify a suite.
+	DefaultSuite = AES128CTRHMAC
+)
+
+var suites = []Suite{AES128CTRHMAC, AES256GCMSIV, ChaCha20Poly1305}
+
+// SuiteByName returns the suite with the given name, or the default
+// suite if name is empty.
+func SuiteByName(name string) (Suite, error) {
+	if name == "" {
+		return DefaultSuite, nil
+	}
+	for _, suite := range suites {
+		if suite.Name() == name {
+			return suite, nil
+		}
+	}
+	return nil, errors.New("Unknown cipher suite " + name)
+}
+
+type aesHMACSuite struct{}
+
+func (aesHMACSuite) Name() string  { return "aes128-ctr-hmac-sha256" }
+func (aesHMACSuite) Overhead() int { return hmacOverhead }
+
+func (aesHMACSuite) Seal(out, message []byte, nonce *[NONCE_SIZE]byte, key *[SHARED_KEY_SIZE]byte) []byte {
+	return SecretSeal(out, message, nonce, key)
+}
+
+func (aesHMACSuite) Open(out, box []byte, nonce *[NONCE_SIZE]byte, key *[SHARED_KEY_SIZE]byte) ([]byte, bool) {
+	return SecretOpen(out, box, nonce, key)
+}
+
+// aeadSuite wraps a standard AEAD with a 12 byte nonce. The AEAD key
+// and nonce are both derived from the shared key and the layer nonce.
+type aeadSuite struct {
+	name    string
+	keySize int
+	newAEAD func(key []byte) (cipher.AEAD, error)
+}
+
+func (s *aeadSuite) Name() string  { return s.name }
+func (s *aeadSuite) Overhead() int { return aes.BlockSize }
+
+func (s *aeadSuite) aead(nonce *[NONCE_SIZE]byte, key *[SHARED_KEY_SIZE]byte) (cipher.AEAD, []byte) {
+	keys := hkdf.New(sha256.New, (*key)[:], (*nonce)[:], []byte(s.name))
+	aeadKey := make([]byte, s.keySize)
+	if _, err := io.ReadFull(keys, aeadKey); err != nil {
+		panic("Could not generate aead key")
+	}
+	aead, err := s.newAEAD(aeadKey)
+	if err != nil {
+		panic("Could not create new aead cipher")
+	}
+	aeadNonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(keys, aeadNonce); err != nil {
+		panic("Could not generate aead nonce")
+	}
+	return aead, aeadNonce
+}
+
+func (s *aeadSuite) Seal(out, message []byte, nonce *[NONCE_SIZE]byte, key *[SHARED_KEY_SIZE]byte) []byte {
+	aead, aeadNonce := s.aead(nonce, key)
+	return aead.Seal(out, aeadNonce, message, nil)
+}
+
+func (s *aeadSuite) Open(out, box []byte, nonce *[NONCE_SIZE]byte, key *[SHARED_KEY_SIZE]byte) ([]byte, bool) {
+	aead, aeadNonce := s.aead(nonce, key)
+	res, err := aead.Open(out, aeadNonce, box, nil)
+	if err != nil {
+		return nil, false
+	}
+	return res, true
+}
@@ -0,0 +1,146 @@
+package verifiable_mixnet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// test vectors from RFC 8452, appendix C
+func TestGCMSIVVectors(t *testing.T) {
+	vectors := []struct {
+		key, nonce, plaintext, result string
+	}{
+		{
+			"01000000000000000000000000000000",
+			"030000000000000000000000",
+			"",
+			"dc20e2d83f25705bb49e439eca56de25",
+		},
+		{
+			"01000000000000000000000000000000",
+			"030000000000000000000000",
+			"0100000000000000",
+			"b5d839330ac7b786578782fff6013b815b287c22493a364c",
+		},
+		{
+			"0100000000000000000000000000000000000000000000000000000000000000",
+			"030000000000000000000000",
+			"",
+			"07f5f4169bbf55a8400cd47ea6fd400f",
+		},
+		{
+			"0100000000000000000000000000000000000000000000000000000000000000",
+			"030000000000000000000000",
+			"0100000000000000",
+			"c2ef328e5c71c83b843122130f7364b761e0b97427e3df28",
+		},
+	}
+
+	for _, v := range vectors {
+		key, _ := hex.DecodeString(v.key)
+		nonce, _ := hex.DecodeString(v.nonce)
+		plaintext, _ := hex.DecodeString(v.plaintext)
+		result, _ := hex.DecodeString(v.result)
+
+		aead, err := newGCMSIV(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ciphertext := aead.Seal(nil, nonce, plaintext, nil)
+		if !bytes.Equal(ciphertext, result) {
+			t.Errorf("Wrong ciphertext %x, expected %s", ciphertext, v.result)
+		}
+		opened, err := aead.Open(nil, nonce, result, nil)
+		if err != nil {
+			t.Error(err)
+		} else if !bytes.Equal(opened, plaintext) {
+			t.Error("Decryption failed")
+		}
+	}
+}
+
+func TestSuites(t *testing.T) {
+	var key [SHARED_KEY_SIZE]byte
+	rand.Read(key[:])
+	nonce := Nonce(1, 2, 3)
+	msg := make([]byte, 100)
+	rand.Read(msg)
+
+	for _, suite := range suites {
+		found, err := SuiteByName(suite.Name())
+		if err != nil || found != suite {
+			t.Error("Could not find suite", suite.Name())
+		}
+
+		c := suite.Seal(nil, msg, &nonce, &key)
+		if len(c) != len(msg)+suite.Overhead() {
+			t.Error("Wrong overhead for", suite.Name())
+		}
+		res, ok := suite.Open(nil, c, &nonce, &key)
+		if !ok || !bytes.Equal(res, msg) {
+			t.Error("Decryption failed for", suite.Name())
+		}
+
+		c[0] ^= 1
+		_, ok = suite.Open(nil, c, &nonce, &key)
+		if ok {
+			t.Error("Tampered ciphertext opened for", suite.Name())
+		}
+	}
+
+	if _, err := SuiteByName("unknown"); err == nil {
+		t.Error("Unknown suite accepted")
+	}
+}
+
+func TestSingleGroupP256Suites(t *testing.T) {
+	K := 3
+	for _, suite := range suites {
+		mixes := make([]Mix, K)
+		publicKeys, privateKeys := make([][]byte, K), make([][]byte, K)
+		nonces := make([][]byte, K)
+		for i := range mixes {
+			publicKeys[i], privateKeys[i] = P256KeyToBytes(GenerateP256Key())
+			nonce := Nonce(0, 0, i)
+			nonces[i] = nonce[:]
+
+			mixes[i] = NewMix(P256DecryptionWorker)
+			err := mixes[i].NewRound(0, RoundConfiguration{Index: i, Suite: suite})
+			if err != nil {
+				t.Error(err)
+			}
+			mixes[i].SetRoundKey(0, publicKeys[i], privateKeys[i])
+			mixes[i].SetBlindKey(0, nil, big.NewInt(1).Bytes())
+		}
+
+		msgs := make([][]byte, 10)
+		ciphertexts := make([][]byte, len(msgs))
+		for i := range msgs {
+			msgs[i] = make([]byte, 100)
+			rand.Read(msgs[i])
+			ciphertexts[i], _ = P256OnionEncrypt(suite, msgs[i], make([][]byte, K), nonces, publicKeys, false)
+		}
+
+		res := ciphertexts
+		var err error
+		for i := range mixes {
+			err = mixes[i].AddMessages(0, res)
+			if err != nil {
+				t.Error(err)
+			}
+			res, err = mixes[i].Mix(0)
+			if err != nil {
+				t.Error(err)
+			}
+		}
+
+		for r := range res {
+			if res[r] == nil || !partOf(res[r][POINT_SIZE:], msgs) {
+				t.Error("Mixnet failed for", suite.Name())
+			}
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package mixnet
 
 import (
+	"crypto/mlkem"
 	"encoding/binary"
 	"errors"
 	"log"
 	"math/big"
 	"runtime"
 	"sync"
+
+	"github.com/kwonalbert/xrd/mixnet/verifiable_mixnet"
 )
 
 type Verifier interface {
@@ -20,9 +23,16 @@ type Verifier interface {
 
 	PrivateKey(round int) (*big.Int, error)
 
+	// KEMPublicKey and KEMPrivateKey return the ML-KEM keys of
+	// this round, or nil if the verifier is not hybrid.
+	KEMPublicKey(round int) ([]byte, error)
+
+	KEMPrivateKey(round int) ([]byte, error)
+
 	AddInnerCiphertexts(round int, msgs [][]byte) error
 
-	Finalize(round int, privateKeys [][]byte) ([][]byte, error)
+	// kemPrivateKeys is ignored if the verifier is not hybrid.
+	Finalize(round int, privateKeys [][]byte, kemPrivateKeys [][]byte) ([][]byte, error)
 }
 
 type verifier struct {
@@ -30,6 +40,8 @@ type verifier struct {
 
 	index     int
 	groupSize int
+	suite     verifiable_mixnet.Suite
+	hybrid    bool
 
 	smu    sync.RWMutex
 	states map[int]*verifierRoundState
@@ -45,18 +57,24 @@ type verifierRoundState struct {
 	publicX *big.Int
 	publicY *big.Int
 
+	// ml-kem keys of this round if hybrid
+	kemPublic  []byte
+	kemPrivate []byte
+
 	innerCiphertexts [][]byte
 
 	decJobs chan decryptionJob
 	done    bool
 }
 
-func NewVerifier(index int, groupSize int) Verifier {
+func NewVerifier(index int, groupSize int, suite verifiable_mixnet.Suite, hybrid bool) Verifier {
 	v := &verifier{
 		round: 0,
 
 		index:     index,
 		groupSize: groupSize,
+		suite:     suite,
+		hybrid:    hybrid,
 
 		states: make(map[int]*verifierRoundState),
 	}
@@ -87,6 +105,9 @@ func (ver *verifier) NewRound(round int) error {
 
 		done: false,
 	}
+	if ver.hybrid {
+		state.kemPublic, state.kemPrivate = verifiable_mixnet.GenerateKEMKey()
+	}
 	for i := 0; i < runtime.NumCPU()*2; i++ {
 		go decryptionWorker(round, state.decJobs)
 	}
@@ -147,7 +168,9 @@ func (ver *verifier) PublicKey(round int) (*big.Int, *big.Int, error) {
 }
 
 type decryptionJob struct {
+	suite      verifiable_mixnet.Suite
 	privateKey *big.Int
+	kemKeys    []*mlkem.DecapsulationKey768
 	ciphertext []byte
 	idx        int
 	errs       []error
@@ -165,7 +188,7 @@ func decryptionWorker(round int, jobs chan decryptionJob) {
 		xb, yb := ciphertext[:32], ciphertext[32:64]
 		msg := ciphertext[64:]
 		rx, ry := x.SetBytes(xb), y.SetBytes(yb)
-		plaintext, err := Decrypt(job.privateKey, &nonce, rx, ry, msg)
+		plaintext, err := HybridDecrypt(job.suite, job.privateKey, job.kemKeys, &nonce, rx, ry, msg)
 		job.results[job.idx] = plaintext
 		job.errs[job.idx] = err
 		job.wg.Done()
@@ -183,6 +206,26 @@ func (ver *verifier) PrivateKey(round int) (*big.Int, error) {
 	return state.private, nil
 }
 
+func (ver *verifier) KEMPublicKey(round int) ([]byte, error) {
+	ver.smu.RLock()
+	state, ok := ver.states[round]
+	ver.smu.RUnlock()
+	if !ok {
+		return nil, errors.New("Looking for non-existing keys")
+	}
+	return state.kemPublic, nil
+}
+
+func (ver *verifier) KEMPrivateKey(round int) ([]byte, error) {
+	ver.smu.RLock()
+	state, ok := ver.states[round]
+	ver.smu.RUnlock()
+	if !ok {
+		return nil, errors.New("Round not yet started")
+	}
+	return state.kemPrivate, nil
+}
+
 func (ver *verifier) AddInnerCiphertexts(round int, inners [][]byte) error {
 	ver.smu.RLock()
 	state, ok := ver.states[round]
@@ -196,7 +239,7 @@ func (ver *verifier) AddInnerCiphertexts(round int, inners [][]byte) error {
 	return nil
 }
 
-func (ver *verifier) Finalize(round int, privateKeys [][]byte) ([][]byte, error) {
+func (ver *verifier) Finalize(round int, privateKeys [][]byte, kemPrivateKeys [][]byte) ([][]byte, error) {
 	ver.smu.RLock()
 	state, ok := ver.states[round]
 	ver.smu.RUnlock()
@@ -213,6 +256,21 @@ func (ver *verifier) Finalize(round int, privateKeys [][]byte) ([][]byte, error)
 		aggKey = aggKey.Add(aggKey, new(big.Int).SetBytes(priv))
 	}
 
+	var kemKeys []*mlkem.DecapsulationKey768
+	if ver.hybrid {
+		if len(kemPrivateKeys) != ver.groupSize {
+			return nil, errors.New("Missing kem keys")
+		}
+		kemKeys = make([]*mlkem.DecapsulationKey768, len(kemPrivateKeys))
+		for i, priv := range kemPrivateKeys {
+			kemKey, err := verifiable_mixnet.NewKEMPrivateKey(priv)
+			if err != nil {
+				return nil, err
+			}
+			kemKeys[i] = kemKey
+		}
+	}
+
 	wg := new(sync.WaitGroup)
 	wg.Add(len(state.innerCiphertexts))
 	errs := make([]error, len(state.innerCiphertexts))
@@ -220,7 +278,9 @@ func (ver *verifier) Finalize(round int, privateKeys [][]byte) ([][]byte, error)
 
 	for c := range state.innerCiphertexts {
 		state.decJobs <- decryptionJob{
+			suite:      ver.suite,
 			privateKey: aggKey,
+			kemKeys:    kemKeys,
 			ciphertext: state.innerCiphertexts[c],
 			idx:        c,
 			errs:       errs,
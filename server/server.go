@@ -25,9 +25,12 @@ type server struct {
 
 	servers     map[string]*config.Server
 	mailboxes   map[string]*config.Server
+	ring        *mailbox.Ring
 	groups      map[string]*config.Group
 	myServers   map[string]*config.Server
 	lastServers map[string]*config.Server
+	// group ids of the last servers, to tag the mails they deliver
+	lastGids map[string]string
 
 	rerrs map[int]chan error
 
@@ -42,9 +45,11 @@ type server struct {
 // servers: map (list) of all server configurations
 // groups: map (list) of all group configurations
 // mailboxes: map (list) of all mailboxes
-func NewServer(addr string, coordinator ecdsa.PublicKey, mailboxes, servers map[string]*config.Server, groups map[string]*config.Group, mix mixnet.MixServer) XRDServer {
+// replicas: number of mailboxes holding each inbox
+func NewServer(addr string, coordinator ecdsa.PublicKey, mailboxes, servers map[string]*config.Server, groups map[string]*config.Group, replicas int, mix mixnet.MixServer) XRDServer {
 	myServers := make(map[string]*config.Server)
 	lastServers := make(map[string]*config.Server)
+	lastGids := make(map[string]string)
 	for _, group := range groups {
 		for s, sid := range group.Servers {
 			server := servers[sid]
@@ -54,6 +59,7 @@ func NewServer(addr string, coordinator ecdsa.PublicKey, mailboxes, servers map[
 			}
 			if server.Address == addr && s == len(group.Servers)-1 {
 				lastServers[sid] = server
+				lastGids[sid] = group.Gid
 			}
 		}
 	}
@@ -65,9 +71,11 @@ func NewServer(addr string, coordinator ecdsa.PublicKey, mailboxes, servers map[
 
 		servers:     servers,
 		mailboxes:   mailboxes,
+		ring:        mailbox.NewRing(mailboxes, replicas),
 		groups:      groups,
 		myServers:   myServers,
 		lastServers: lastServers,
+		lastGids:    lastGids,
 
 		rerrs: make(map[int]chan error),
 	}
@@ -91,7 +99,7 @@ func (srv *server) dialOnce() error {
 }
 
 // only called for the last servers in the chain
-func (srv *server) handleRound(round uint64, server *config.Server, mailboxMap map[[32]byte]string) error {
+func (srv *server) handleRound(round uint64, server *config.Server) error {
 	md := metadata.Pairs(
 		"id", server.Id,
 	)
@@ -136,8 +144,10 @@ func (srv *server) handleRound(round uint64, server *config.Server, mailboxMap m
 	for _, msg := range final.Plaintexts {
 		mail := mailbox.UnmarshalMail(msg)
 		copy(tmpKey[:], mail.UserKey)
-		dst := mailboxMap[tmpKey]
-		mails[dst] = append(mails[dst], mail)
+		// every replica of the inbox gets the mail
+		for _, dst := range srv.ring.Replicas(tmpKey) {
+			mails[dst] = append(mails[dst], mail)
+		}
 	}
 
 	for mid, ms := range mails {
@@ -147,20 +157,25 @@ func (srv *server) handleRound(round uint64, server *config.Server, mailboxMap m
 			}
 			spans := span.StreamSpan(len(ms), config.StreamSize, len(ms[0].Message)+32)
 
+			// a replica that is down catches up from the others later
 			stream, err := srv.mrpcs[mid].DeliverMails(context.Background())
 			if err != nil {
 				log.Println("Could not create stream to mailbox:", err)
+				return
 			}
 
 			for _, span := range spans {
 				req := &mailbox.DeliverMailsRequest{
-					Round: round,
-					Mails: ms[span.Start:span.End],
+					Round:  round,
+					Mails:  ms[span.Start:span.End],
+					Gid:    srv.lastGids[server.Id],
+					Server: server.Id,
 				}
 
 				err = stream.Send(req)
 				if err != nil {
 					log.Println("Could not stream to mailbox:", err)
+					break
 				}
 			}
 			_, err = stream.CloseAndRecv()
@@ -183,46 +198,20 @@ func (srv *server) NewRound(ctx context.Context, in *NewRoundRequest) (*NewRound
 	}
 
 	_, err := srv.mix.NewRound(context.Background(), &mixnet.NewRoundRequest{
-		Round: in.Round,
+		Round:    in.Round,
+		TokenKey: in.TokenKey,
+		Suites:   in.Suites,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var tmpKey [32]byte
-	mailboxMap := make(map[[32]byte]string)
-	for mid, rpc := range srv.mrpcs {
-		req := &mailbox.RegisteredUsersRequest{
-			Round: in.Round,
-		}
-		stream, err := rpc.RegisteredUsers(context.Background(), req)
-		if err != nil {
-			return nil, err
-		}
-
-		var keys [][]byte
-		for {
-			resp, err := stream.Recv()
-			if err == io.EOF {
-				break
-			} else if err != nil {
-				return nil, err
-			}
-			keys = append(keys, resp.UserKeys...)
-		}
-
-		for _, key := range keys {
-			copy(tmpKey[:], key)
-			mailboxMap[tmpKey] = mid
-		}
-	}
-
 	errs := make(chan error, len(srv.lastServers))
 	srv.rerrs[int(in.Round)] = errs
 
 	for _, server := range srv.lastServers {
 		go func(server *config.Server) {
-			errs <- srv.handleRound(in.Round, server, mailboxMap)
+			errs <- srv.handleRound(in.Round, server)
 		}(server)
 	}
 	return &NewRoundResponse{}, nil
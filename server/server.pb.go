@@ -1,17 +1,32 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: server.proto
 
+/*
+Package server is a generated protocol buffer package.
+
+It is generated from these files:
+	server.proto
+
+It has these top-level messages:
+	NewRoundRequest
+	NewRoundResponse
+	EndRoundRequest
+	EndRoundResponse
+	StartRoundRequest
+	StartRoundResponse
+*/
 package server
 
-import (
-	context "context"
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-	math "math"
-)
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
+
+import binary "encoding/binary"
+
+import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -22,39 +37,18 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type NewRoundRequest struct {
-	Round                uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *NewRoundRequest) Reset()         { *m = NewRoundRequest{} }
-func (m *NewRoundRequest) String() string { return proto.CompactTextString(m) }
-func (*NewRoundRequest) ProtoMessage()    {}
-func (*NewRoundRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ad098daeda4239f7, []int{0}
-}
-
-func (m *NewRoundRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewRoundRequest.Unmarshal(m, b)
-}
-func (m *NewRoundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewRoundRequest.Marshal(b, m, deterministic)
-}
-func (m *NewRoundRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewRoundRequest.Merge(m, src)
-}
-func (m *NewRoundRequest) XXX_Size() int {
-	return xxx_messageInfo_NewRoundRequest.Size(m)
-}
-func (m *NewRoundRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewRoundRequest.DiscardUnknown(m)
+	Round    uint64            `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
+	TokenKey []byte            `protobuf:"bytes,2,opt,name=token_key,json=tokenKey,proto3" json:"token_key,omitempty"`
+	Suites   map[string]string `protobuf:"bytes,3,rep,name=suites" json:"suites,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-var xxx_messageInfo_NewRoundRequest proto.InternalMessageInfo
+func (m *NewRoundRequest) Reset()                    { *m = NewRoundRequest{} }
+func (m *NewRoundRequest) String() string            { return proto.CompactTextString(m) }
+func (*NewRoundRequest) ProtoMessage()               {}
+func (*NewRoundRequest) Descriptor() ([]byte, []int) { return fileDescriptorServer, []int{0} }
 
 func (m *NewRoundRequest) GetRound() uint64 {
 	if m != nil {
@@ -63,68 +57,36 @@ func (m *NewRoundRequest) GetRound() uint64 {
 	return 0
 }
 
-type NewRoundResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *NewRoundRequest) GetTokenKey() []byte {
+	if m != nil {
+		return m.TokenKey
+	}
+	return nil
 }
 
-func (m *NewRoundResponse) Reset()         { *m = NewRoundResponse{} }
-func (m *NewRoundResponse) String() string { return proto.CompactTextString(m) }
-func (*NewRoundResponse) ProtoMessage()    {}
-func (*NewRoundResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ad098daeda4239f7, []int{1}
+func (m *NewRoundRequest) GetSuites() map[string]string {
+	if m != nil {
+		return m.Suites
+	}
+	return nil
 }
 
-func (m *NewRoundResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewRoundResponse.Unmarshal(m, b)
-}
-func (m *NewRoundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewRoundResponse.Marshal(b, m, deterministic)
-}
-func (m *NewRoundResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewRoundResponse.Merge(m, src)
-}
-func (m *NewRoundResponse) XXX_Size() int {
-	return xxx_messageInfo_NewRoundResponse.Size(m)
-}
-func (m *NewRoundResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewRoundResponse.DiscardUnknown(m)
+type NewRoundResponse struct {
 }
 
-var xxx_messageInfo_NewRoundResponse proto.InternalMessageInfo
+func (m *NewRoundResponse) Reset()                    { *m = NewRoundResponse{} }
+func (m *NewRoundResponse) String() string            { return proto.CompactTextString(m) }
+func (*NewRoundResponse) ProtoMessage()               {}
+func (*NewRoundResponse) Descriptor() ([]byte, []int) { return fileDescriptorServer, []int{1} }
 
 type EndRoundRequest struct {
-	Round                uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 }
 
-func (m *EndRoundRequest) Reset()         { *m = EndRoundRequest{} }
-func (m *EndRoundRequest) String() string { return proto.CompactTextString(m) }
-func (*EndRoundRequest) ProtoMessage()    {}
-func (*EndRoundRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ad098daeda4239f7, []int{2}
-}
-
-func (m *EndRoundRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EndRoundRequest.Unmarshal(m, b)
-}
-func (m *EndRoundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EndRoundRequest.Marshal(b, m, deterministic)
-}
-func (m *EndRoundRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EndRoundRequest.Merge(m, src)
-}
-func (m *EndRoundRequest) XXX_Size() int {
-	return xxx_messageInfo_EndRoundRequest.Size(m)
-}
-func (m *EndRoundRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_EndRoundRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_EndRoundRequest proto.InternalMessageInfo
+func (m *EndRoundRequest) Reset()                    { *m = EndRoundRequest{} }
+func (m *EndRoundRequest) String() string            { return proto.CompactTextString(m) }
+func (*EndRoundRequest) ProtoMessage()               {}
+func (*EndRoundRequest) Descriptor() ([]byte, []int) { return fileDescriptorServer, []int{2} }
 
 func (m *EndRoundRequest) GetRound() uint64 {
 	if m != nil {
@@ -134,67 +96,21 @@ func (m *EndRoundRequest) GetRound() uint64 {
 }
 
 type EndRoundResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EndRoundResponse) Reset()         { *m = EndRoundResponse{} }
-func (m *EndRoundResponse) String() string { return proto.CompactTextString(m) }
-func (*EndRoundResponse) ProtoMessage()    {}
-func (*EndRoundResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ad098daeda4239f7, []int{3}
-}
-
-func (m *EndRoundResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EndRoundResponse.Unmarshal(m, b)
-}
-func (m *EndRoundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EndRoundResponse.Marshal(b, m, deterministic)
-}
-func (m *EndRoundResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EndRoundResponse.Merge(m, src)
-}
-func (m *EndRoundResponse) XXX_Size() int {
-	return xxx_messageInfo_EndRoundResponse.Size(m)
-}
-func (m *EndRoundResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EndRoundResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_EndRoundResponse proto.InternalMessageInfo
+func (m *EndRoundResponse) Reset()                    { *m = EndRoundResponse{} }
+func (m *EndRoundResponse) String() string            { return proto.CompactTextString(m) }
+func (*EndRoundResponse) ProtoMessage()               {}
+func (*EndRoundResponse) Descriptor() ([]byte, []int) { return fileDescriptorServer, []int{3} }
 
 type StartRoundRequest struct {
-	Round                uint64   `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Round uint64 `protobuf:"fixed64,1,opt,name=round,proto3" json:"round,omitempty"`
 }
 
-func (m *StartRoundRequest) Reset()         { *m = StartRoundRequest{} }
-func (m *StartRoundRequest) String() string { return proto.CompactTextString(m) }
-func (*StartRoundRequest) ProtoMessage()    {}
-func (*StartRoundRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ad098daeda4239f7, []int{4}
-}
-
-func (m *StartRoundRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StartRoundRequest.Unmarshal(m, b)
-}
-func (m *StartRoundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StartRoundRequest.Marshal(b, m, deterministic)
-}
-func (m *StartRoundRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StartRoundRequest.Merge(m, src)
-}
-func (m *StartRoundRequest) XXX_Size() int {
-	return xxx_messageInfo_StartRoundRequest.Size(m)
-}
-func (m *StartRoundRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StartRoundRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_StartRoundRequest proto.InternalMessageInfo
+func (m *StartRoundRequest) Reset()                    { *m = StartRoundRequest{} }
+func (m *StartRoundRequest) String() string            { return proto.CompactTextString(m) }
+func (*StartRoundRequest) ProtoMessage()               {}
+func (*StartRoundRequest) Descriptor() ([]byte, []int) { return fileDescriptorServer, []int{4} }
 
 func (m *StartRoundRequest) GetRound() uint64 {
 	if m != nil {
@@ -204,35 +120,12 @@ func (m *StartRoundRequest) GetRound() uint64 {
 }
 
 type StartRoundResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StartRoundResponse) Reset()         { *m = StartRoundResponse{} }
-func (m *StartRoundResponse) String() string { return proto.CompactTextString(m) }
-func (*StartRoundResponse) ProtoMessage()    {}
-func (*StartRoundResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ad098daeda4239f7, []int{5}
-}
-
-func (m *StartRoundResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StartRoundResponse.Unmarshal(m, b)
-}
-func (m *StartRoundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StartRoundResponse.Marshal(b, m, deterministic)
-}
-func (m *StartRoundResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StartRoundResponse.Merge(m, src)
-}
-func (m *StartRoundResponse) XXX_Size() int {
-	return xxx_messageInfo_StartRoundResponse.Size(m)
-}
-func (m *StartRoundResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_StartRoundResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_StartRoundResponse proto.InternalMessageInfo
+func (m *StartRoundResponse) Reset()                    { *m = StartRoundResponse{} }
+func (m *StartRoundResponse) String() string            { return proto.CompactTextString(m) }
+func (*StartRoundResponse) ProtoMessage()               {}
+func (*StartRoundResponse) Descriptor() ([]byte, []int) { return fileDescriptorServer, []int{5} }
 
 func init() {
 	proto.RegisterType((*NewRoundRequest)(nil), "server.NewRoundRequest")
@@ -243,24 +136,6 @@ func init() {
 	proto.RegisterType((*StartRoundResponse)(nil), "server.StartRoundResponse")
 }
 
-func init() { proto.RegisterFile("server.proto", fileDescriptor_ad098daeda4239f7) }
-
-var fileDescriptor_ad098daeda4239f7 = []byte{
-	// 185 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0x4e, 0x2d, 0x2a,
-	0x4b, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0, 0x94, 0xd4, 0xb9, 0xf8,
-	0xfd, 0x52, 0xcb, 0x83, 0xf2, 0x4b, 0xf3, 0x52, 0x82, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x84,
-	0x44, 0xb8, 0x58, 0x8b, 0x40, 0x7c, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xb6, 0x20, 0x08, 0x47, 0x49,
-	0x88, 0x4b, 0x00, 0xa1, 0xb0, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0x15, 0xa4, 0xd9, 0x35, 0x2f, 0x85,
-	0x38, 0xcd, 0x08, 0x85, 0x50, 0xcd, 0x9a, 0x5c, 0x82, 0xc1, 0x25, 0x89, 0x45, 0x25, 0x44, 0x68,
-	0x17, 0xe1, 0x12, 0x42, 0x56, 0x0a, 0x31, 0xc0, 0xe8, 0x1c, 0x23, 0x17, 0x73, 0x44, 0x90, 0x8b,
-	0x90, 0x3d, 0x17, 0x07, 0xcc, 0x65, 0x42, 0xe2, 0x7a, 0x50, 0x5f, 0xa2, 0x79, 0x4a, 0x4a, 0x02,
-	0x53, 0x02, 0xea, 0x0e, 0x06, 0x90, 0x01, 0x30, 0xd7, 0x21, 0x0c, 0x40, 0xf3, 0x18, 0xc2, 0x00,
-	0x0c, 0x8f, 0x30, 0x08, 0xb9, 0x72, 0x71, 0x21, 0xdc, 0x27, 0x24, 0x09, 0x53, 0x89, 0xe1, 0x3d,
-	0x29, 0x29, 0x6c, 0x52, 0x30, 0x63, 0x92, 0xd8, 0xc0, 0x51, 0x63, 0x0c, 0x08, 0x00, 0x00, 0xff,
-	0xff, 0x1c, 0x2e, 0xa1, 0xe4, 0xaa, 0x01, 0x00, 0x00,
-}
-
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
 var _ grpc.ClientConn
@@ -269,9 +144,8 @@ var _ grpc.ClientConn
 // is compatible with the grpc package it is being compiled against.
 const _ = grpc.SupportPackageIsVersion4
 
-// XRDClient is the client API for XRD service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+// Client API for XRD service
+
 type XRDClient interface {
 	NewRound(ctx context.Context, in *NewRoundRequest, opts ...grpc.CallOption) (*NewRoundResponse, error)
 	EndRound(ctx context.Context, in *EndRoundRequest, opts ...grpc.CallOption) (*EndRoundResponse, error)
@@ -288,7 +162,7 @@ func NewXRDClient(cc *grpc.ClientConn) XRDClient {
 
 func (c *xRDClient) NewRound(ctx context.Context, in *NewRoundRequest, opts ...grpc.CallOption) (*NewRoundResponse, error) {
 	out := new(NewRoundResponse)
-	err := c.cc.Invoke(ctx, "/server.XRD/NewRound", in, out, opts...)
+	err := grpc.Invoke(ctx, "/server.XRD/NewRound", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -297,7 +171,7 @@ func (c *xRDClient) NewRound(ctx context.Context, in *NewRoundRequest, opts ...g
 
 func (c *xRDClient) EndRound(ctx context.Context, in *EndRoundRequest, opts ...grpc.CallOption) (*EndRoundResponse, error) {
 	out := new(EndRoundResponse)
-	err := c.cc.Invoke(ctx, "/server.XRD/EndRound", in, out, opts...)
+	err := grpc.Invoke(ctx, "/server.XRD/EndRound", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -306,34 +180,21 @@ func (c *xRDClient) EndRound(ctx context.Context, in *EndRoundRequest, opts ...g
 
 func (c *xRDClient) StartRound(ctx context.Context, in *StartRoundRequest, opts ...grpc.CallOption) (*StartRoundResponse, error) {
 	out := new(StartRoundResponse)
-	err := c.cc.Invoke(ctx, "/server.XRD/StartRound", in, out, opts...)
+	err := grpc.Invoke(ctx, "/server.XRD/StartRound", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// XRDServer is the server API for XRD service.
+// Server API for XRD service
+
 type XRDServer interface {
 	NewRound(context.Context, *NewRoundRequest) (*NewRoundResponse, error)
 	EndRound(context.Context, *EndRoundRequest) (*EndRoundResponse, error)
 	StartRound(context.Context, *StartRoundRequest) (*StartRoundResponse, error)
 }
 
-// UnimplementedXRDServer can be embedded to have forward compatible implementations.
-type UnimplementedXRDServer struct {
-}
-
-func (*UnimplementedXRDServer) NewRound(ctx context.Context, req *NewRoundRequest) (*NewRoundResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NewRound not implemented")
-}
-func (*UnimplementedXRDServer) EndRound(ctx context.Context, req *EndRoundRequest) (*EndRoundResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EndRound not implemented")
-}
-func (*UnimplementedXRDServer) StartRound(ctx context.Context, req *StartRoundRequest) (*StartRoundResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StartRound not implemented")
-}
-
 func RegisterXRDServer(s *grpc.Server, srv XRDServer) {
 	s.RegisterService(&_XRD_serviceDesc, srv)
 }
@@ -412,3 +273,840 @@ var _XRD_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "server.proto",
 }
+
+func (m *NewRoundRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NewRoundRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	if len(m.TokenKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintServer(dAtA, i, uint64(len(m.TokenKey)))
+		i += copy(dAtA[i:], m.TokenKey)
+	}
+	if len(m.Suites) > 0 {
+		for k, _ := range m.Suites {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Suites[k]
+			mapSize := 1 + len(k) + sovServer(uint64(len(k))) + 1 + len(v) + sovServer(uint64(len(v)))
+			i = encodeVarintServer(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintServer(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintServer(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	return i, nil
+}
+
+func (m *NewRoundResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NewRoundResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *EndRoundRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndRoundRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *EndRoundResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndRoundResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *StartRoundRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StartRoundRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x9
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *StartRoundResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StartRoundResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func encodeVarintServer(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *NewRoundRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	l = len(m.TokenKey)
+	if l > 0 {
+		n += 1 + l + sovServer(uint64(l))
+	}
+	if len(m.Suites) > 0 {
+		for k, v := range m.Suites {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovServer(uint64(len(k))) + 1 + len(v) + sovServer(uint64(len(v)))
+			n += mapEntrySize + 1 + sovServer(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *NewRoundResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *EndRoundRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *EndRoundResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *StartRoundRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *StartRoundResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func sovServer(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozServer(x uint64) (n int) {
+	return sovServer(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NewRoundRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewRoundRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewRoundRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowServer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthServer
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenKey = append(m.TokenKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.TokenKey == nil {
+				m.TokenKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suites", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowServer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthServer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Suites == nil {
+				m.Suites = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowServer
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowServer
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthServer
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowServer
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthServer
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipServer(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthServer
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Suites[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NewRoundResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewRoundResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewRoundResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndRoundRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndRoundRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndRoundRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndRoundResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndRoundResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndRoundResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StartRoundRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartRoundRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartRoundRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StartRoundResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowServer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartRoundResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartRoundResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipServer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthServer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipServer(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowServer
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowServer
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowServer
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthServer
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowServer
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipServer(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthServer = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowServer   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto.RegisterFile("server.proto", fileDescriptorServer) }
+
+var fileDescriptorServer = []byte{
+	// 300 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0x4e, 0x2d, 0x2a,
+	0x4b, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0, 0x94, 0xf6, 0x30, 0x72,
+	0xf1, 0xfb, 0xa5, 0x96, 0x07, 0xe5, 0x97, 0xe6, 0xa5, 0x04, 0xa5, 0x16, 0x96, 0xa6, 0x16, 0x97,
+	0x08, 0x89, 0x70, 0xb1, 0x16, 0x81, 0xf8, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x6c, 0x41, 0x10, 0x8e,
+	0x90, 0x34, 0x17, 0x67, 0x49, 0x7e, 0x76, 0x6a, 0x5e, 0x7c, 0x76, 0x6a, 0xa5, 0x04, 0x93, 0x02,
+	0xa3, 0x06, 0x4f, 0x10, 0x07, 0x58, 0xc0, 0x3b, 0xb5, 0x52, 0xc8, 0x9a, 0x8b, 0xad, 0xb8, 0x34,
+	0xb3, 0x24, 0xb5, 0x58, 0x82, 0x59, 0x81, 0x59, 0x83, 0xdb, 0x48, 0x59, 0x0f, 0x6a, 0x1b, 0x9a,
+	0xd9, 0x7a, 0xc1, 0x60, 0x55, 0xae, 0x79, 0x25, 0x45, 0x95, 0x41, 0x50, 0x2d, 0x52, 0x96, 0x5c,
+	0xdc, 0x48, 0xc2, 0x42, 0x02, 0x5c, 0xcc, 0x20, 0x2b, 0x40, 0x96, 0x73, 0x06, 0x81, 0x98, 0x20,
+	0x07, 0x95, 0x25, 0xe6, 0x94, 0xa6, 0x82, 0xad, 0xe5, 0x0c, 0x82, 0x70, 0xac, 0x98, 0x2c, 0x18,
+	0x95, 0x84, 0xb8, 0x04, 0x10, 0x36, 0x14, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x2a, 0xa9, 0x73, 0xf1,
+	0xbb, 0xe6, 0xa5, 0x10, 0xf6, 0x11, 0x48, 0x33, 0x42, 0x21, 0x54, 0xb3, 0x26, 0x97, 0x60, 0x70,
+	0x49, 0x62, 0x51, 0x09, 0x11, 0xda, 0x45, 0xb8, 0x84, 0x90, 0x95, 0x42, 0x0c, 0x30, 0x3a, 0xc7,
+	0xc8, 0xc5, 0x1c, 0x11, 0xe4, 0x22, 0x64, 0xcf, 0xc5, 0x01, 0x73, 0x99, 0x90, 0x38, 0x8e, 0xd0,
+	0x90, 0x92, 0xc0, 0x94, 0x80, 0xba, 0x83, 0x01, 0x64, 0x00, 0xcc, 0x75, 0x08, 0x03, 0xd0, 0x3c,
+	0x26, 0x25, 0x81, 0x29, 0x01, 0x37, 0xc0, 0x95, 0x8b, 0x0b, 0xe1, 0x3e, 0x21, 0x49, 0x98, 0x4a,
+	0x0c, 0xef, 0x49, 0x49, 0x61, 0x93, 0x82, 0x19, 0xe3, 0x24, 0x70, 0xe2, 0x91, 0x1c, 0xe3, 0x85,
+	0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x90, 0xc4, 0x06, 0x4e, 0x42,
+	0xc6, 0x80, 0x01, 0x00, 0x3b, 0x14, 0xd4, 0x76, 0x52, 0x02, 0x00, 0x00,
+}
@@ -1,11 +1,14 @@
 package xrd
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime/pprof"
 	"strings"
 	"testing"
@@ -18,6 +21,7 @@ import (
 	"github.com/kwonalbert/xrd/mixnet"
 	"github.com/kwonalbert/xrd/server"
 
+	"golang.org/x/crypto/nacl/box"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -35,30 +39,34 @@ func profile() {
 	}
 }
 
+// shifts all the ports, so that each test gets its own network
+var portOffset = 0
+
 func serverAddr(idx int) string {
-	return fmt.Sprintf("localhost:%d", 8000+idx)
+	return fmt.Sprintf("localhost:%d", 8000+portOffset+idx)
 }
 
 func mailboxAddr(idx int) string {
-	return fmt.Sprintf("localhost:%d", 8500+idx)
+	return fmt.Sprintf("localhost:%d", 8500+portOffset+idx)
 }
 
 func clientAddr(idx int) string {
-	return fmt.Sprintf("localhost:%d", 9000+idx)
+	return fmt.Sprintf("localhost:%d", 9000+portOffset+idx)
 }
 
 func port(addr string) string {
 	return ":" + strings.Split(addr, ":")[1]
 }
 
-func createMailboxes(coordinator ecdsa.PublicKey, mcfgs map[string]*config.Server) map[string]mailbox.MailboxServer {
+func createMailboxes(coordinator ecdsa.PublicKey, mcfgs map[string]*config.Server, gcfgs map[string]*config.Group, replicas int) map[string]mailbox.MailboxServer {
 	mailboxes := make(map[string]mailbox.MailboxServer)
 	for id := range mcfgs {
-		mailboxes[id] = mailbox.NewMailboxServer(coordinator)
+		replication := mailbox.Replication{Id: id, Mailboxes: mcfgs, Factor: replicas}
+		mailboxes[id] = mailbox.NewMailboxServer(coordinator, mailbox.NewMemoryStore(), mailbox.Retention{}, replication, mailbox.Tokens{Groups: gcfgs})
 	}
 	for id, cfg := range mcfgs {
 		go func(id string, cfg *config.Server) {
-			cred := credentials.NewServerTLSFromCert(config.FindCertificate(cfg.Address, mcfgs))
+			cred := mailbox.ServerCredentials(cfg.Address, mcfgs)
 			grpcServer := grpc.NewServer(grpc.Creds(cred))
 			mailbox.RegisterMailboxServer(grpcServer, mailboxes[id])
 
@@ -78,12 +86,16 @@ func createMailboxes(coordinator ecdsa.PublicKey, mcfgs map[string]*config.Serve
 	return mailboxes
 }
 
-func createClients(ccfgs, mcfgs, scfgs map[string]*config.Server, gcfgs map[string]*config.Group) map[string]client.ClientServer {
+func createClients(ccfgs, mcfgs, scfgs map[string]*config.Server, gcfgs map[string]*config.Group, replicas int, pir bool) map[string]client.ClientServer {
 	clients := make(map[string]client.ClientServer)
 	for id := range ccfgs {
-		clients[id] = client.NewClient(mcfgs, scfgs, gcfgs)
+		clients[id] = client.NewClient(mcfgs, scfgs, gcfgs, replicas, pir, nil)
 	}
+	serveClients(ccfgs, clients)
+	return clients
+}
 
+func serveClients(ccfgs map[string]*config.Server, clients map[string]client.ClientServer) {
 	for id, cfg := range ccfgs {
 		go func(id string, cfg *config.Server) {
 			cred := credentials.NewServerTLSFromCert(config.FindCertificate(cfg.Address, ccfgs))
@@ -102,11 +114,9 @@ func createClients(ccfgs, mcfgs, scfgs map[string]*config.Server, gcfgs map[stri
 		}(id, cfg)
 	}
 	time.Sleep(time.Millisecond)
-
-	return clients
 }
 
-func createServers(coordinator ecdsa.PublicKey, mcfgs, scfgs map[string]*config.Server, gcfgs map[string]*config.Group) map[string]server.XRDServer {
+func createServers(coordinator ecdsa.PublicKey, mcfgs, scfgs map[string]*config.Server, gcfgs map[string]*config.Group, replicas int) map[string]server.XRDServer {
 	// only one server per adddress
 	servers := make(map[string]server.XRDServer)
 	mixes := make(map[string]mixnet.MixServer)
@@ -116,7 +126,7 @@ func createServers(coordinator ecdsa.PublicKey, mcfgs, scfgs map[string]*config.
 			continue
 		}
 		mixes[addr] = mixnet.NewMixServer(cfg.Address, coordinator, scfgs, gcfgs)
-		servers[addr] = server.NewServer(cfg.Address, coordinator, mcfgs, scfgs, gcfgs, mixes[addr])
+		servers[addr] = server.NewServer(cfg.Address, coordinator, mcfgs, scfgs, gcfgs, replicas, mixes[addr])
 	}
 
 	for addr := range servers {
@@ -178,20 +188,31 @@ func createNetworkConfig(numBoxes, numClients, groupSize, numGroups int) (map[st
 }
 
 func TestXRD(t *testing.T) {
-	numMailboxes := 2
-	numClients := 2
-	groupSize := 8
-	numGroups := 4
-	numUsers := 1000
+	testXRD(t, 2, 1, 2, 8, 4, 1000, false, false)
+}
+
+func TestXRDPIR(t *testing.T) {
+	portOffset = 100
+	defer func() { portOffset = 0 }()
+	testXRD(t, 3, 3, 2, 3, 2, 200, true, false)
+}
+
+func TestXRDReplicas(t *testing.T) {
+	portOffset = 200
+	defer func() { portOffset = 0 }()
+	testXRD(t, 3, 2, 2, 3, 2, 200, false, true)
+}
+
+func testXRD(t *testing.T, numMailboxes, replicas, numClients, groupSize, numGroups, numUsers int, pir, enforce bool) {
 	msgSize := 256
 
 	mcfgs, ccfgs, scfgs, gcfgs := createNetworkConfig(numMailboxes, numClients, groupSize, numGroups)
 
-	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs)
+	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs, time.Minute, enforce)
 
-	createMailboxes(coordinator.PublicKey(), mcfgs)
-	createServers(coordinator.PublicKey(), mcfgs, scfgs, gcfgs)
-	createClients(ccfgs, mcfgs, scfgs, gcfgs)
+	createMailboxes(coordinator.PublicKey(), mcfgs, gcfgs, replicas)
+	createServers(coordinator.PublicKey(), mcfgs, scfgs, gcfgs, replicas)
+	createClients(ccfgs, mcfgs, scfgs, gcfgs, replicas, pir)
 
 	for i := 0; i < 2; i++ {
 		log.Println("Setup new rounds")
@@ -221,3 +242,391 @@ func TestXRD(t *testing.T) {
 		}
 	}
 }
+
+func TestXRDUsers(t *testing.T) {
+	portOffset = 300
+	defer func() { portOffset = 0 }()
+	msgSize := 256
+
+	mcfgs, ccfgs, scfgs, gcfgs := createNetworkConfig(2, 3, 2, 4)
+	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs, time.Minute, true)
+
+	createMailboxes(coordinator.PublicKey(), mcfgs, gcfgs, 1)
+	createServers(coordinator.PublicKey(), mcfgs, scfgs, gcfgs, 1)
+
+	// two real users talking, among simulated ones, all with keystores
+	dir := t.TempDir()
+	passphrase := []byte("passphrase")
+	keystores := make([]client.Keystore, 3)
+	for i := range keystores {
+		var err error
+		keystores[i], err = client.OpenKeystore(filepath.Join(dir, fmt.Sprint(i)), passphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	pubs := make([]*[32]byte, 2)
+	for i := range pubs {
+		ids, _, err := keystores[i].Identities(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubs[i] = ids[0]
+	}
+	for i := range pubs {
+		if err := keystores[i].AddContact(pubs[i], "peer", pubs[1-i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.OpenKeystore(filepath.Join(dir, "0"), []byte("wrong")); err != client.ErrWrongPassphrase {
+		t.Fatal("Opened a keystore with a wrong passphrase")
+	}
+
+	// the first payload is queued before a restart
+	payloads := [][]string{{"hello", ""}, {"", "hi back"}}
+	early, err := client.LoadUser(keystores[0], mcfgs, scfgs, gcfgs, 1, msgSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := early.Send(pubs[1], []byte(payloads[0][0])); err != nil {
+		t.Fatal(err)
+	}
+
+	users := make([]client.User, 2)
+	for i := range users {
+		keys, err := client.OpenKeystore(filepath.Join(dir, fmt.Sprint(i)), passphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		users[i], err = client.LoadUser(keys, mcfgs, scfgs, gcfgs, 1, msgSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *users[i].PublicKey() != *pubs[i] {
+			t.Fatal("Identity not kept")
+		}
+	}
+	serveClients(ccfgs, map[string]client.ClientServer{
+		"client:0": client.NewClient(mcfgs, scfgs, gcfgs, 1, false, keystores[2]),
+		"client:1": users[0],
+		"client:2": users[1],
+	})
+
+	for round := 0; round < 2; round++ {
+		for i, user := range users {
+			if payloads[i][round] == "" || i == 0 && round == 0 {
+				continue
+			}
+			_, err := user.Send(users[1-i].PublicKey(), []byte(payloads[i][round]))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := coordinator.NewRound(round, 50); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.GenerateMessages(round, msgSize); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.SubmitMessages(round); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.StartExperiment(round); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, user := range users {
+			sent := payloads[1-i][round]
+			select {
+			case msg := <-user.Receive():
+				if sent == "" || string(msg.Payload) != sent || msg.From != *users[1-i].PublicKey() {
+					t.Fatal("Wrong message", round, i, string(msg.Payload))
+				}
+			default:
+				if sent != "" {
+					t.Fatal("Message not received", round, i)
+				}
+			}
+		}
+	}
+}
+
+func TestXRDDial(t *testing.T) {
+	portOffset = 400
+	defer func() { portOffset = 0 }()
+	msgSize := 256
+
+	mcfgs, ccfgs, scfgs, gcfgs := createNetworkConfig(2, 3, 2, 4)
+	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs, time.Minute, true)
+
+	createMailboxes(coordinator.PublicKey(), mcfgs, gcfgs, 1)
+	createServers(coordinator.PublicKey(), mcfgs, scfgs, gcfgs, 1)
+
+	// two strangers, who meet in a dial round and talk after
+	users := make([]client.User, 2)
+	for i := range users {
+		pub, priv, err := box.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		users[i] = client.NewUser(pub, priv, mcfgs, scfgs, gcfgs, 1, msgSize)
+	}
+	serveClients(ccfgs, map[string]client.ClientServer{
+		"client:0": client.NewClient(mcfgs, scfgs, gcfgs, 1, false, nil),
+		"client:1": users[0],
+		"client:2": users[1],
+	})
+
+	if err := users[0].Dial(users[1].PublicKey()); err != nil {
+		t.Fatal(err)
+	}
+	for round := 0; round < 2; round++ {
+		newRound := coordinator.NewRound
+		if round == 0 {
+			newRound = coordinator.NewDialRound
+		} else if _, err := users[1].Send(users[0].PublicKey(), []byte("nice to meet you")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := newRound(round, 50); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.GenerateMessages(round, msgSize); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.SubmitMessages(round); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.StartExperiment(round); err != nil {
+			t.Fatal(err)
+		}
+
+		// the callee learns of the caller, then the caller hears back
+		receiver, sender := users[1], users[0]
+		if round == 1 {
+			receiver, sender = users[0], users[1]
+		}
+		select {
+		case msg := <-receiver.Receive():
+			if msg.From != *sender.PublicKey() || msg.Dial != (round == 0) {
+				t.Fatal("Wrong message", round, msg.Dial)
+			}
+			if round == 1 && string(msg.Payload) != "nice to meet you" {
+				t.Fatal("Wrong payload", string(msg.Payload))
+			}
+		default:
+			t.Fatal("Message not received", round)
+		}
+		select {
+		case <-sender.Receive():
+			t.Fatal("Unexpected message", round)
+		default:
+		}
+	}
+}
+
+func TestXRDConversations(t *testing.T) {
+	portOffset = 450
+	defer func() { portOffset = 0 }()
+	msgSize := 256
+
+	mcfgs, ccfgs, scfgs, gcfgs := createNetworkConfig(2, 4, 2, 4)
+	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs, time.Minute, false)
+
+	createMailboxes(coordinator.PublicKey(), mcfgs, gcfgs, 1)
+	createServers(coordinator.PublicKey(), mcfgs, scfgs, gcfgs, 1)
+
+	// three users that all talk with each other, which is as many peers
+	// as users get with two chains a round
+	users := make([]client.User, 3)
+	for i := range users {
+		pub, priv, err := box.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		users[i] = client.NewUser(pub, priv, mcfgs, scfgs, gcfgs, 1, msgSize)
+	}
+	for i, user := range users {
+		for j, peer := range users {
+			if i == j {
+				continue
+			}
+			if err := user.AddPeer(peer.PublicKey()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	var strangers []*[32]byte
+	for err := error(nil); err != client.ErrTooManyPeers; {
+		if len(strangers) == len(gcfgs) {
+			t.Fatal("Added more peers than chains")
+		}
+		stranger, _, kerr := box.GenerateKey(rand.Reader)
+		if kerr != nil {
+			t.Fatal(kerr)
+		}
+		strangers = append(strangers, stranger)
+		err = users[0].AddPeer(stranger)
+	}
+	for _, stranger := range strangers {
+		users[0].RemovePeer(stranger)
+	}
+
+	clients := map[string]client.ClientServer{
+		"client:0": client.NewClient(mcfgs, scfgs, gcfgs, 1, false, nil),
+	}
+	for i, user := range users {
+		clients[fmt.Sprintf("client:%d", i+1)] = user
+	}
+	serveClients(ccfgs, clients)
+
+	for round := 0; round < 2; round++ {
+		for i, user := range users {
+			for j, peer := range users {
+				if i != j && (round == 0 || i == 0) {
+					_, err := user.Send(peer.PublicKey(), []byte(fmt.Sprint(round, i, j)))
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+		}
+
+		if err := coordinator.NewRound(round, 50); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.GenerateMessages(round, msgSize); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.SubmitMessages(round); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.StartExperiment(round); err != nil {
+			t.Fatal(err)
+		}
+
+		for j, user := range users {
+			got := make(map[string]bool)
+		receive:
+			for {
+				select {
+				case msg := <-user.Receive():
+					got[string(msg.Payload)] = true
+				default:
+					break receive
+				}
+			}
+			for i := range users {
+				sent := i != j && (round == 0 || i == 0)
+				if got[fmt.Sprint(round, i, j)] != sent {
+					t.Fatal("Wrong messages", round, i, j, got)
+				}
+			}
+			if round == 1 && j != 0 && len(got) != 1 || round == 0 && len(got) != len(users)-1 {
+				t.Fatal("Wrong number of messages", round, j, got)
+			}
+		}
+	}
+}
+
+func TestXRDFragments(t *testing.T) {
+	portOffset = 350
+	defer func() { portOffset = 0 }()
+	msgSize := 256
+
+	mcfgs, ccfgs, scfgs, gcfgs := createNetworkConfig(2, 3, 2, 4)
+	coordinator := coordinator.NewCoordinator(mcfgs, ccfgs, scfgs, gcfgs, time.Minute, false)
+
+	createMailboxes(coordinator.PublicKey(), mcfgs, gcfgs, 1)
+	createServers(coordinator.PublicKey(), mcfgs, scfgs, gcfgs, 1)
+
+	// a payload of several messages one way, and a short one the other
+	users := make([]client.User, 2)
+	for i := range users {
+		pub, priv, err := box.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		users[i] = client.NewUser(pub, priv, mcfgs, scfgs, gcfgs, 1, msgSize)
+	}
+	serveClients(ccfgs, map[string]client.ClientServer{
+		"client:0": client.NewClient(mcfgs, scfgs, gcfgs, 1, false, nil),
+		"client:1": users[0],
+		"client:2": users[1],
+	})
+
+	long := make([]byte, 3*msgSize)
+	if _, err := rand.Read(long); err != nil {
+		t.Fatal(err)
+	}
+	longID, err := users[0].Send(users[1].PublicKey(), long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shortID, err := users[1].Send(users[0].PublicKey(), []byte("short"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// until the long payload is acknowledged, a round after it is
+	// reassembled
+	var got []byte
+	for round := 0; ; round++ {
+		if round == 8 {
+			t.Fatal("Payload not acknowledged")
+		}
+		if err := coordinator.NewRound(round, 50); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.GenerateMessages(round, msgSize); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.SubmitMessages(round); err != nil {
+			t.Fatal(err)
+		}
+		if err := coordinator.StartExperiment(round); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case msg := <-users[0].Receive():
+			if round != 0 || string(msg.Payload) != "short" {
+				t.Fatal("Wrong message", round, string(msg.Payload))
+			}
+		default:
+			if round == 0 {
+				t.Fatal("Short payload not received")
+			}
+		}
+		select {
+		case msg := <-users[1].Receive():
+			if round == 0 {
+				t.Fatal("Payload received before all of its fragments")
+			}
+			got = msg.Payload
+		default:
+		}
+
+		status, err := users[1].Status(users[0].PublicKey(), shortID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if round == 0 && status != client.Status_DELIVERED || round > 0 && status != client.Status_ACKNOWLEDGED {
+			t.Fatal("Wrong status", round, status)
+		}
+		status, err = users[0].Status(users[1].PublicKey(), longID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status == client.Status_ACKNOWLEDGED {
+			if got == nil {
+				t.Fatal("Acknowledged before reassembled", round)
+			}
+			break
+		}
+	}
+	if !bytes.Equal(got, long) {
+		t.Fatal("Wrong payload")
+	}
+}